@@ -1,25 +1,51 @@
 package middlewares
 
 import (
-	"fmt"
-	"github.com/golang-jwt/jwt/v5"
+	"errors"
 	"google.golang.org/grpc/metadata"
+	"log"
 	"net/http"
-	"os"
+	"socialnet/pkg/utils"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// identityHeaders — выставляются только gateway по проверенному токену,
+// присланные клиентом значения отбрасываются (иначе можно выдать себя за модератора)
+var identityHeaders = []string{
+	"Grpc-Metadata-User-Id",
+	"Grpc-Metadata-Username",
+	"Grpc-Metadata-Email-Verified",
+	"Grpc-Metadata-User-Role",
+	"Grpc-Metadata-Session-Id",
+	"Grpc-Metadata-Actor-Id",
+}
+
 var publicPaths = []string{
 	"/api/v1/auth/login",
 	"/api/v1/auth/register",
 	"/api/v1/auth/refresh",
-	"/api/v1/auth/update-password",
 	"/api/v1/auth/forgot-password",
 	"/api/v1/auth/reset-password",
+	"/api/v1/auth/magic-link",
+	"/api/v1/auth/oidc/providers",
+	"/api/v1/auth/oidc/authorize/",
+	"/api/v1/auth/oidc/callback/",
+	"/api/v1/auth/verify-email",
+	"/api/v1/auth/email/confirm",
+	"/api/v1/auth/2fa/verify",
+	"/.well-known/jwks.json",
 }
 
-func AuthMiddleware(next http.Handler) http.Handler {
+// AuthMiddleware — принимает access токены (JWT, проверка по JWKS auth сервиса, отзыв — через
+// IntrospectionCache) и personal access токены (проверка в auth, доступ ограничен правами токена)
+func AuthMiddleware(keys *JWKSCache, revocations *IntrospectionCache, pats *PATCache, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, h := range identityHeaders {
+			r.Header.Del(h)
+		}
+
 		for _, path := range publicPaths {
 			if strings.HasPrefix(r.URL.Path, path) {
 				next.ServeHTTP(w, r)
@@ -40,35 +66,86 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		}
 		tokenString := parts[1]
 
-		jwtSecret := os.Getenv("JWT_SECRET")
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		var userId, username, role, sessionID, actorID string
+		var emailVerified bool
+
+		if strings.HasPrefix(tokenString, utils.PersonalTokenPrefix) {
+			identity, err := pats.Validate(r.Context(), tokenString)
+			if errors.Is(err, ErrInvalidToken) {
+				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				log.Printf("⚠️ personal access token check failed: %v", err)
+				http.Error(w, "Authentication unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			scope, allowed := requiredScope(r.Method, r.URL.Path)
+			if !allowed || !hasScope(identity.Scopes, scope) {
+				http.Error(w, "Token scope does not allow this request", http.StatusForbidden)
+				return
+			}
+			userId, username, role, emailVerified = identity.UserID, identity.Username, identity.Role, identity.EmailVerified
+		} else {
+			claims, err := keys.Parse(r.Context(), tokenString)
+			if err != nil {
+				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+			exp, _ := claims.GetExpirationTime()
+			var expiresAt time.Time
+			if exp != nil {
+				expiresAt = exp.Time
+			}
+			active, err := revocations.Active(r.Context(), tokenString, expiresAt)
+			if err != nil {
+				// токен имперсонации без проверки отзыва не пропускаем никогда: его завершают первым делом
+				_, impersonated := claims["act"]
+				if !revocations.FailOpen || impersonated {
+					log.Printf("⚠️ token introspection failed: %v", err)
+					http.Error(w, "Authentication unavailable", http.StatusServiceUnavailable)
+					return
+				}
+				log.Printf("⚠️ token introspection failed, revocation not checked: %v", err)
+			} else if !active {
+				http.Error(w, "Token has been revoked", http.StatusUnauthorized)
+				return
+			}
+			userId, _ = claims["sub"].(string)
+			username, _ = claims["name"].(string)
+			emailVerified, _ = claims["email_verified"].(bool)
+			role, _ = claims["role"].(string)
+			sessionID, _ = claims["sid"].(string)
+			// имперсонация: сервисы видят пользователя, а настоящий автор запроса идёт отдельно
+			if act, ok := claims["act"].(map[string]interface{}); ok {
+				actorID, _ = act["sub"].(string)
 			}
-			return []byte(jwtSecret), nil
-		})
-		if err != nil || !token.Valid {
-			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
-			return
 		}
-
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			http.Error(w, "Invalid token claims", http.StatusUnauthorized)
-			return
+		if role == "" {
+			// токены, выпущенные до появления ролей
+			role = utils.RoleUser
 		}
 
-		userId, _ := claims["sub"].(string)
-		username, _ := claims["name"].(string)
-
 		// 📌 добавляем заголовки, которые gRPC-Gateway преобразует в metadata
 		r.Header.Set("Grpc-Metadata-User-Id", userId)
 		r.Header.Set("Grpc-Metadata-Username", username)
+		r.Header.Set("Grpc-Metadata-Email-Verified", strconv.FormatBool(emailVerified))
+		r.Header.Set("Grpc-Metadata-User-Role", role)
+		if sessionID != "" {
+			r.Header.Set("Grpc-Metadata-Session-Id", sessionID)
+		}
+		if actorID != "" {
+			r.Header.Set("Grpc-Metadata-Actor-Id", actorID)
+		}
 
 		// опционально — оставляем context для прямых gRPC вызовов
 		md := metadata.New(map[string]string{
-			"user-id":  userId,
-			"username": username,
+			"user-id":        userId,
+			"username":       username,
+			"email-verified": strconv.FormatBool(emailVerified),
+			"user-role":      role,
+			"session-id":     sessionID,
+			"actor-id":       actorID,
 		})
 		ctx := metadata.NewOutgoingContext(r.Context(), md)
 		r = r.WithContext(ctx)
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// spoofableMetadata — через Grpc-Metadata-* клиент мог бы подменить адрес и устройство,
+// которые сервисы пишут в журнал безопасности и используют для лимитов входа
+var spoofableMetadata = []string{
+	"Grpc-Metadata-X-Forwarded-For",
+	"Grpc-Metadata-User-Agent",
+	"Grpc-Metadata-Grpcgateway-User-Agent",
+	"Grpc-Metadata-X-User-Id",
+}
+
+// ParseTrustedProxies — GATEWAY_TRUSTED_PROXIES: CIDR или адреса через запятую
+func ParseTrustedProxies(value string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if !strings.Contains(raw, "/") {
+			if ip := net.ParseIP(raw); ip != nil && ip.To4() != nil {
+				raw += "/32"
+			} else {
+				raw += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(raw)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %v", raw, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// ClientIPMiddleware — gateway сам определяет IP клиента и передаёт его дальше в X-Forwarded-For
+// (gRPC-Gateway превращает его в metadata). Присланный клиентом X-Forwarded-For учитывается,
+// только если соединение пришло от доверенного прокси: тогда клиент — последний недоверенный адрес цепочки.
+func ClientIPMiddleware(trusted []*net.IPNet, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, h := range spoofableMetadata {
+			r.Header.Del(h)
+		}
+
+		r.Header.Set("X-Forwarded-For", clientIP(r, trusted))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientIP(r *http.Request, trusted []*net.IPNet) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrusted(remote, trusted) {
+		return remote
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			// мусор в заголовке — дальше по цепочке верить нельзя
+			break
+		}
+		if !isTrusted(hop, trusted) {
+			return hop
+		}
+		remote = hop
+	}
+	return remote
+}
+
+func isTrusted(addr string, trusted []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
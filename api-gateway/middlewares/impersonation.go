@@ -0,0 +1,72 @@
+package middlewares
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ImpersonatedRequest — запрос администратора под аккаунтом пользователя
+type ImpersonatedRequest struct {
+	ActorID   string
+	UserID    string
+	SessionID string
+	Method    string
+	Path      string
+	Status    int
+	IP        string
+	UserAgent string
+}
+
+// ImpersonationAuditMiddleware — каждый запрос с токеном имперсонации пишется в журнал auth.
+// Ставится после AuthMiddleware: заголовок актора к этому моменту выставлен только им.
+func ImpersonationAuditMiddleware(record func(ctx context.Context, req ImpersonatedRequest) error, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actorID := r.Header.Get("Grpc-Metadata-Actor-Id")
+		if actorID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		req := ImpersonatedRequest{
+			ActorID:   actorID,
+			UserID:    r.Header.Get("Grpc-Metadata-User-Id"),
+			SessionID: r.Header.Get("Grpc-Metadata-Session-Id"),
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			Status:    rec.status,
+			IP:        r.Header.Get("X-Forwarded-For"),
+			UserAgent: r.UserAgent(),
+		}
+		// ответ уже отдан, запись не задерживает клиента
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := record(ctx, req); err != nil {
+				log.Printf("❌ impersonated request not audited: actor=%s user=%s %s %s: %v",
+					req.ActorID, req.UserID, req.Method, req.Path, err)
+			}
+		}()
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush — grpc-gateway стримит ответы серверных потоков
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+const (
+	// отзыв сессии или смена пароля доходит до gateway не позже чем через introspectionCacheTTL
+	introspectionCacheTTL     = 10 * time.Second
+	introspectionCacheMaxSize = 50000
+)
+
+type introspectionEntry struct {
+	active    bool
+	expiresAt time.Time
+}
+
+// IntrospectionCache — не отозван ли access токен (спрашиваем auth, ответ кэшируем ненадолго).
+// Подпись и срок действия проверяет JWKSCache, здесь только отзыв.
+type IntrospectionCache struct {
+	introspect func(ctx context.Context, token string) (bool, error)
+
+	// FailOpen — при недоступном auth пропускать токены с проверенной подписью без проверки отзыва.
+	// По умолчанию выключено: такой запрос получает 503.
+	FailOpen bool
+
+	mu      sync.Mutex
+	entries map[string]introspectionEntry
+}
+
+func NewIntrospectionCache(introspect func(ctx context.Context, token string) (bool, error)) *IntrospectionCache {
+	return &IntrospectionCache{introspect: introspect, entries: map[string]introspectionEntry{}}
+}
+
+// Active — tokenExp нужен, чтобы отозванный токен помнить до конца его срока: активным он уже не станет
+func (c *IntrospectionCache) Active(ctx context.Context, token string, tokenExp time.Time) (bool, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.active, nil
+	}
+
+	active, err := c.introspect(ctx, token)
+	if err != nil {
+		return false, err
+	}
+
+	expiresAt := now.Add(introspectionCacheTTL)
+	if !active && tokenExp.After(expiresAt) {
+		expiresAt = tokenExp
+	}
+	c.mu.Lock()
+	if len(c.entries) >= introspectionCacheMaxSize {
+		c.evictExpiredLocked(now)
+	}
+	c.entries[key] = introspectionEntry{active: active, expiresAt: expiresAt}
+	c.mu.Unlock()
+	return active, nil
+}
+
+func (c *IntrospectionCache) evictExpiredLocked(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= introspectionCacheMaxSize {
+		c.entries = map[string]introspectionEntry{}
+	}
+}
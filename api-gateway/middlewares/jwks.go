@@ -0,0 +1,99 @@
+package middlewares
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v5"
+	"log"
+	"socialnet/pkg/utils"
+	"sync"
+	"time"
+)
+
+// не чаще раза в interval перезапрашиваем JWKS из-за токена с незнакомым kid
+const unknownKIDRefreshInterval = 30 * time.Second
+
+// JWKSCache — публичные ключи auth сервиса для проверки access токенов.
+// Обновляется периодически и при появлении нового kid (ротация ключей).
+type JWKSCache struct {
+	keys  *utils.KeySet
+	fetch func(ctx context.Context) ([]byte, error)
+
+	mu          sync.Mutex
+	lastRefresh time.Time
+}
+
+func NewJWKSCache(fetch func(ctx context.Context) ([]byte, error)) *JWKSCache {
+	empty, _ := utils.ParseJWKS([]byte(`{"keys":[]}`))
+	return &JWKSCache{keys: empty, fetch: fetch}
+}
+
+func (c *JWKSCache) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshLocked(ctx)
+}
+
+func (c *JWKSCache) refreshLocked(ctx context.Context) error {
+	c.lastRefresh = time.Now()
+	data, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	fresh, err := utils.ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+	// старые ключи не теряем, если auth временно отдал пустой набор
+	if fresh.Len() == 0 {
+		return nil
+	}
+	c.keys.Replace(fresh)
+	return nil
+}
+
+// Run — фоновое обновление ключей до отмены ctx
+func (c *JWKSCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				log.Printf("⚠️ JWKS refresh failed: %v", err)
+			}
+		}
+	}
+}
+
+func (c *JWKSCache) Parse(ctx context.Context, tokenString string) (jwt.MapClaims, error) {
+	c.ensureKey(ctx, tokenString)
+
+	claims := jwt.MapClaims{}
+	if _, err := c.keys.Parse(tokenString, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// ensureKey — токен подписан ключом, которого нет в кэше: возможно, auth уже перешёл на новый
+func (c *JWKSCache) ensureKey(ctx context.Context, tokenString string) {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" || c.keys.HasKey(kid) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.keys.HasKey(kid) || time.Since(c.lastRefresh) < unknownKIDRefreshInterval {
+		return
+	}
+	if err := c.refreshLocked(ctx); err != nil {
+		log.Printf("⚠️ JWKS refresh failed: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, path string
+		scope        string
+		allowed      bool
+	}{
+		// корневые ресурсы
+		{http.MethodGet, "/api/v1/posts", "posts:read", true},
+		{http.MethodHead, "/api/v1/posts/7", "posts:read", true},
+		{http.MethodPost, "/api/v1/posts", "posts:write", true},
+		{http.MethodPut, "/api/v1/posts/7", "posts:write", true},
+		{http.MethodGet, "/api/v1/feed", "posts:read", true},
+		{http.MethodGet, "/api/v1/users/5", "users:read", true},
+		{http.MethodPatch, "/api/v1/users/5", "users:write", true},
+		{http.MethodGet, "/api/v1/u/alice", "users:read", true},
+		{http.MethodGet, "/api/v1/chats", "chat:read", true},
+		{http.MethodPost, "/api/v1/chats/3/messages", "chat:write", true},
+		{http.MethodPost, "/api/v1/notifications/read-all", "notifications:write", true},
+		{http.MethodGet, "/api/v1/search/users", "search:read", true},
+		{http.MethodGet, "/api/v1/posts/", "posts:read", true},
+
+		// вложенные ресурсы берут право вложенного
+		{http.MethodGet, "/api/v1/posts/7/comments", "comments:read", true},
+		{http.MethodPost, "/api/v1/posts/7/comments", "comments:write", true},
+		{http.MethodPost, "/api/v1/posts/7/like", "likes:write", true},
+		{http.MethodGet, "/api/v1/posts/7/likes", "likes:read", true},
+		{http.MethodDelete, "/api/v1/comments/9/like", "likes:write", true},
+		{http.MethodGet, "/api/v1/users/5/posts", "posts:read", true},
+		{http.MethodGet, "/api/v1/users/5/followers", "users:read", true},
+		{http.MethodPost, "/api/v1/chats/3/messages/8/read", "chat:write", true},
+
+		// из auth — только чтение своего профиля
+		{http.MethodGet, "/api/v1/auth/me", "profile:read", true},
+		{http.MethodGet, "/api/v1/auth/me/", "profile:read", true},
+		{http.MethodPost, "/api/v1/auth/me", "", false},
+		{http.MethodGet, "/api/v1/auth/me/extra", "", false},
+		{http.MethodGet, "/api/v1/auth/sessions", "", false},
+		{http.MethodPost, "/api/v1/auth/tokens", "", false},
+		{http.MethodPost, "/api/v1/auth/update-password", "", false},
+
+		// неизвестные маршруты и пути вне API
+		{http.MethodGet, "/api/v1/admin", "", false},
+		{http.MethodGet, "/api/v2/posts", "", false},
+		{http.MethodGet, "/.well-known/jwks.json", "", false},
+		{http.MethodGet, "/api/v1/", "", false},
+	}
+	for _, tt := range tests {
+		scope, allowed := requiredScope(tt.method, tt.path)
+		if scope != tt.scope || allowed != tt.allowed {
+			t.Errorf("requiredScope(%s %s) = %q, %v; want %q, %v",
+				tt.method, tt.path, scope, allowed, tt.scope, tt.allowed)
+		}
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	scopes := []string{"posts:read", "comments:write"}
+	if !hasScope(scopes, "posts:read") {
+		t.Error("posts:read should be granted")
+	}
+	// write не подразумевает read и наоборот
+	if hasScope(scopes, "posts:write") || hasScope(scopes, "comments:read") {
+		t.Error("scopes must match exactly")
+	}
+	if hasScope(nil, "posts:read") {
+		t.Error("empty token grants nothing")
+	}
+}
+
+func TestPATCache(t *testing.T) {
+	calls := 0
+	var authErr error
+	cache := NewPATCache(func(ctx context.Context, token string) (*PATIdentity, error) {
+		calls++
+		if authErr != nil {
+			return nil, authErr
+		}
+		if token == "snp_bad" {
+			return nil, ErrInvalidToken
+		}
+		return &PATIdentity{UserID: "42", Scopes: []string{"posts:read"}}, nil
+	})
+	ctx := context.Background()
+
+	// действительный токен проверяется в auth один раз
+	for i := 0; i < 2; i++ {
+		identity, err := cache.Validate(ctx, "snp_good")
+		if err != nil || identity.UserID != "42" {
+			t.Fatalf("Validate(good) = %v, %v", identity, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("good token: %d auth calls, want 1", calls)
+	}
+
+	// недействительный тоже кэшируется
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Validate(ctx, "snp_bad"); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("Validate(bad) error = %v, want ErrInvalidToken", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("bad token: %d auth calls, want 2", calls)
+	}
+
+	// сбой auth не кэшируется: следующий запрос снова идёт в auth
+	authErr = errors.New("auth unavailable")
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Validate(ctx, "snp_other"); err == nil || errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("Validate(other) error = %v, want auth error", err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("auth outage: %d auth calls, want 4", calls)
+	}
+}
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken — токен отозван, истёк или не существует (в отличие от недоступности auth)
+var ErrInvalidToken = errors.New("invalid token")
+
+const (
+	patCacheTTL     = 30 * time.Second
+	patCacheMaxSize = 10000
+)
+
+// PATIdentity — аккаунт и права personal access токена
+type PATIdentity struct {
+	UserID        string
+	Username      string
+	Role          string
+	EmailVerified bool
+	Scopes        []string
+}
+
+type patEntry struct {
+	identity  *PATIdentity // nil — токен недействителен
+	expiresAt time.Time
+}
+
+// PATCache — проверка personal access токенов через auth с коротким кэшем,
+// чтобы не ходить в auth на каждый запрос бота. Отзыв токена вступает в силу не позже patCacheTTL.
+type PATCache struct {
+	validate func(ctx context.Context, token string) (*PATIdentity, error)
+
+	mu      sync.Mutex
+	entries map[string]patEntry
+}
+
+func NewPATCache(validate func(ctx context.Context, token string) (*PATIdentity, error)) *PATCache {
+	return &PATCache{validate: validate, entries: map[string]patEntry{}}
+}
+
+func (c *PATCache) Validate(ctx context.Context, token string) (*PATIdentity, error) {
+	// в памяти держим хэш, а не сам токен
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		if entry.identity == nil {
+			return nil, ErrInvalidToken
+		}
+		return entry.identity, nil
+	}
+
+	identity, err := c.validate(ctx, token)
+	if err != nil && !errors.Is(err, ErrInvalidToken) {
+		// ошибку связи с auth не кэшируем
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if len(c.entries) >= patCacheMaxSize {
+		c.evictExpiredLocked()
+	}
+	c.entries[key] = patEntry{identity: identity, expiresAt: time.Now().Add(patCacheTTL)}
+	c.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (c *PATCache) evictExpiredLocked() {
+	now := time.Now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	// всё ещё живые записи: проще сбросить кэш целиком, чем расти без ограничений
+	if len(c.entries) >= patCacheMaxSize {
+		c.entries = map[string]patEntry{}
+	}
+}
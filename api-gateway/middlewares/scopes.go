@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+)
+
+// resourceScopes — первый сегмент пути после /api/v1/ -> ресурс в праве токена
+var resourceScopes = map[string]string{
+	"users":         "users",
+	"u":             "users", // профиль по handle
+	"posts":         "posts",
+	"feed":          "posts",
+	"comments":      "comments",
+	"chats":         "chat",
+	"notifications": "notifications",
+	"search":        "search",
+}
+
+var nestedScopes = map[string]string{
+	"posts":    "posts",
+	"comments": "comments",
+	"like":     "likes",
+	"likes":    "likes",
+}
+
+// requiredScope — право personal access токена, нужное для запроса.
+// ok=false — маршрут токенам недоступен вовсе (управление аккаунтом, сессиями и самими токенами).
+func requiredScope(method, path string) (string, bool) {
+	rest, found := strings.CutPrefix(path, "/api/v1/")
+	if !found {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+
+	// из auth боту доступен только собственный профиль
+	if segments[0] == "auth" {
+		if method == http.MethodGet && len(segments) == 2 && segments[1] == "me" {
+			return "profile:read", true
+		}
+		return "", false
+	}
+
+	resource, ok := resourceScopes[segments[0]]
+	if !ok {
+		return "", false
+	}
+	// вложенные ресурсы: /posts/{id}/comments, /comments/{id}/like, /users/{id}/posts
+	if len(segments) >= 3 {
+		if nested, ok := nestedScopes[segments[2]]; ok {
+			resource = nested
+		}
+	}
+
+	if method == http.MethodGet || method == http.MethodHead {
+		return resource + ":read", true
+	}
+	return resource + ":write", true
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
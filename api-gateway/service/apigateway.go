@@ -4,11 +4,17 @@ import (
 	"context"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"log"
 	"net/http"
+	"os"
+	"socialnet/pkg/interceptor"
 	"socialnet/pkg/utils"
+	"strconv"
+	"time"
 
 	midl "socialnet/api-gateway/middlewares"
 	authpb "socialnet/services/auth/gen"
@@ -65,7 +71,87 @@ func main() {
 		log.Fatalf("failed to register search service: %v", err)
 	}
 
-	handler := midl.CorsMiddleware(midl.AuthMiddleware(mux))
+	// 🔹 Публичные ключи для проверки токенов берём у auth сервиса
+	authConn, err := grpc.NewClient("localhost:50051", opts...)
+	if err != nil {
+		log.Fatalf("failed to connect to auth service: %v", err)
+	}
+	defer authConn.Close()
+	authClient := authpb.NewAuthServiceClient(authConn)
+
+	jwks := midl.NewJWKSCache(func(ctx context.Context) ([]byte, error) {
+		body, err := authClient.GetJWKS(ctx, &authpb.GetJWKSRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return body.Data, nil
+	})
+	if err := jwks.Refresh(ctx); err != nil {
+		log.Printf("⚠️ JWKS not loaded yet, will retry: %v", err)
+	}
+	go jwks.Run(ctx, 5*time.Minute)
+
+	// 🔹 Отзыв access токенов (выход, смена пароля) проверяет auth (внутренний метод)
+	revocations := midl.NewIntrospectionCache(func(ctx context.Context, token string) (bool, error) {
+		resp, err := authClient.IntrospectToken(interceptor.WithInternalToken(ctx),
+			&authpb.IntrospectTokenRequest{Token: token})
+		if err != nil {
+			return false, err
+		}
+		return resp.Active, nil
+	})
+	// без auth токены по умолчанию отклоняются; GATEWAY_INTROSPECTION_FAIL_OPEN=true — пропускать
+	if v := os.Getenv("GATEWAY_INTROSPECTION_FAIL_OPEN"); v != "" {
+		failOpen, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Fatalf("invalid GATEWAY_INTROSPECTION_FAIL_OPEN: %v", err)
+		}
+		revocations.FailOpen = failOpen
+	}
+
+	// 🔹 Personal access токены проверяет auth (внутренний метод)
+	pats := midl.NewPATCache(func(ctx context.Context, token string) (*midl.PATIdentity, error) {
+		resp, err := authClient.ValidatePersonalAccessToken(interceptor.WithInternalToken(ctx),
+			&authpb.ValidatePersonalAccessTokenRequest{Token: token})
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, midl.ErrInvalidToken
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &midl.PATIdentity{
+			UserID:        resp.UserId,
+			Username:      resp.Username,
+			Role:          resp.Role,
+			EmailVerified: resp.EmailVerified,
+			Scopes:        resp.Scopes,
+		}, nil
+	})
+
+	// 🔹 Запросы под имперсонацией — в журнал безопасности auth
+	auditImpersonation := func(ctx context.Context, req midl.ImpersonatedRequest) error {
+		_, err := authClient.RecordImpersonatedRequest(interceptor.WithInternalToken(ctx),
+			&authpb.RecordImpersonatedRequestRequest{
+				ActorId:   req.ActorID,
+				UserId:    req.UserID,
+				SessionId: req.SessionID,
+				Method:    req.Method,
+				Path:      req.Path,
+				Status:    int32(req.Status),
+				Ip:        req.IP,
+				UserAgent: req.UserAgent,
+			})
+		return err
+	}
+
+	// 🔹 IP клиента для лимитов входа и журнала безопасности
+	trusted, err := midl.ParseTrustedProxies(os.Getenv("GATEWAY_TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("invalid GATEWAY_TRUSTED_PROXIES: %v", err)
+	}
+
+	handler := midl.CorsMiddleware(midl.ClientIPMiddleware(trusted, midl.AuthMiddleware(jwks, revocations, pats,
+		midl.ImpersonationAuditMiddleware(auditImpersonation, mux))))
 
 	log.Println("API Gateway listening on :8080")
 	if err := http.ListenAndServe("0.0.0.0:8080", handler); err != nil {
@@ -2,8 +2,10 @@
 import api from './api';
 
 const NotificationAPI = {
-  list: (limit = 50, offset = 0) =>
-    api.get(`/notifications?limit=${limit}&offset=${offset}`).then(r => r.data),
+  list: (pageSize = 50, pageToken = '') =>
+    api
+      .get('/notifications', { params: { page_size: pageSize, page_token: pageToken || undefined } })
+      .then(r => r.data),
 
   markAsRead: id => api.post(`/notifications/${id}/read`).then(r => r.data),
 
@@ -67,7 +67,9 @@ export const UpdateUser = () => {
           ? new Date(birthDate).toISOString().split('T')[0]
           : null,
         bio,
-        socialLinks: socialLinks.length > 0 ? socialLinks : null
+        socialLinks: socialLinks.length > 0 ? socialLinks : null,
+        // без маски сервер запрос отклоняет
+        updateMask: 'firstName,lastName,birthDate,bio'
       };
 
       await api.patch(`/users/${userId}`, userData);
@@ -4,11 +4,11 @@ import NotificationAPI from '../api/notificationApi';
 // ==============================
 //  Получить список уведомлений
 // ==============================
-export async function listNotifications(limit = 50, offset = 0) {
-  const res = await NotificationAPI.list(limit, offset);
+export async function listNotifications(pageSize = 50, pageToken = '') {
+  const res = await NotificationAPI.list(pageSize, pageToken);
 
   // backend возвращает:
-  // { notifications: [...] }
+  // { notifications: [...], next_page_token: "..." }
   return res.notifications || [];
 }
 
@@ -5,6 +5,8 @@ import (
 	notificationpb "socialnet/services/notification/gen"
 	"sync"
 
+	chatpb "socialnet/services/chat/gen"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -22,12 +24,14 @@ type GRPCClients struct {
 	userConn    *grpc.ClientConn
 	postConn    *grpc.ClientConn
 	notifConn   *grpc.ClientConn
+	chatConn    *grpc.ClientConn
 
 	LikeClient    likepb.LikeServiceClient
 	CommentClient commentpb.CommentServiceClient
 	UserClient    userpb.UserServiceClient
 	PostClient    postpb.PostServiceClient
 	NotifClient   notificationpb.NotificationServiceClient
+	ChatClient    chatpb.ChatServiceClient
 }
 
 // 🔹 Универсальная функция подключения
@@ -119,6 +123,22 @@ func (c *GRPCClients) GetNotifClient(addr string) (notificationpb.NotificationSe
 	return c.NotifClient, nil
 }
 
+// 🔹 Ленивое подключение к ChatService
+func (c *GRPCClients) GetChatClient(addr string) (chatpb.ChatServiceClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ChatClient == nil {
+		conn, err := dial(addr)
+		if err != nil {
+			return nil, err
+		}
+		c.chatConn = conn
+		c.ChatClient = chatpb.NewChatServiceClient(conn)
+	}
+	return c.ChatClient, nil
+}
+
 // 🔹 Закрыть все соединения (при завершении приложения)
 func (c *GRPCClients) CloseAll() {
 	if c.likeConn != nil {
@@ -136,4 +156,7 @@ func (c *GRPCClients) CloseAll() {
 	if c.notifConn != nil {
 		c.notifConn.Close()
 	}
+	if c.chatConn != nil {
+		c.chatConn.Close()
+	}
 }
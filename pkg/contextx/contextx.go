@@ -5,8 +5,14 @@ import "context"
 type ctxKey string
 
 const (
-	UserIDKey   ctxKey = "user_id"
-	UsernameKey ctxKey = "username"
+	UserIDKey        ctxKey = "user_id"
+	UsernameKey      ctxKey = "username"
+	UserAgentKey     ctxKey = "user_agent"
+	ClientIPKey      ctxKey = "client_ip"
+	EmailVerifiedKey ctxKey = "email_verified"
+	RoleKey          ctxKey = "role"
+	SessionIDKey     ctxKey = "session_id"
+	ActorIDKey       ctxKey = "actor_id"
 )
 
 // GetUserID — безопасно достаёт user_id
@@ -24,3 +30,52 @@ func GetUsername(ctx context.Context) string {
 	}
 	return ""
 }
+
+// GetUserAgent — безопасно достаёт user agent клиента
+func GetUserAgent(ctx context.Context) string {
+	if val, ok := ctx.Value(UserAgentKey).(string); ok {
+		return val
+	}
+	return ""
+}
+
+// GetClientIP — безопасно достаёт IP клиента
+func GetClientIP(ctx context.Context) string {
+	if val, ok := ctx.Value(ClientIPKey).(string); ok {
+		return val
+	}
+	return ""
+}
+
+// GetEmailVerified — значение claim email_verified; known=false, если запрос пришёл не через gateway
+func GetEmailVerified(ctx context.Context) (verified bool, known bool) {
+	val, ok := ctx.Value(EmailVerifiedKey).(string)
+	if !ok {
+		return false, false
+	}
+	return val == "true", true
+}
+
+// GetSessionID — сессия (sid) access токена, пусто для personal access токенов и прямых вызовов
+func GetSessionID(ctx context.Context) string {
+	if val, ok := ctx.Value(SessionIDKey).(string); ok {
+		return val
+	}
+	return ""
+}
+
+// GetActorID — администратор, работающий под чужим аккаунтом (имперсонация); пусто в обычных запросах
+func GetActorID(ctx context.Context) string {
+	if val, ok := ctx.Value(ActorIDKey).(string); ok {
+		return val
+	}
+	return ""
+}
+
+// GetRole — роль из access токена, пустая строка для вызовов не через gateway
+func GetRole(ctx context.Context) string {
+	if val, ok := ctx.Value(RoleKey).(string); ok {
+		return val
+	}
+	return ""
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
 	"socialnet/pkg/contextx"
+	"strings"
 )
 
-// ExtractUserInterceptor — добавляет user_id и username в контекст
+// ExtractUserInterceptor — добавляет user_id, username и данные устройства в контекст
 func ExtractUserInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{},
 		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
@@ -25,10 +28,48 @@ func ExtractUserInterceptor() grpc.UnaryServerInterceptor {
 			if names := md.Get("username"); len(names) > 0 {
 				ctx = context.WithValue(ctx, contextx.UsernameKey, names[0])
 			}
+			if verified := md.Get("email-verified"); len(verified) > 0 {
+				ctx = context.WithValue(ctx, contextx.EmailVerifiedKey, verified[0])
+			}
+			if roles := md.Get("user-role"); len(roles) > 0 {
+				ctx = context.WithValue(ctx, contextx.RoleKey, roles[0])
+			}
+			if sessions := md.Get("session-id"); len(sessions) > 0 {
+				ctx = context.WithValue(ctx, contextx.SessionIDKey, sessions[0])
+			}
+			if actors := md.Get("actor-id"); len(actors) > 0 {
+				ctx = context.WithValue(ctx, contextx.ActorIDKey, actors[0])
+			}
+			// gRPC-Gateway пробрасывает User-Agent как grpcgateway-user-agent
+			if agents := md.Get("grpcgateway-user-agent"); len(agents) > 0 {
+				ctx = context.WithValue(ctx, contextx.UserAgentKey, agents[0])
+			} else if agents := md.Get("user-agent"); len(agents) > 0 {
+				ctx = context.WithValue(ctx, contextx.UserAgentKey, agents[0])
+			}
+			if ips := md.Get("x-forwarded-for"); len(ips) > 0 {
+				ctx = context.WithValue(ctx, contextx.ClientIPKey, firstForwardedIP(ips[0]))
+			}
 		} else {
 			fmt.Println("⚠️ No metadata found in context")
 		}
 
+		// прямой gRPC вызов без gateway — берём адрес соединения
+		if contextx.GetClientIP(ctx) == "" {
+			if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+				host, _, err := net.SplitHostPort(p.Addr.String())
+				if err != nil {
+					host = p.Addr.String()
+				}
+				ctx = context.WithValue(ctx, contextx.ClientIPKey, host)
+			}
+		}
+
 		return handler(ctx, req)
 	}
 }
+
+// firstForwardedIP — X-Forwarded-For может содержать цепочку прокси, клиент всегда первый
+func firstForwardedIP(header string) string {
+	ip, _, _ := strings.Cut(header, ",")
+	return strings.TrimSpace(ip)
+}
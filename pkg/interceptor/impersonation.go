@@ -0,0 +1,27 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"socialnet/pkg/contextx"
+)
+
+// ForbidImpersonation — методы, недоступные администратору под чужим аккаунтом:
+// смена пароля, почты, 2FA, токены, удаление аккаунта и т.п.
+func ForbidImpersonation(methods ...string) grpc.UnaryServerInterceptor {
+	forbidden := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		forbidden[m] = true
+	}
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if forbidden[info.FullMethod] && contextx.GetActorID(ctx) != "" {
+			return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+		}
+		return handler(ctx, req)
+	}
+}
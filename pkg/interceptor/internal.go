@@ -0,0 +1,54 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"os"
+)
+
+const internalTokenHeader = "x-internal-token"
+
+// InternalOnly — перечисленные методы доступны только другим сервисам.
+// У них нет HTTP маршрута в gateway, но gRPC-Web обёртки открывают все методы браузеру,
+// поэтому вызов подтверждается общим секретом INTERNAL_API_TOKEN.
+// Без настроенного секрета методы недоступны совсем.
+func InternalOnly(methods ...string) grpc.UnaryServerInterceptor {
+	internal := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		internal[m] = true
+	}
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if internal[info.FullMethod] && !validInternalToken(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "internal method")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// WithInternalToken — добавляет секрет к исходящему вызову внутреннего метода
+func WithInternalToken(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, internalTokenHeader, os.Getenv("INTERNAL_API_TOKEN"))
+}
+
+func validInternalToken(ctx context.Context) bool {
+	expected := os.Getenv("INTERNAL_API_TOKEN")
+	if expected == "" {
+		return false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	tokens := md.Get(internalTokenHeader)
+	if len(tokens) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(expected)) == 1
+}
@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"socialnet/pkg/contextx"
+	"socialnet/pkg/utils"
+)
+
+// RequireRoles — таблица "полное имя метода -> допустимые роли".
+// Методы, которых нет в таблице, доступны всем; для остальных роль берётся из
+// metadata user-role, которую gateway выставляет по access токену.
+// Запрос без роли (не через gateway) к методу из таблицы отклоняется.
+func RequireRoles(table map[string][]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if allowed, ok := table[info.FullMethod]; ok {
+			if contextx.GetUserID(ctx) == "" {
+				return nil, status.Error(codes.Unauthenticated, "missing user id")
+			}
+			if ok, _ := utils.AuthorizeUser(contextx.GetRole(ctx), allowed...); !ok {
+				return nil, status.Error(codes.PermissionDenied, "insufficient role")
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
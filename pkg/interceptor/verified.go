@@ -0,0 +1,34 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"socialnet/pkg/contextx"
+	"strings"
+)
+
+// RequireVerifiedEmail — запрещает перечисленные методы пользователям с неподтверждённым email.
+// Политика включена по умолчанию, отключается через EMAIL_VERIFICATION_REQUIRED=false.
+// Внутренние вызовы между сервисами (без claim от gateway) не ограничиваются.
+func RequireVerifiedEmail(methods ...string) grpc.UnaryServerInterceptor {
+	blocked := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		blocked[m] = true
+	}
+	enabled := !strings.EqualFold(os.Getenv("EMAIL_VERIFICATION_REQUIRED"), "false")
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if enabled && blocked[info.FullMethod] {
+			if verified, known := contextx.GetEmailVerified(ctx); known && !verified {
+				return nil, status.Error(codes.PermissionDenied, "email is not verified")
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
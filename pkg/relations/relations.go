@@ -0,0 +1,119 @@
+package relations
+
+import (
+	"context"
+	"socialnet/pkg/config"
+	"socialnet/pkg/interceptor"
+	"socialnet/pkg/users"
+	userpb "socialnet/services/user/gen"
+	"sync"
+	"time"
+)
+
+// cacheTTL — блокировка доходит до остальных сервисов не позже чем через это время
+const cacheTTL = 30 * time.Second
+
+// Relations — отношения пользователя к другим: Blocked — блокировка в любую сторону,
+// Muted — пользователь заглушил другого
+type Relations struct {
+	Blocked map[string]bool
+	Muted   map[string]bool
+}
+
+// Hidden — контент other не показываем в ленте и уведомлениях
+func (r *Relations) Hidden(other string) bool {
+	return r.Blocked[other] || r.Muted[other]
+}
+
+type entry struct {
+	blocked, muted bool
+	expires        time.Time
+}
+
+// Checker — батч-проверка блокировок через UserService.CheckRelations с кэшем по парам
+type Checker struct {
+	client func() (userpb.UserServiceClient, error)
+
+	mu    sync.Mutex
+	cache map[[2]string]entry
+}
+
+func NewChecker(client func() (userpb.UserServiceClient, error)) *Checker {
+	return &Checker{client: client, cache: map[[2]string]entry{}}
+}
+
+// FromClients — user сервис по USER_SERVICE_ADDR из общего набора клиентов
+func FromClients(clients *config.GRPCClients) *Checker {
+	return NewChecker(func() (userpb.UserServiceClient, error) {
+		return clients.GetUserClient(users.Addr())
+	})
+}
+
+// Check — закэшированные пары берём из памяти, остальные одним вызовом
+func (c *Checker) Check(ctx context.Context, userID string, otherIDs []string) (*Relations, error) {
+	rel := &Relations{Blocked: map[string]bool{}, Muted: map[string]bool{}}
+	if userID == "" {
+		return rel, nil
+	}
+
+	now := time.Now()
+	var missing []string
+	c.mu.Lock()
+	for _, other := range otherIDs {
+		if other == "" || other == userID {
+			continue
+		}
+		if e, ok := c.cache[[2]string{userID, other}]; ok && now.Before(e.expires) {
+			rel.Blocked[other], rel.Muted[other] = e.blocked, e.muted
+			continue
+		}
+		missing = append(missing, other)
+	}
+	c.mu.Unlock()
+	if len(missing) == 0 {
+		return rel, nil
+	}
+
+	client, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.CheckRelations(interceptor.WithInternalToken(ctx), &userpb.CheckRelationsRequest{
+		UserId:   userID,
+		OtherIds: missing,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range resp.BlockedIds {
+		rel.Blocked[id] = true
+	}
+	for _, id := range resp.MutedIds {
+		rel.Muted[id] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.cache {
+		if !now.Before(e.expires) {
+			delete(c.cache, k)
+		}
+	}
+	for _, other := range missing {
+		c.cache[[2]string{userID, other}] = entry{
+			blocked: rel.Blocked[other],
+			muted:   rel.Muted[other],
+			expires: now.Add(cacheTTL),
+		}
+	}
+	return rel, nil
+}
+
+// Blocked — есть ли блокировка между двумя пользователями в любую сторону
+func (c *Checker) Blocked(ctx context.Context, userID, otherID string) (bool, error) {
+	rel, err := c.Check(ctx, userID, []string{otherID})
+	if err != nil {
+		return false, err
+	}
+	return rel.Blocked[otherID], nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/joho/godotenv"
 	"io"
 	"os"
+	"strings"
+	"time"
 )
 
 type S3Client struct {
@@ -46,3 +48,45 @@ func (s *S3Client) UploadFile(reader io.Reader, fileName string) (string, error)
 
 	return url, nil
 }
+
+// DeleteFile — удаление объекта; отсутствие ключа ошибкой не считается (S3 так и отвечает)
+func (s *S3Client) DeleteFile(fileName string) error {
+	_, err := s.client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(fileName),
+	})
+	return err
+}
+
+// DownloadFile — содержимое объекта, закрыть reader обязан вызывающий
+func (s *S3Client) DownloadFile(fileName string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(fileName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// PresignURL — временная ссылка на скачивание приватного объекта (не дольше 7 дней)
+func (s *S3Client) PresignURL(fileName string, ttl time.Duration) (string, error) {
+	req, err := s3.NewPresignClient(s.client).PresignGetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(fileName),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
+// KeyFromURL — обратное преобразование ссылки, которую вернул UploadFile
+func KeyFromURL(url string) (string, bool) {
+	prefix := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", os.Getenv("AWS_BUCKET"), os.Getenv("AWS_REGION"))
+	if !strings.HasPrefix(url, prefix) || len(url) == len(prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, prefix), true
+}
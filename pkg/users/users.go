@@ -0,0 +1,81 @@
+package users
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"socialnet/pkg/interceptor"
+	userpb "socialnet/services/user/gen"
+	"strings"
+)
+
+// Addr — адрес user сервиса: USER_SERVICE_ADDR из окружения, локально порт по умолчанию
+func Addr() string {
+	if addr := os.Getenv("USER_SERVICE_ADDR"); addr != "" {
+		return addr
+	}
+	return "localhost:50052"
+}
+
+// maxMentions — больше упоминаний в одном тексте не уведомляем
+const maxMentions = 10
+
+// mentionRe — @handle по правилам username; e-mail (bob@example.com) упоминанием не считается
+var mentionRe = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_]{3,32})\b`)
+
+// Mentions — handle из текста в нижнем регистре, без повторов, не больше maxMentions
+func Mentions(text string) []string {
+	var handles []string
+	seen := map[string]bool{}
+	for _, m := range mentionRe.FindAllStringSubmatch(text, -1) {
+		h := strings.ToLower(m[1])
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+		handles = append(handles, h)
+		if len(handles) == maxMentions {
+			break
+		}
+	}
+	return handles
+}
+
+// Mentioned — профили упомянутых в тексте; несуществующие handle пропускаются
+func Mentioned(ctx context.Context, client userpb.UserServiceClient, text string) ([]*userpb.UserSummary, error) {
+	handles := Mentions(text)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+	resp, err := client.BatchGetUsers(interceptor.WithInternalToken(ctx), &userpb.BatchGetUsersRequest{Handles: handles})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Users, nil
+}
+
+// Summaries — краткие профили по id одним BatchGetUsers; повторы и пустые id отбрасываются.
+// Id без профиля в карту не попадают.
+func Summaries(ctx context.Context, client userpb.UserServiceClient, ids []string) (map[string]*userpb.UserSummary, error) {
+	result := map[string]*userpb.UserSummary{}
+	seen := map[string]bool{}
+	var unique []string
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return result, nil
+	}
+
+	resp, err := client.BatchGetUsers(interceptor.WithInternalToken(ctx), &userpb.BatchGetUsersRequest{Ids: unique})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range resp.Users {
+		result[u.Id] = u
+	}
+	return result, nil
+}
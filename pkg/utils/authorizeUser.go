@@ -10,6 +10,22 @@ const (
 	ExpKey      ContextKey = "expiresAt"
 )
 
+// Роли пользователей: хранятся в auth БД и попадают в access токен (claim role)
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// ValidRole — true для известных ролей
+func ValidRole(role string) bool {
+	switch role {
+	case RoleUser, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 func AuthorizeUser(userRole string, allowedRoles ...string) (bool, error) {
 	for _, role := range allowedRoles {
 		if role == userRole {
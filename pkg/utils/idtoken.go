@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IDTokenClaims — claims ID токена OpenID Connect, которые нужны для входа
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string    `json:"nonce"`
+	AuthorizedParty   string    `json:"azp"`
+	Email             string    `json:"email"`
+	EmailVerified     ClaimBool `json:"email_verified"`
+	Name              string    `json:"name"`
+	PreferredUsername string    `json:"preferred_username"`
+}
+
+// ClaimBool — часть провайдеров присылает email_verified строкой "true"
+type ClaimBool bool
+
+func (b *ClaimBool) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch val := v.(type) {
+	case bool:
+		*b = ClaimBool(val)
+	case string:
+		*b = ClaimBool(val == "true")
+	default:
+		*b = false
+	}
+	return nil
+}
+
+// ParseIDToken — подпись по ключам провайдера (JWKS), iss, aud, exp и azp по спецификации OIDC.
+// nonce сверяет вызывающий: он знает, какой nonce отправлял.
+func (ks *KeySet) ParseIDToken(raw, issuer, clientID string, now time.Time) (*IDTokenClaims, error) {
+	claims := &IDTokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, ks.Keyfunc,
+		jwt.WithValidMethods(allowedSigningMethods),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+		jwt.WithLeeway(time.Minute), // расхождение часов с провайдером
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+	// несколько получателей — токен должен быть выдан именно нам
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != clientID {
+		return nil, errors.New("id token issued to another party")
+	}
+	return claims, nil
+}
@@ -0,0 +1,340 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Поддерживаемые алгоритмы подписи access токенов
+var allowedSigningMethods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+
+// KeySet — набор ключей JWT.
+// Подписывает новые токены только активный ключ (у auth сервиса), а проверка принимает
+// любой опубликованный ключ — так при ротации продолжают работать уже выданные токены.
+//
+// Ротация без простоя:
+//  1. кладём новый ключ в JWT_KEYS_DIR — он появляется в JWKS, но ещё не подписывает;
+//  2. после обновления кэша у проверяющих переключаем JWT_ACTIVE_KID на новый ключ;
+//  3. когда истечёт срок жизни access токенов (JWT_EXPIRES_IN), удаляем старый ключ.
+type KeySet struct {
+	mu      sync.RWMutex
+	signKID string
+	signer  crypto.Signer
+	keys    map[string]crypto.PublicKey
+}
+
+// LoadKeySet — читает из каталога PEM файлы вида <kid>.pem.
+// Приватные ключи (PKCS8, RSA или Ed25519) подходят для подписи, публичные (PKIX) — только для проверки.
+func LoadKeySet(dir, activeKID string) (*KeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no keys found in %s", dir)
+	}
+
+	ks := &KeySet{keys: map[string]crypto.PublicKey{}}
+	signers := map[string]crypto.Signer{}
+	for _, file := range files {
+		kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		signer, public, err := parsePEMKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %v", kid, err)
+		}
+		ks.keys[kid] = public
+		if signer != nil {
+			signers[kid] = signer
+		}
+	}
+
+	// единственный приватный ключ можно не указывать явно
+	if activeKID == "" && len(signers) == 1 {
+		for kid := range signers {
+			activeKID = kid
+		}
+	}
+	if activeKID != "" {
+		signer, ok := signers[activeKID]
+		if !ok {
+			return nil, fmt.Errorf("active key %q has no private key in %s", activeKID, dir)
+		}
+		ks.signKID, ks.signer = activeKID, signer
+	}
+	return ks, nil
+}
+
+// NewEphemeralKeySet — одноразовый Ed25519 ключ для локального запуска и тестов.
+// После рестарта выданные access токены перестанут проверяться.
+func NewEphemeralKeySet() (*KeySet, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	kid := keyThumbprint(public)
+	return &KeySet{
+		signKID: kid,
+		signer:  private,
+		keys:    map[string]crypto.PublicKey{kid: public},
+	}, nil
+}
+
+// ParseJWKS — набор только для проверки, например ответ /.well-known/jwks.json
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var doc jwksDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	ks := &KeySet{keys: map[string]crypto.PublicKey{}}
+	for _, k := range doc.Keys {
+		public, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("jwk %s: %v", k.Kid, err)
+		}
+		ks.keys[k.Kid] = public
+	}
+	return ks, nil
+}
+
+// Replace — атомарно подменяет ключи проверки (обновление кэша JWKS)
+func (ks *KeySet) Replace(other *KeySet) {
+	other.mu.RLock()
+	keys := other.keys
+	other.mu.RUnlock()
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+}
+
+func (ks *KeySet) HasKey(kid string) bool {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	_, ok := ks.keys[kid]
+	return ok
+}
+
+func (ks *KeySet) Len() int {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return len(ks.keys)
+}
+
+// CanSign — false у наборов только для проверки (JWKS, публичные ключи)
+func (ks *KeySet) CanSign() bool {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.signer != nil
+}
+
+// Sign — подписывает claims активным ключом, kid пишется в заголовок
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	ks.mu.RLock()
+	kid, signer := ks.signKID, ks.signer
+	ks.mu.RUnlock()
+	if signer == nil {
+		return "", errors.New("no active signing key")
+	}
+
+	method, err := signingMethodFor(signer.Public())
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	return token.SignedString(signer)
+}
+
+// Keyfunc — для jwt.Parse: ключ ищется по kid, алгоритм должен соответствовать типу ключа
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no kid")
+	}
+
+	ks.mu.RLock()
+	public, ok := ks.keys[kid]
+	ks.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	method, err := signingMethodFor(public)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return public, nil
+}
+
+// Parse — проверка подписи и срока действия токена
+func (ks *KeySet) Parse(tokenStr string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenStr, claims, ks.Keyfunc, jwt.WithValidMethods(allowedSigningMethods))
+}
+
+// JWKS — публичные ключи в формате RFC 7517
+func (ks *KeySet) JWKS() ([]byte, error) {
+	ks.mu.RLock()
+	kids := make([]string, 0, len(ks.keys))
+	for kid := range ks.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	doc := jwksDocument{Keys: make([]jwk, 0, len(kids))}
+	for _, kid := range kids {
+		k, err := newJWK(kid, ks.keys[kid])
+		if err != nil {
+			ks.mu.RUnlock()
+			return nil, err
+		}
+		doc.Keys = append(doc.Keys, k)
+	}
+	ks.mu.RUnlock()
+
+	return json.Marshal(doc)
+}
+
+func parsePEMKey(data []byte) (crypto.Signer, crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("invalid PEM")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, nil, errors.New("unsupported private key")
+		}
+		if _, err := signingMethodFor(signer.Public()); err != nil {
+			return nil, nil, err
+		}
+		return signer, signer.Public(), nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, key.Public(), nil
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := signingMethodFor(key); err != nil {
+			return nil, nil, err
+		}
+		return nil, key, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+func signingMethodFor(public crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch public.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", public)
+}
+
+func keyThumbprint(public ed25519.PublicKey) string {
+	sum := sha256.Sum256(public)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+type jwksDocument struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+func newJWK(kid string, public crypto.PublicKey) (jwk, error) {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		return jwk{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return jwk{
+			Kty: "OKP",
+			Kid: kid,
+			Use: "sig",
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}, nil
+	}
+	return jwk{}, fmt.Errorf("unsupported key type %T", public)
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
@@ -5,41 +5,93 @@ import (
 	"encoding/base64"
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
+	"log"
 	"os"
+	"sync"
 	"time"
 )
 
 type Claims struct {
-	UserID   string `json:"sub"`
-	Username string `json:"username"`
+	UserID        string `json:"sub"`
+	Username      string `json:"name"`
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
+	// SessionID — семейство refresh токенов, из которого выдан токен; по нему работает отзыв сессии
+	SessionID string `json:"sid,omitempty"`
+	// Actor — администратор, действующий от имени пользователя (RFC 8693, claim act)
+	Actor *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func SignToken(userId, username string) (string, error) {
-	jwtSecret := os.Getenv("JWT_SECRET")
-	jwtExpires := os.Getenv("JWT_EXPIRES_IN")
+type ActorClaim struct {
+	Subject string `json:"sub"`
+}
 
-	claims := jwt.MapClaims{
-		"sub":  userId,
-		"name": username,
-		"iat":  time.Now().Unix(),
-	}
+var (
+	defaultKeys     *KeySet
+	defaultKeysErr  error
+	defaultKeysOnce sync.Once
+)
+
+// DefaultKeySet — ключи из JWT_KEYS_DIR (активный ключ подписи — JWT_ACTIVE_KID).
+// Без JWT_KEYS_DIR генерируется временный ключ, это допустимо только для разработки.
+func DefaultKeySet() (*KeySet, error) {
+	defaultKeysOnce.Do(func() {
+		dir := os.Getenv("JWT_KEYS_DIR")
+		if dir == "" {
+			log.Println("⚠️ JWT_KEYS_DIR is not set, using ephemeral signing key")
+			defaultKeys, defaultKeysErr = NewEphemeralKeySet()
+			return
+		}
+		defaultKeys, defaultKeysErr = LoadKeySet(dir, os.Getenv("JWT_ACTIVE_KID"))
+	})
+	return defaultKeys, defaultKeysErr
+}
+
+func SignToken(userId, username, role string, emailVerified bool, sessionID string) (string, error) {
 	// срок жизни
-	if jwtExpires != "" {
+	ttl := 15 * time.Minute
+	if jwtExpires := os.Getenv("JWT_EXPIRES_IN"); jwtExpires != "" {
 		duration, err := time.ParseDuration(jwtExpires)
 		if err != nil {
 			return "", fmt.Errorf("invalid JWT_EXPIRES_IN: %v", err)
 		}
-		claims["exp"] = time.Now().Add(duration).Unix()
-	} else {
-		claims["exp"] = time.Now().Add(15 * time.Minute).Unix()
+		ttl = duration
+	}
+	return signAccessClaims(accessClaims(userId, username, role, emailVerified, sessionID), ttl)
+}
+
+// SignImpersonationToken — токен пользователя userId, выданный администратору actorID.
+// Без refresh токена: по истечении ttl нужно начинать заново.
+func SignImpersonationToken(userId, username, role string, emailVerified bool, sessionID, actorID string, ttl time.Duration) (string, error) {
+	claims := accessClaims(userId, username, role, emailVerified, sessionID)
+	claims["act"] = map[string]string{"sub": actorID}
+	return signAccessClaims(claims, ttl)
+}
+
+func accessClaims(userId, username, role string, emailVerified bool, sessionID string) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"sub":            userId,
+		"name":           username,
+		"email_verified": emailVerified,
+		"role":           role,
+		"iat":            time.Now().Unix(),
 	}
+	if sessionID != "" {
+		claims["sid"] = sessionID
+	}
+	return claims
+}
 
-	// создаём токен
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+func signAccessClaims(claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	keys, err := DefaultKeySet()
+	if err != nil {
+		return "", fmt.Errorf("failed to load signing keys: %v", err)
+	}
+	claims["exp"] = time.Now().Add(ttl).Unix()
 
-	// подписываем
-	signedToken, err := token.SignedString([]byte(jwtSecret))
+	// подписываем активным ключом, kid попадает в заголовок
+	signedToken, err := keys.Sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %v", err)
 	}
@@ -54,16 +106,12 @@ func GenerateRefreshToken() (string, error) {
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
 
-var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
-
 func ParseToken(tokenStr string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return jwtSecret, nil
-	})
-
+	keys, err := DefaultKeySet()
+	if err != nil {
+		return nil, err
+	}
+	token, err := keys.Parse(tokenStr, &Claims{})
 	if err != nil {
 		return nil, err
 	}
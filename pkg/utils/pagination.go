@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PageCursor — позиция keyset пагинации: последняя отданная запись по (created_at, id).
+// Клиенту уходит непрозрачной строкой, разбирать её на фронте не нужно.
+type PageCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"id"`
+}
+
+// PageSize — размер страницы из запроса: 0 означает значение по умолчанию, больше максимума обрезаем
+func PageSize(requested int32) int {
+	switch {
+	case requested <= 0:
+		return DefaultPageSize
+	case requested > MaxPageSize:
+		return MaxPageSize
+	default:
+		return int(requested)
+	}
+}
+
+func EncodePageToken(createdAt time.Time, id uint) string {
+	data, _ := json.Marshal(PageCursor{CreatedAt: createdAt.UTC(), ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken — пустой токен значит первую страницу (nil без ошибки)
+func DecodePageToken(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var c PageCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == 0 {
+		return nil, ErrInvalidPageToken
+	}
+	return &c, nil
+}
@@ -0,0 +1,26 @@
+package utils
+
+// PersonalTokenPrefix — отличает personal access токены от JWT в заголовке Authorization
+const PersonalTokenPrefix = "pat_"
+
+// TokenScopes — права personal access токенов: <ресурс>:read для GET, <ресурс>:write для остального.
+// Gateway сопоставляет маршрут с правом, обычные сессии (JWT) ограничений по правам не имеют.
+var TokenScopes = []string{
+	"profile:read",
+	"users:read", "users:write",
+	"posts:read", "posts:write",
+	"comments:read", "comments:write",
+	"likes:read", "likes:write",
+	"chat:read", "chat:write",
+	"notifications:read", "notifications:write",
+	"search:read",
+}
+
+func ValidScope(scope string) bool {
+	for _, s := range TokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -1,19 +1,29 @@
 package main
 
 import (
+	"context"
+	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"log"
 	"net"
 	"os"
+	"socialnet/pkg/config"
 	"socialnet/pkg/interceptor"
 	"socialnet/pkg/logger"
+	"socialnet/pkg/storage"
+	"socialnet/pkg/utils"
 	authpb "socialnet/services/auth/gen"
 	"socialnet/services/auth/internal/handlers"
+	"socialnet/services/auth/internal/mailer"
 	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/oidc"
 	"socialnet/services/auth/internal/repos"
 	"socialnet/services/auth/internal/service"
+	authUtils "socialnet/services/auth/internal/utils"
+	"time"
 )
 
 func main() {
@@ -25,6 +35,9 @@ func main() {
 	logger.Init("AuthService")
 	defer logger.Sync()
 
+	// локальный запуск: SMTP и прочие настройки из .env, в контейнере — из окружения
+	_ = godotenv.Load(".env")
+
 	// 🔹 Подключаемся к БД
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
@@ -32,14 +45,96 @@ func main() {
 	}
 
 	// 🔹 Автомиграции
-	if err := db.AutoMigrate(&model.User{}, &model.RefreshToken{}, &model.PasswordReset{}); err != nil {
+	if n, err := repos.NewAuthRepo(db).DropLegacyRefreshTokens(); err != nil {
+		log.Fatalf(" failed to drop legacy refresh tokens: %v", err)
+	} else if n > 0 {
+		log.Printf("🔑 %d refresh tokens issued before sessions removed, their owners sign in again", n)
+	}
+	if n, err := repos.NewAuthRepo(db).BackfillEmailVerified(); err != nil {
+		log.Fatalf(" failed to backfill email_verified: %v", err)
+	} else if n > 0 {
+		log.Printf("✉️ %d accounts created before email verification marked verified", n)
+	}
+	if err := db.AutoMigrate(&model.User{}, &model.RefreshToken{}, &model.PasswordReset{}, &model.EmailVerification{},
+		&model.RecoveryCode{}, &model.TwoFactorChallenge{}, &model.AccountDeletion{}, &model.AccountDeletionStep{},
+		&model.DataExport{}, &model.PersonalAccessToken{}, &model.EmailChange{}, &model.UsernameHold{},
+		&model.OutboxEmail{}, &model.SecurityEvent{}, &model.MagicLink{},
+		&model.PasswordHistory{}, &model.OIDCState{}, &model.ExternalIdentity{},
+		&model.Impersonation{}); err != nil {
 		log.Fatalf(" failed to migrate database: %v", err)
 	}
 
+	// 🔹 Ключи подписи JWT — без них токены выдавать нельзя
+	keys, err := utils.DefaultKeySet()
+	if err != nil {
+		log.Fatalf(" failed to load JWT keys: %v", err)
+	}
+	if !keys.CanSign() {
+		log.Fatalf(" no active JWT signing key, set JWT_ACTIVE_KID")
+	}
+
+	// 🔹 Счётчики попыток входа: Redis общий для всех реплик, без него — память процесса
+	var attempts repos.AttemptStore
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		rdb := redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: os.Getenv("REDIS_PASS"),
+			DB:       0,
+		})
+		if err := rdb.Ping(context.Background()).Err(); err != nil {
+			log.Fatalf("❌ Redis connection failed: %v", err)
+		}
+		attempts = repos.NewRedisAttemptStore(rdb)
+	} else {
+		log.Println("⚠️ REDIS_ADDR is not set, login attempts are counted in memory")
+		attempts = repos.NewMemoryAttemptStore(nil)
+	}
+
 	// 🔹 Репозиторий, сервис, хендлер
 	repo := repos.NewAuthRepo(db)
-	authService := service.NewAuthService(repo)
+	authService := service.NewAuthService(repo, attempts)
+	if authService.PasswordPolicy, err = authUtils.PasswordPolicyFromEnv(); err != nil {
+		log.Fatalf("❌ invalid password policy: %v", err)
+	}
+	if authService.OIDCProviders, err = oidc.ProvidersFromEnv(authService.AppBaseURL); err != nil {
+		log.Fatalf("❌ invalid OIDC providers: %v", err)
+	}
 	authHandler := handlers.NewAuthHandler(authService)
+	if err := authService.BootstrapAdmins(); err != nil {
+		log.Fatalf("❌ failed to grant admin roles: %v", err)
+	}
+
+	// 🔹 Письма: outbox в БД, отправка фоном с повторами
+	mail, err := mailer.FromEnv()
+	if err != nil {
+		log.Fatalf("❌ mailer: %v", err)
+	}
+	authService.Mailer = mail
+	go authService.StartMailWorker(context.Background(), 10*time.Second)
+
+	// 🔹 Сага удаления аккаунтов: шаги очистки в остальных сервисах
+	clients := &config.GRPCClients{}
+	defer clients.CloseAll()
+	authService.DeletionSteps = service.RemoteDeletionSteps(clients)
+	go authService.StartDeletionWorker(context.Background(), time.Minute)
+
+	// 🔹 username → handle профиля в user сервисе (повторы и аккаунты, созданные раньше)
+	authService.SyncHandle = service.RemoteHandleSync(clients)
+	go authService.StartHandleSyncWorker(context.Background(), 30*time.Second)
+
+	// 🔹 Выгрузка персональных данных: архивы хранятся в S3
+	if os.Getenv("AWS_BUCKET") != "" {
+		s3, err := storage.NewS3Client()
+		if err != nil {
+			log.Fatalf("❌ S3 client: %v", err)
+		}
+		authService.ExportStore = s3
+		authService.ExportSources = service.RemoteExportSources(clients)
+		authService.NotifyExportReady = service.RemoteExportNotifier(clients)
+		go authService.StartExportWorker(context.Background(), 30*time.Second)
+	} else {
+		log.Println("⚠️ AWS_BUCKET is not set, data export is disabled")
+	}
 
 	// 🔹 gRPC сервер
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
@@ -50,6 +145,39 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.ExtractUserInterceptor(),
+			interceptor.RequireRoles(map[string][]string{
+				authpb.AuthService_UnlockAccount_FullMethodName:           {utils.RoleAdmin},
+				authpb.AuthService_SetUserRole_FullMethodName:             {utils.RoleAdmin},
+				authpb.AuthService_AdminListSecurityEvents_FullMethodName: {utils.RoleAdmin},
+				authpb.AuthService_Impersonate_FullMethodName:             {utils.RoleAdmin},
+				authpb.AuthService_EndImpersonation_FullMethodName:        {utils.RoleAdmin},
+			}),
+			interceptor.InternalOnly(authpb.AuthService_ValidatePersonalAccessToken_FullMethodName,
+				authpb.AuthService_IntrospectToken_FullMethodName,
+				authpb.AuthService_RecordImpersonatedRequest_FullMethodName),
+			// под имперсонацией поддержка смотрит, но не меняет учётные данные пользователя
+			interceptor.ForbidImpersonation(
+				authpb.AuthService_UpdatePassword_FullMethodName,
+				authpb.AuthService_RequestEmailChange_FullMethodName,
+				authpb.AuthService_ConfirmEmailChange_FullMethodName,
+				authpb.AuthService_ChangeUsername_FullMethodName,
+				authpb.AuthService_EnrollTwoFactor_FullMethodName,
+				authpb.AuthService_ConfirmTwoFactor_FullMethodName,
+				authpb.AuthService_DisableTwoFactor_FullMethodName,
+				authpb.AuthService_RegenerateRecoveryCodes_FullMethodName,
+				authpb.AuthService_CreatePersonalAccessToken_FullMethodName,
+				authpb.AuthService_RevokePersonalAccessToken_FullMethodName,
+				authpb.AuthService_CreateBot_FullMethodName,
+				authpb.AuthService_RevokeSession_FullMethodName,
+				authpb.AuthService_LogoutAll_FullMethodName,
+				authpb.AuthService_DeleteAccount_FullMethodName,
+				authpb.AuthService_CancelAccountDeletion_FullMethodName,
+				authpb.AuthService_RequestDataExport_FullMethodName,
+				authpb.AuthService_GetDataExportStatus_FullMethodName,
+				authpb.AuthService_LinkOIDCProvider_FullMethodName,
+				authpb.AuthService_UnlinkOIDCProvider_FullMethodName,
+				authpb.AuthService_Impersonate_FullMethodName,
+			),
 			interceptor.LoggingInterceptor(),
 		),
 	)
@@ -1,22 +1,50 @@
 package main
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"socialnet/services/auth/internal/service"
+	"socialnet/services/auth/internal/utils"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/argon2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"socialnet/pkg/contextx"
+	"socialnet/pkg/interceptor"
+	"socialnet/pkg/logger"
+	utils2 "socialnet/pkg/utils"
 	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/mailer"
 	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/oidc"
 	"socialnet/services/auth/internal/repos"
 )
 
 var testSvc *service.AuthService
 var testDB *gorm.DB
+var testMailer *mailer.FakeMailer
 
 // =========================================================
 //
@@ -44,6 +72,22 @@ func TestMain(m *testing.M) {
 		&model.User{},
 		&model.RefreshToken{},
 		&model.PasswordReset{},
+		&model.EmailVerification{},
+		&model.RecoveryCode{},
+		&model.TwoFactorChallenge{},
+		&model.AccountDeletion{},
+		&model.AccountDeletionStep{},
+		&model.DataExport{},
+		&model.PersonalAccessToken{},
+		&model.EmailChange{},
+		&model.UsernameHold{},
+		&model.OutboxEmail{},
+		&model.SecurityEvent{},
+		&model.MagicLink{},
+		&model.PasswordHistory{},
+		&model.OIDCState{},
+		&model.ExternalIdentity{},
+		&model.Impersonation{},
 	)
 
 	// --- выполняем миграции ---
@@ -51,31 +95,66 @@ func TestMain(m *testing.M) {
 		&model.User{},
 		&model.RefreshToken{},
 		&model.PasswordReset{},
+		&model.EmailVerification{},
+		&model.RecoveryCode{},
+		&model.TwoFactorChallenge{},
+		&model.AccountDeletion{},
+		&model.AccountDeletionStep{},
+		&model.DataExport{},
+		&model.PersonalAccessToken{},
+		&model.EmailChange{},
+		&model.UsernameHold{},
+		&model.OutboxEmail{},
+		&model.SecurityEvent{},
+		&model.MagicLink{},
+		&model.PasswordHistory{},
+		&model.OIDCState{},
+		&model.ExternalIdentity{},
+		&model.Impersonation{},
 	); err != nil {
 		panic(fmt.Sprintf("❌ migration error: %v", err))
 	}
 
+	logger.Init("AuthServiceTest")
+
 	// создаём repo/service
 	repo := repos.NewAuthRepo(db)
-	testSvc = service.NewAuthService(repo)
+	testSvc = service.NewAuthService(repo, newTestAttempts())
 
 	// запускаем все тесты
 	code := m.Run()
 	os.Exit(code)
 }
 
+// счётчики попыток в памяти, время — по часам сервиса
+func newTestAttempts() repos.AttemptStore {
+	return repos.NewMemoryAttemptStore(func() time.Time { return testSvc.Clock() })
+}
+
 // =========================================================
 //           Helper — очищаем таблицы перед тестом
 // =========================================================
 
 func resetTables(t *testing.T) {
 	err := testDB.Exec(`
-		TRUNCATE users, refresh_tokens, password_resets 
+		TRUNCATE users, refresh_tokens, password_resets, email_verifications,
+			recovery_codes, two_factor_challenges, account_deletions, account_deletion_steps,
+			data_exports, personal_access_tokens, email_changes, username_holds,
+			outbox_emails, security_events, magic_links, password_histories, oidc_states, external_identities, impersonations
 		RESTART IDENTITY CASCADE;
 	`).Error
 	if err != nil {
 		t.Fatalf("❌ reset error: %v", err)
 	}
+	testSvc.Attempts = newTestAttempts()
+	testSvc.DeletionSteps = nil
+	testSvc.ExportSources = nil
+	testSvc.ExportStore = nil
+	testMailer = &mailer.FakeMailer{}
+	testSvc.Mailer = testMailer
+	testSvc.AppBaseURL = "https://app.example.test"
+	testSvc.PasswordPolicy = utils.DefaultPasswordPolicy()
+	testSvc.SyncHandle = nil
 }
 
 // =========================================================
@@ -93,7 +172,7 @@ func TestRegister_Success(t *testing.T) {
 		Username: "reguser",
 	}
 
-	access, refresh, err := testSvc.Register(req)
+	access, refresh, err := testSvc.Register(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, access)
@@ -105,14 +184,14 @@ func TestRegister_Success(t *testing.T) {
 func TestRegister_DuplicateEmail(t *testing.T) {
 	resetTables(t)
 
-	_, _, err := testSvc.Register(&pb.RegisterRequest{
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
 		Email:    "dup@example.com",
 		Password: "12345678Aa!",
 		Username: "dupuser",
 	})
 	assert.NoError(t, err)
 
-	_, _, err2 := testSvc.Register(&pb.RegisterRequest{
+	_, _, err2 := testSvc.Register(context.Background(), &pb.RegisterRequest{
 		Email:    "dup@example.com",
 		Password: "12345678Aa!",
 		Username: "dupuser",
@@ -129,21 +208,22 @@ func TestLogin_Success(t *testing.T) {
 	email := "login_ok@example.com"
 	pass := "Pass123456!"
 
-	_, _, err := testSvc.Register(&pb.RegisterRequest{
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
 		Email:    email,
 		Password: pass,
 		Username: "testlogin",
 	})
 	assert.NoError(t, err)
 
-	access, refresh, err := testSvc.Login(&pb.LoginRequest{
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{
 		Email:    email,
 		Password: pass,
 	})
 
 	assert.NoError(t, err)
-	assert.NotEmpty(t, access)
-	assert.NotEmpty(t, refresh)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.False(t, resp.TwoFactorRequired)
 }
 
 // ------------- Login Wrong Password -----------------------
@@ -153,14 +233,14 @@ func TestLogin_WrongPassword(t *testing.T) {
 
 	email := "wrongp@example.com"
 
-	_, _, err := testSvc.Register(&pb.RegisterRequest{
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
 		Email:    email,
 		Password: "CorrectPass123!",
 		Username: "wrongp",
 	})
 	assert.NoError(t, err)
 
-	_, _, err = testSvc.Login(&pb.LoginRequest{
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{
 		Email:    email,
 		Password: "WrongP",
 	})
@@ -176,24 +256,186 @@ func TestRefreshToken_Success(t *testing.T) {
 	email := "ref@example.com"
 	pass := "Pass123456!"
 
-	_, _, err := testSvc.Register(&pb.RegisterRequest{
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
 		Email:    email,
 		Password: pass,
 		Username: "refuser",
 	})
 	assert.NoError(t, err)
 
-	_, refresh, err := testSvc.Login(&pb.LoginRequest{
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{
 		Email:    email,
 		Password: pass,
 	})
 	assert.NoError(t, err)
+	refresh := resp.RefreshToken
 
-	newAccess, err := testSvc.RefreshToken(&pb.RefreshRequest{
+	newAccess, newRefresh, err := testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{
 		RefreshToken: refresh,
 	})
 	assert.NoError(t, err)
 	assert.NotEmpty(t, newAccess)
+	assert.NotEmpty(t, newRefresh)
+	assert.NotEqual(t, refresh, newRefresh)
+}
+
+// ------------- Refresh Token Reuse Revokes Session -------------
+
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	resetTables(t)
+
+	_, refresh, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    "reuse@example.com",
+		Password: "Pass123456!",
+		Username: "reuseuser",
+	})
+	assert.NoError(t, err)
+
+	_, rotated, err := testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: refresh})
+	assert.NoError(t, err)
+
+	// старый токен предъявлен повторно → вся сессия отзывается
+	_, _, err = testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: refresh})
+	assert.Error(t, err)
+
+	_, _, err = testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: rotated})
+	assert.Error(t, err)
+}
+
+// ------------------- Multiple Sessions ---------------------
+
+func TestLogin_KeepsOtherSessions(t *testing.T) {
+	resetTables(t)
+
+	email := "multi@example.com"
+	pass := "Pass123456!"
+
+	_, laptop, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		Username: "multiuser",
+	})
+	assert.NoError(t, err)
+
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+
+	// вход с телефона не разлогинивает ноутбук
+	_, _, err = testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: laptop})
+	assert.NoError(t, err)
+
+	user, err := testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+
+	sessions, err := testSvc.ListSessions(user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+}
+
+// ------------------- Revoke / Logout -----------------------
+
+func TestRevokeSessionAndLogoutAll(t *testing.T) {
+	resetTables(t)
+
+	email := "revoke@example.com"
+	pass := "Pass123456!"
+
+	_, first, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		Username: "revokeuser",
+	})
+	assert.NoError(t, err)
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+
+	user, err := testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+
+	sessions, err := testSvc.ListSessions(user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	assert.NoError(t, testSvc.RevokeSession(context.Background(), user.ID, sessions[0].FamilyID))
+	assert.Error(t, testSvc.RevokeSession(context.Background(), user.ID+1, sessions[1].FamilyID))
+
+	sessions, err = testSvc.ListSessions(user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+
+	assert.NoError(t, testSvc.LogoutAll(context.Background(), user.ID))
+
+	_, _, err = testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: first})
+	assert.Error(t, err)
+	_, _, err = testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: resp.RefreshToken})
+	assert.Error(t, err)
+}
+
+// ------------------- Token introspection -------------------
+
+func TestIntrospectToken_RevokedSessionsAndPasswordChange(t *testing.T) {
+	resetTables(t)
+
+	email := "introspect@example.com"
+	pass := "Pass123456!"
+	first, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		Username: "introspect",
+	})
+	assert.NoError(t, err)
+	second, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+	user, err := testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+
+	active := func(token string) bool {
+		t.Helper()
+		resp, err := testSvc.IntrospectToken(token)
+		assert.NoError(t, err)
+		return resp.Active
+	}
+	assert.True(t, active(first))
+	assert.True(t, active(second.AccessToken))
+	assert.False(t, active("not-a-token"))
+
+	// завершение сессии отзывает и её access токен
+	claims, err := utils2.ParseToken(second.AccessToken)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, claims.SessionID)
+	assert.NoError(t, testSvc.RevokeSession(context.Background(), user.ID, claims.SessionID))
+	assert.False(t, active(second.AccessToken))
+	assert.True(t, active(first))
+
+	// смена пароля завершает все сессии, вызывающий продолжает в новой
+	access, refresh, err := testSvc.UpdatePassword(context.Background(), user.ID, &pb.UpdatePasswordRequest{
+		CurrentPassword: pass,
+		NewPassword:     "NewPass654321!",
+	})
+	assert.NoError(t, err)
+	assert.False(t, active(first))
+	assert.True(t, active(access))
+	_, _, err = testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: refresh})
+	assert.NoError(t, err)
+
+	// сброс пароля — тоже
+	assert.NoError(t, testSvc.ForgotPassword(context.Background(), &pb.ForgotPasswordRequest{Email: email}))
+	reset := &model.PasswordReset{}
+	assert.NoError(t, testDB.Where("user_id = ?", user.ID).First(reset).Error)
+	assert.NoError(t, testSvc.ResetPassword(context.Background(), &pb.ResetPasswordRequest{
+		ResetToken:      reset.Token,
+		NewPassword:     "ResetPass987654!",
+		ConfirmPassword: "ResetPass987654!",
+	}))
+	assert.False(t, active(access))
+
+	// токен без sid отсекается только по времени выпуска
+	legacy, err := utils2.SignToken(fmt.Sprint(user.ID), user.Username, utils2.RoleUser, false, "")
+	assert.NoError(t, err)
+	assert.True(t, active(legacy))
+	assert.NoError(t, testDB.Model(&model.User{}).Where("id = ?", user.ID).
+		Update("tokens_valid_after", time.Now().Add(time.Hour)).Error)
+	assert.False(t, active(legacy))
 }
 
 // -------------------- Update Password OK --------------------
@@ -205,7 +447,7 @@ func TestUpdatePassword_Success(t *testing.T) {
 	old := "OldPass123!"
 	newp := "NewPass321!"
 
-	_, _, err := testSvc.Register(&pb.RegisterRequest{
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
 		Email:    email,
 		Password: old,
 		Username: "upduser",
@@ -215,20 +457,116 @@ func TestUpdatePassword_Success(t *testing.T) {
 	user, err := testSvc.Repo.GetUserByEmail(email)
 	assert.NoError(t, err)
 
-	_, err = testSvc.UpdatePassword(&pb.UpdatePasswordRequest{
-		Id:              fmt.Sprint(user.ID),
+	_, _, err = testSvc.UpdatePassword(context.Background(), user.ID, &pb.UpdatePasswordRequest{
 		CurrentPassword: old,
 		NewPassword:     newp,
 	})
 	assert.NoError(t, err)
 
-	_, _, err = testSvc.Login(&pb.LoginRequest{
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{
 		Email:    email,
 		Password: newp,
 	})
 	assert.NoError(t, err)
 }
 
+func TestUpdatePassword_LockoutSharedWithLogin(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+	user := registerUser(t, "guess@example.com")
+
+	wrong := &pb.UpdatePasswordRequest{CurrentPassword: "WrongPass1!", NewPassword: "NewPass654321!"}
+	for i := 1; i <= 10; i++ {
+		_, _, err := testSvc.UpdatePassword(context.Background(), user.ID, wrong)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "attempt %d", i)
+		now = now.Add(time.Minute)
+		setClock(t, now)
+	}
+
+	// подбор через смену пароля блокирует и её, и вход
+	_, _, err := testSvc.UpdatePassword(context.Background(), user.ID,
+		&pb.UpdatePasswordRequest{CurrentPassword: "Pass123456!", NewPassword: "NewPass654321!"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: "guess@example.com", Password: "Pass123456!"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// -------------------- Password policy & rehash ------------------
+
+func TestPasswordPolicy_LengthBlocklistAndHistory(t *testing.T) {
+	resetTables(t)
+	testSvc.PasswordPolicy.HistorySize = 3
+
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{Email: "policy@example.com", Password: "Ab1!", Username: "policy"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = testSvc.Register(context.Background(), &pb.RegisterRequest{Email: "policy@example.com", Password: "Password123", Username: "policy"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	user := registerUser(t, "policy@example.com")
+	changePassword := func(current, next string) error {
+		_, _, err := testSvc.UpdatePassword(context.Background(), user.ID, &pb.UpdatePasswordRequest{
+			CurrentPassword: current, NewPassword: next,
+		})
+		return err
+	}
+
+	// текущий пароль и два предыдущих повторять нельзя
+	assert.Equal(t, codes.InvalidArgument, status.Code(changePassword("Pass123456!", "Pass123456!")))
+	assert.NoError(t, changePassword("Pass123456!", "Second-pass-2"))
+	assert.NoError(t, changePassword("Second-pass-2", "Third-pass-3"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(changePassword("Third-pass-3", "Pass123456!")))
+	assert.NoError(t, changePassword("Third-pass-3", "Fourth-pass-4"))
+	// первый пароль вышел за пределы истории
+	assert.NoError(t, changePassword("Fourth-pass-4", "Pass123456!"))
+
+	var kept int64
+	assert.NoError(t, testDB.Model(&model.PasswordHistory{}).Where("user_id = ?", user.ID).Count(&kept).Error)
+	assert.Equal(t, int64(2), kept)
+
+	// сброс пароля проверяется той же политикой, ссылка одноразовая
+	assert.NoError(t, testSvc.Repo.SaveResetToken(user.ID, "reset-token"))
+	err = testSvc.ResetPassword(context.Background(), &pb.ResetPasswordRequest{ResetToken: "reset-token", NewPassword: "Fourth-pass-4"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = testSvc.ResetPassword(context.Background(), &pb.ResetPasswordRequest{ResetToken: "reset-token", NewPassword: "qwerty123"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, testSvc.ResetPassword(context.Background(), &pb.ResetPasswordRequest{ResetToken: "reset-token", NewPassword: "Fifth-pass-5"}))
+	err = testSvc.ResetPassword(context.Background(), &pb.ResetPasswordRequest{ResetToken: "reset-token", NewPassword: "Sixth-pass-6"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPasswordHash_LegacyFormatUpgradedOnLogin(t *testing.T) {
+	resetTables(t)
+	user := registerUser(t, "legacy@example.com")
+
+	// хэш старого формата: base64(salt).base64(hash), argon2id t=1, 64 MiB
+	salt := []byte("0123456789abcdef")
+	legacy := base64.StdEncoding.EncodeToString(salt) + "." +
+		base64.StdEncoding.EncodeToString(argon2.IDKey([]byte("Pass123456!"), salt, 1, 64*1024, 4, 32))
+	assert.NoError(t, testSvc.Repo.UpdatePassword(user.ID, legacy))
+	assert.True(t, utils.NeedsRehash(legacy))
+
+	_, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: "legacy@example.com", Password: "WrongPass1!"})
+	assert.Error(t, err)
+	stored, err := testSvc.Repo.GetUserById(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, stored.Password)
+
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: "legacy@example.com", Password: "Pass123456!"})
+	assert.NoError(t, err)
+	stored, err = testSvc.Repo.GetUserById(user.ID)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stored.Password, "$argon2id$v=19$m=65536,t=3,p=4$"), stored.Password)
+	assert.False(t, utils.NeedsRehash(stored.Password))
+
+	// пароль после пересчёта прежний, в историю пересчёт не попадает
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: "legacy@example.com", Password: "Pass123456!"})
+	assert.NoError(t, err)
+	var history int64
+	assert.NoError(t, testDB.Model(&model.PasswordHistory{}).Where("user_id = ?", user.ID).Count(&history).Error)
+	assert.Zero(t, history)
+}
+
 // -------------------- GetProfile OK -------------------------
 
 func TestGetProfile_Success(t *testing.T) {
@@ -237,7 +575,7 @@ func TestGetProfile_Success(t *testing.T) {
 	email := "prof@example.com"
 	pass := "Pass123456!"
 
-	_, _, err := testSvc.Register(&pb.RegisterRequest{
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
 		Email:    email,
 		Password: pass,
 		Username: "profuser",
@@ -253,3 +591,1647 @@ func TestGetProfile_Success(t *testing.T) {
 	assert.Equal(t, email, profile.Email)
 	assert.Equal(t, "profuser", profile.Username)
 }
+
+// -------------------- Verify Email ---------------------------
+
+func TestVerifyEmail_Success(t *testing.T) {
+	resetTables(t)
+
+	email := "verify@example.com"
+
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: "Pass123456!",
+		Username: "verifyuser",
+	})
+	assert.NoError(t, err)
+
+	user, err := testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+	assert.False(t, user.EmailVerified)
+
+	verification := &model.EmailVerification{}
+	assert.NoError(t, testDB.Where("user_id = ?", user.ID).First(verification).Error)
+
+	assert.NoError(t, testSvc.VerifyEmail(&pb.VerifyEmailRequest{Token: verification.Token}))
+
+	user, err = testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+	assert.True(t, user.EmailVerified)
+
+	// токен одноразовый
+	assert.Error(t, testSvc.VerifyEmail(&pb.VerifyEmailRequest{Token: verification.Token}))
+	assert.Error(t, testSvc.ResendVerification(user.ID))
+}
+
+func TestVerifyEmail_InvalidToken(t *testing.T) {
+	resetTables(t)
+
+	err := testSvc.VerifyEmail(&pb.VerifyEmailRequest{Token: "not-a-token"})
+	assert.Error(t, err)
+}
+
+// -------------------- Two-Factor ----------------------------
+
+// фиксированные часы: коды TOTP в тестах детерминированы
+func setClock(t *testing.T, now time.Time) {
+	testSvc.Clock = func() time.Time { return now }
+	t.Cleanup(func() { testSvc.Clock = time.Now })
+}
+
+func totpAt(t *testing.T, secret string, now time.Time) string {
+	code, err := utils.TOTPCode(secret, utils.TOTPStep(now))
+	assert.NoError(t, err)
+	return code
+}
+
+// регистрирует пользователя и включает 2FA, возвращает секрет и коды восстановления
+func enableTwoFactor(t *testing.T, email, pass string, now time.Time) (*model.User, string, []string) {
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		Username: "tfa_" + email[:3],
+	})
+	assert.NoError(t, err)
+
+	user, err := testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+
+	secret, uri, err := testSvc.EnrollTwoFactor(user.ID)
+	assert.NoError(t, err)
+	assert.Contains(t, uri, "otpauth://totp/")
+	assert.Contains(t, uri, "secret="+secret)
+
+	recoveryCodes, err := testSvc.ConfirmTwoFactor(context.Background(), user.ID, totpAt(t, secret, now))
+	assert.NoError(t, err)
+	assert.Len(t, recoveryCodes, 10)
+
+	return user, secret, recoveryCodes
+}
+
+func TestTOTPCode_RFC6238Vector(t *testing.T) {
+	// тестовый вектор RFC 6238 для SHA1 (ключ "12345678901234567890"), последние 6 цифр
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	code, err := utils.TOTPCode(secret, utils.TOTPStep(time.Unix(59, 0)))
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	code, err = utils.TOTPCode(secret, utils.TOTPStep(time.Unix(1111111109, 0)))
+	assert.NoError(t, err)
+	assert.Equal(t, "081804", code)
+}
+
+func TestTwoFactor_LoginFlow(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	email := "tfa@example.com"
+	pass := "Pass123456!"
+	_, secret, _ := enableTwoFactor(t, email, pass, now)
+
+	// пароль верный, но токенов нет — только challenge
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.Empty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.ChallengeToken)
+
+	// код, уже использованный при подтверждении, повторно не принимается
+	_, _, err = testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+		ChallengeToken: resp.ChallengeToken,
+		Code:           totpAt(t, secret, now),
+	})
+	assert.Error(t, err)
+
+	later := now.Add(30 * time.Second)
+	setClock(t, later)
+	access, refresh, err := testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+		ChallengeToken: resp.ChallengeToken,
+		Code:           totpAt(t, secret, later),
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, access)
+	assert.NotEmpty(t, refresh)
+
+	// challenge одноразовый
+	_, _, err = testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+		ChallengeToken: resp.ChallengeToken,
+		Code:           totpAt(t, secret, later.Add(30*time.Second)),
+	})
+	assert.Error(t, err)
+}
+
+func TestTwoFactor_ConcurrentGuessesLimited(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	email := "guesses@example.com"
+	pass := "Pass123456!"
+	_, secret, _ := enableTwoFactor(t, email, pass, now)
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+
+	// параллельные подборы одного challenge вместе не получают больше 5 проверок кода
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	checked := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, err := testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+				ChallengeToken: resp.ChallengeToken,
+				Code:           fmt.Sprintf("%06d", i),
+			})
+			if status.Convert(err).Message() == "invalid code" {
+				mu.Lock()
+				checked++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, checked, 5)
+
+	later := now.Add(30 * time.Second)
+	setClock(t, later)
+	_, _, err = testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+		ChallengeToken: resp.ChallengeToken,
+		Code:           totpAt(t, secret, later),
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestTwoFactor_ChallengeExpires(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	email := "exp@example.com"
+	pass := "Pass123456!"
+	_, secret, _ := enableTwoFactor(t, email, pass, now)
+
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+
+	later := now.Add(10 * time.Minute)
+	setClock(t, later)
+	_, _, err = testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+		ChallengeToken: resp.ChallengeToken,
+		Code:           totpAt(t, secret, later),
+	})
+	assert.Error(t, err)
+}
+
+func TestTwoFactor_RecoveryCodeSingleUse(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	email := "rec@example.com"
+	pass := "Pass123456!"
+	user, _, recoveryCodes := enableTwoFactor(t, email, pass, now)
+
+	// коды в БД только в виде хэша
+	var stored model.RecoveryCode
+	assert.NoError(t, testDB.Where("user_id = ?", user.ID).First(&stored).Error)
+	assert.NotContains(t, recoveryCodes, stored.CodeHash)
+
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+	_, _, err = testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+		ChallengeToken: resp.ChallengeToken,
+		Code:           recoveryCodes[0],
+	})
+	assert.NoError(t, err)
+
+	resp, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+	_, _, err = testSvc.VerifyTwoFactor(context.Background(), &pb.VerifyTwoFactorRequest{
+		ChallengeToken: resp.ChallengeToken,
+		Code:           recoveryCodes[0],
+	})
+	assert.Error(t, err)
+}
+
+func TestTwoFactor_Disable(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	email := "dis@example.com"
+	pass := "Pass123456!"
+	user, _, recoveryCodes := enableTwoFactor(t, email, pass, now)
+
+	assert.Error(t, testSvc.DisableTwoFactor(context.Background(), user.ID, "WrongPass1!", recoveryCodes[1]))
+	assert.NoError(t, testSvc.DisableTwoFactor(context.Background(), user.ID, pass, recoveryCodes[1]))
+
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+	assert.False(t, resp.TwoFactorRequired)
+	assert.NotEmpty(t, resp.AccessToken)
+}
+
+// -------------------- JWKS -----------------------------------
+
+func TestJWKS_VerifiesIssuedTokens(t *testing.T) {
+	resetTables(t)
+
+	access, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    "jwks@example.com",
+		Password: "Pass123456!",
+		Username: "jwksuser",
+	})
+	assert.NoError(t, err)
+
+	// проверяющей стороне достаточно опубликованных публичных ключей
+	jwks, err := testSvc.JWKS()
+	assert.NoError(t, err)
+	verifier, err := utils2.ParseJWKS(jwks)
+	assert.NoError(t, err)
+
+	claims := &utils2.Claims{}
+	_, err = verifier.Parse(access, claims)
+	assert.NoError(t, err)
+	assert.Equal(t, "jwksuser", claims.Username)
+
+	// токен, подписанный чужим ключом, не принимается
+	foreign, err := utils2.NewEphemeralKeySet()
+	assert.NoError(t, err)
+	forged, err := foreign.Sign(&utils2.Claims{UserID: claims.UserID, Username: claims.Username})
+	assert.NoError(t, err)
+	_, err = verifier.Parse(forged, &utils2.Claims{})
+	assert.Error(t, err)
+}
+
+// -------------------- Roles --------------------------------
+
+func TestSetUserRole_RoleInRefreshedToken(t *testing.T) {
+	resetTables(t)
+
+	admin := registerUser(t, "roleadmin@example.com")
+	mod := registerUser(t, "rolemod@example.com")
+	t.Setenv("AUTH_ADMIN_IDS", fmt.Sprint(admin.ID))
+	assert.NoError(t, testSvc.BootstrapAdmins())
+
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: "rolemod@example.com", Password: "Pass123456!"})
+	assert.NoError(t, err)
+	claims, err := utils2.ParseToken(resp.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, utils2.RoleUser, claims.Role)
+
+	// назначать роли может только администратор
+	err = testSvc.SetUserRole(context.Background(), mod.ID, fmt.Sprint(mod.ID), utils2.RoleAdmin)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = testSvc.SetUserRole(context.Background(), admin.ID, fmt.Sprint(mod.ID), "owner")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = testSvc.SetUserRole(context.Background(), admin.ID, fmt.Sprint(admin.ID), utils2.RoleUser)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	assert.NoError(t, testSvc.SetUserRole(context.Background(), admin.ID, fmt.Sprint(mod.ID), utils2.RoleModerator))
+
+	access, _, err := testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: resp.RefreshToken})
+	assert.NoError(t, err)
+	claims, err = utils2.ParseToken(access)
+	assert.NoError(t, err)
+	assert.Equal(t, utils2.RoleModerator, claims.Role)
+
+	// снятая роль перестаёт действовать сразу, независимо от выданного токена
+	assert.NoError(t, testSvc.SetUserRole(context.Background(), admin.ID, fmt.Sprint(mod.ID), utils2.RoleAdmin))
+	assert.NoError(t, testSvc.SetUserRole(context.Background(), admin.ID, fmt.Sprint(mod.ID), utils2.RoleUser))
+	err = testSvc.UnlockAccount(context.Background(), mod.ID, fmt.Sprint(admin.ID))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+// -------------------- Impersonation --------------------------
+
+func TestImpersonation_ScopedAndAudited(t *testing.T) {
+	resetTables(t)
+
+	admin := registerUser(t, "impadmin@example.com")
+	other := registerUser(t, "impother@example.com")
+	target := registerUser(t, "imptarget@example.com")
+	t.Setenv("AUTH_ADMIN_IDS", fmt.Sprintf("%d,%d", admin.ID, other.ID))
+	assert.NoError(t, testSvc.BootstrapAdmins())
+	ctx := context.Background()
+
+	_, _, err := testSvc.Impersonate(ctx, target.ID, &pb.ImpersonateRequest{UserId: fmt.Sprint(admin.ID), Reason: "ticket 1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, _, err = testSvc.Impersonate(ctx, admin.ID, &pb.ImpersonateRequest{UserId: fmt.Sprint(target.ID)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	// чужие права администратора так не получить
+	_, _, err = testSvc.Impersonate(ctx, admin.ID, &pb.ImpersonateRequest{UserId: fmt.Sprint(other.ID), Reason: "ticket 1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	token, imp, err := testSvc.Impersonate(ctx, admin.ID, &pb.ImpersonateRequest{UserId: fmt.Sprint(target.ID), Reason: "ticket 42"})
+	assert.NoError(t, err)
+	claims, err := utils2.ParseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprint(target.ID), claims.UserID)
+	assert.Equal(t, utils2.RoleUser, claims.Role)
+	assert.Equal(t, fmt.Sprint(admin.ID), claims.Actor.Subject)
+	assert.Equal(t, imp.ID, claims.SessionID)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, time.Minute)
+
+	introspection, err := testSvc.IntrospectToken(token)
+	assert.NoError(t, err)
+	assert.True(t, introspection.Active)
+	assert.Equal(t, fmt.Sprint(admin.ID), introspection.ActorId)
+
+	// смена пароля, почты и т.п. под имперсонацией запрещена
+	forbid := interceptor.ForbidImpersonation(pb.AuthService_UpdatePassword_FullMethodName)
+	impersonated := context.WithValue(ctx, contextx.ActorIDKey, fmt.Sprint(admin.ID))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err = forbid(impersonated, nil, &grpc.UnaryServerInfo{FullMethod: pb.AuthService_UpdatePassword_FullMethodName}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = forbid(impersonated, nil, &grpc.UnaryServerInfo{FullMethod: pb.AuthService_GetProfile_FullMethodName}, handler)
+	assert.NoError(t, err)
+	_, err = forbid(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.AuthService_UpdatePassword_FullMethodName}, handler)
+	assert.NoError(t, err)
+
+	// каждый запрос под имперсонацией — в журнале с автором
+	assert.NoError(t, testSvc.RecordImpersonatedRequest(ctx, &pb.RecordImpersonatedRequestRequest{
+		ActorId: fmt.Sprint(admin.ID), UserId: fmt.Sprint(target.ID), SessionId: imp.ID,
+		Method: "GET", Path: "/api/v1/feed", Status: 200,
+	}))
+	events, _, err := testSvc.AdminListSecurityEvents(admin.ID, &pb.AdminListSecurityEventsRequest{ActorId: fmt.Sprint(admin.ID)})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{model.EventImpersonatedRequest, model.EventImpersonationStarted}, eventTypes(events))
+	assert.Equal(t, target.ID, *events[0].UserID)
+	assert.Contains(t, events[0].Details, "GET /api/v1/feed 200")
+
+	// завершить может только начавший администратор, после этого токен отозван
+	assert.Equal(t, codes.NotFound, status.Code(testSvc.EndImpersonation(ctx, other.ID, imp.ID)))
+	assert.NoError(t, testSvc.EndImpersonation(ctx, admin.ID, imp.ID))
+	introspection, err = testSvc.IntrospectToken(token)
+	assert.NoError(t, err)
+	assert.False(t, introspection.Active)
+
+	// администратор без роли теряет и выданные имперсонации
+	token, _, err = testSvc.Impersonate(ctx, admin.ID, &pb.ImpersonateRequest{UserId: fmt.Sprint(target.ID), Reason: "ticket 43"})
+	assert.NoError(t, err)
+	assert.NoError(t, testSvc.SetUserRole(ctx, other.ID, fmt.Sprint(admin.ID), utils2.RoleUser))
+	introspection, err = testSvc.IntrospectToken(token)
+	assert.NoError(t, err)
+	assert.False(t, introspection.Active)
+}
+
+// -------------------- Personal access tokens ------------------
+
+func TestPersonalAccessToken_Lifecycle(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	user := registerUser(t, "pat@example.com")
+
+	_, _, err := testSvc.CreatePersonalAccessToken(context.Background(), user.ID, &pb.CreatePersonalAccessTokenRequest{
+		Name: "ci", Scopes: []string{"posts:write", "admin:all"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	raw, token, err := testSvc.CreatePersonalAccessToken(context.Background(), user.ID, &pb.CreatePersonalAccessTokenRequest{
+		Name: "ci", Scopes: []string{"posts:write", "chat:read", "posts:write"}, ExpiresInDays: 30,
+	})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(raw, utils2.PersonalTokenPrefix))
+	assert.True(t, strings.HasPrefix(raw, token.Prefix))
+	assert.NotContains(t, token.TokenHash, raw)
+
+	identity, scopes, err := testSvc.ValidatePersonalAccessToken(context.Background(), raw)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, identity.ID)
+	assert.Equal(t, []string{"posts:write", "chat:read"}, scopes)
+
+	tokens, err := testSvc.ListPersonalAccessTokens(user.ID, "")
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.NotNil(t, tokens[0].LastUsedAt)
+
+	// чужой токен отозвать нельзя
+	other := registerUser(t, "patother@example.com")
+	err = testSvc.RevokePersonalAccessToken(other.ID, fmt.Sprint(token.ID))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// истёкший токен не принимается
+	setClock(t, now.Add(31*24*time.Hour))
+	_, _, err = testSvc.ValidatePersonalAccessToken(context.Background(), raw)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	setClock(t, now)
+	assert.NoError(t, testSvc.RevokePersonalAccessToken(user.ID, fmt.Sprint(token.ID)))
+	_, _, err = testSvc.ValidatePersonalAccessToken(context.Background(), raw)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestBot_AuthenticatesOnlyWithTokens(t *testing.T) {
+	resetTables(t)
+
+	owner := registerUser(t, "botowner@example.com")
+	_, err := testSvc.CreateBot(context.Background(), owner.ID, "deploy_bot")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	assert.NoError(t, testSvc.Repo.MarkEmailVerified(owner.ID))
+	bot, err := testSvc.CreateBot(context.Background(), owner.ID, "deploy_bot")
+	assert.NoError(t, err)
+	assert.True(t, bot.Bot)
+
+	_, err = testSvc.CreateBot(context.Background(), owner.ID, "Deploy_Bot")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// пароля у бота нет
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: bot.Email, Password: ""})
+	assert.Error(t, err)
+
+	// токены бота выпускает только владелец
+	stranger := registerUser(t, "stranger@example.com")
+	_, _, err = testSvc.CreatePersonalAccessToken(context.Background(), stranger.ID, &pb.CreatePersonalAccessTokenRequest{
+		Name: "steal", Scopes: []string{"posts:write"}, BotId: fmt.Sprint(bot.ID),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	raw, _, err := testSvc.CreatePersonalAccessToken(context.Background(), owner.ID, &pb.CreatePersonalAccessTokenRequest{
+		Name: "deploy", Scopes: []string{"posts:write"}, BotId: fmt.Sprint(bot.ID),
+	})
+	assert.NoError(t, err)
+
+	identity, _, err := testSvc.ValidatePersonalAccessToken(context.Background(), raw)
+	assert.NoError(t, err)
+	assert.Equal(t, bot.ID, identity.ID)
+	assert.True(t, identity.Bot)
+
+	bots, err := testSvc.ListBots(owner.ID)
+	assert.NoError(t, err)
+	assert.Len(t, bots, 1)
+}
+
+// -------------------- Email / username change -----------------
+
+func TestBackfillEmailVerified_GrandfathersExistingAccounts(t *testing.T) {
+	resetTables(t)
+	old := registerUser(t, "before@example.com")
+	// состояние до появления проверки email
+	assert.NoError(t, testDB.Migrator().DropColumn(&model.User{}, "EmailVerified"))
+
+	n, err := testSvc.Repo.BackfillEmailVerified()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = testSvc.Repo.BackfillEmailVerified()
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+
+	old, err = testSvc.Repo.GetUserById(old.ID)
+	assert.NoError(t, err)
+	assert.True(t, old.EmailVerified)
+	assert.False(t, registerUser(t, "after@example.com").EmailVerified)
+}
+
+func TestDropLegacyRefreshTokens(t *testing.T) {
+	resetTables(t)
+	user := registerUser(t, "legacy_token@example.com")
+	// таблица до появления сессий: токены без семейства
+	assert.NoError(t, testDB.Migrator().DropColumn(&model.RefreshToken{}, "FamilyID"))
+
+	n, err := testSvc.Repo.DropLegacyRefreshTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, testDB.AutoMigrate(&model.RefreshToken{}))
+	n, err = testSvc.Repo.DropLegacyRefreshTokens()
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+
+	// новые сессии после миграции работают как обычно
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: user.Email, Password: "Pass123456!"})
+	assert.NoError(t, err)
+}
+
+func TestChangeEmail_ConfirmFlow(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	user := registerUser(t, "oldmail@example.com")
+	registerUser(t, "busy@example.com")
+
+	err := testSvc.RequestEmailChange(context.Background(), user.ID, "new@example.com", "WrongPass1!")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = testSvc.RequestEmailChange(context.Background(), user.ID, "Busy@example.com", "Pass123456!")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	assert.NoError(t, testSvc.RequestEmailChange(context.Background(), user.ID, "new@example.com", "Pass123456!"))
+	_, err = testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+
+	notice, ok := testMailer.Last("oldmail@example.com")
+	assert.True(t, ok)
+	assert.Contains(t, notice.Text, "n***@example.com")
+	confirm, ok := testMailer.Last("new@example.com")
+	assert.True(t, ok)
+	token := mailToken(t, confirm, "https://app.example.test/confirm-email?token=")
+
+	setClock(t, now.Add(25*time.Hour))
+	err = testSvc.ConfirmEmailChange(context.Background(), token)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	setClock(t, now)
+	assert.NoError(t, testSvc.ConfirmEmailChange(context.Background(), token))
+	err = testSvc.ConfirmEmailChange(context.Background(), token)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	updated, err := testSvc.Repo.GetUserById(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", updated.Email)
+	assert.True(t, updated.EmailVerified)
+
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: "new@example.com", Password: "Pass123456!"})
+	assert.NoError(t, err)
+}
+
+func TestChangeEmail_PasswordAttemptsLimited(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+	user := registerUser(t, "probe@example.com")
+
+	for i := 1; i <= 10; i++ {
+		err := testSvc.RequestEmailChange(context.Background(), user.ID, "other@example.com", "WrongPass1!")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "attempt %d", i)
+		now = now.Add(time.Minute)
+		setClock(t, now)
+	}
+	err := testSvc.RequestEmailChange(context.Background(), user.ID, "other@example.com", "Pass123456!")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestChangeUsername_CooldownAndHold(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	alice := registerUser(t, "alice@example.com")
+	bob := registerUser(t, "bob@example.com")
+
+	_, _, err := testSvc.ChangeUsername(context.Background(), alice.ID, "Admin")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = testSvc.ChangeUsername(context.Background(), alice.ID, "BOB")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, access, err := testSvc.ChangeUsername(context.Background(), alice.ID, "alice_new")
+	assert.NoError(t, err)
+	claims, err := utils2.ParseToken(access)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice_new", claims.Username)
+
+	_, _, err = testSvc.ChangeUsername(context.Background(), alice.ID, "alice_again")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// прежнее имя закреплено за alice
+	setClock(t, now.Add(10*24*time.Hour))
+	_, _, err = testSvc.ChangeUsername(context.Background(), bob.ID, "alice")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, _, err = testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email: "fake@example.com", Password: "Pass123456!", Username: "alice",
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// после окончания закрепления имя свободно
+	setClock(t, now.Add(91*24*time.Hour))
+	_, _, err = testSvc.ChangeUsername(context.Background(), bob.ID, "alice")
+	assert.NoError(t, err)
+
+	// своё прежнее имя можно вернуть, пока оно закреплено, чужое — нет
+	setClock(t, now.Add(122*24*time.Hour))
+	_, _, err = testSvc.ChangeUsername(context.Background(), alice.ID, "bob")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, _, err = testSvc.ChangeUsername(context.Background(), bob.ID, "bob")
+	assert.NoError(t, err)
+
+	// смена только регистра своего имени не считается занятым именем
+	setClock(t, now.Add(160*24*time.Hour))
+	user, _, err := testSvc.ChangeUsername(context.Background(), bob.ID, "Bob")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob", user.Username)
+}
+
+// -------------------- Handle sync ----------------------------
+
+func TestHandleSync_RetriesUntilDelivered(t *testing.T) {
+	resetTables(t)
+	handles := map[string]string{}
+	down := true
+	testSvc.SyncHandle = func(ctx context.Context, userID, handle string) error {
+		if down {
+			return errors.New("user service unavailable")
+		}
+		handles[userID] = handle
+		return nil
+	}
+
+	// регистрация проходит, даже если user сервис недоступен
+	alice := registerUser(t, "alice@example.com")
+	aliceID := fmt.Sprint(alice.ID)
+	assert.Empty(t, handles)
+
+	down = false
+	n, err := testSvc.RunHandleSync(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "alice", handles[aliceID])
+
+	// уже синхронизированные не отправляются повторно
+	n, _ = testSvc.RunHandleSync(context.Background())
+	assert.Equal(t, 0, n)
+
+	_, _, err = testSvc.ChangeUsername(context.Background(), alice.ID, "alice_new")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice_new", handles[aliceID])
+	n, _ = testSvc.RunHandleSync(context.Background())
+	assert.Equal(t, 0, n)
+}
+
+func TestHandleSync_RejectedUsernameDoesNotBlockBatch(t *testing.T) {
+	resetTables(t)
+	handles := map[string]string{}
+	testSvc.SyncHandle = func(ctx context.Context, userID, handle string) error {
+		if strings.Contains(handle, ".") {
+			return status.Error(codes.InvalidArgument, "invalid handle")
+		}
+		handles[userID] = handle
+		return nil
+	}
+
+	// аккаунт, созданный до проверки username при регистрации
+	legacy := &model.User{Email: "legacy@example.com", Username: "john.doe", Password: "x"}
+	_, err := testSvc.Repo.RegisterDB(legacy)
+	assert.NoError(t, err)
+	bob := registerUser(t, "bob@example.com")
+	assert.NoError(t, testSvc.Repo.Db.Model(&model.User{}).Where("id = ?", bob.ID).
+		Update("handle_synced", false).Error)
+
+	n, err := testSvc.RunHandleSync(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "bob", handles[fmt.Sprint(bob.ID)])
+
+	// отвергнутое имя больше не отправляется, после смены — снова в очереди
+	n, err = testSvc.RunHandleSync(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	_, _, err = testSvc.ChangeUsername(context.Background(), legacy.ID, "john_doe")
+	assert.NoError(t, err)
+	assert.Equal(t, "john_doe", handles[fmt.Sprint(legacy.ID)])
+}
+
+func TestRegister_InvalidUsername(t *testing.T) {
+	resetTables(t)
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    "dot@example.com",
+		Password: "Pass123456!",
+		Username: "john.doe",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// -------------------- Lockout --------------------------------
+
+func TestLogin_LockoutAfterRepeatedFailures(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	email := "lock@example.com"
+	pass := "Pass123456!"
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		Username: "lockuser",
+	})
+	assert.NoError(t, err)
+
+	wrong := &pb.LoginRequest{Email: email, Password: "WrongPass1!"}
+	for i := 1; i <= 10; i++ {
+		_, err = testSvc.Login(context.Background(), wrong)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "attempt %d", i)
+
+		if i == 3 {
+			// после третьей неудачи сразу пробовать снова нельзя
+			_, err = testSvc.Login(context.Background(), wrong)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		}
+		now = now.Add(time.Minute)
+		setClock(t, now)
+	}
+
+	// аккаунт заблокирован — даже верный пароль не принимается
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	user, err := testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+
+	admin := registerUser(t, "lockadmin@example.com")
+	t.Setenv("AUTH_ADMIN_IDS", fmt.Sprint(admin.ID))
+	assert.NoError(t, testSvc.BootstrapAdmins())
+
+	err = testSvc.UnlockAccount(context.Background(), user.ID, fmt.Sprint(user.ID))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.NoError(t, testSvc.UnlockAccount(context.Background(), admin.ID, fmt.Sprint(user.ID)))
+
+	resp, err := testSvc.Login(context.Background(), &pb.LoginRequest{Email: email, Password: pass})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+}
+
+func TestLogin_UnknownEmailLooksLikeWrongPassword(t *testing.T) {
+	resetTables(t)
+
+	_, err := testSvc.Login(context.Background(), &pb.LoginRequest{
+		Email:    "nobody@example.com",
+		Password: "Pass123456!",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestForgotPassword_UniformResponse(t *testing.T) {
+	resetTables(t)
+
+	email := "forgot@example.com"
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: "Pass123456!",
+		Username: "forgotuser",
+	})
+	assert.NoError(t, err)
+
+	// ответ не зависит от того, зарегистрирован ли email
+	assert.NoError(t, testSvc.ForgotPassword(context.Background(), &pb.ForgotPasswordRequest{Email: "nobody@example.com"}))
+	assert.NoError(t, testSvc.ForgotPassword(context.Background(), &pb.ForgotPasswordRequest{Email: email}))
+
+	var resets int64
+	testDB.Model(&model.PasswordReset{}).Count(&resets)
+	assert.Equal(t, int64(1), resets)
+
+	// сверх лимита письма молча не отправляются
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, testSvc.ForgotPassword(context.Background(), &pb.ForgotPasswordRequest{Email: email}))
+	}
+	testDB.Model(&model.PasswordReset{}).Count(&resets)
+	assert.Equal(t, int64(3), resets)
+}
+
+// -------------------- Mail outbox ----------------------------
+
+// mailToken — токен из ссылки в письме
+func mailToken(t *testing.T, msg mailer.Message, linkPrefix string) string {
+	t.Helper()
+	i := strings.Index(msg.Text, linkPrefix)
+	if i < 0 {
+		t.Fatalf("no link %q in mail:\n%s", linkPrefix, msg.Text)
+	}
+	token, _, _ := strings.Cut(msg.Text[i+len(linkPrefix):], "\n")
+	return strings.TrimSpace(token)
+}
+
+func TestForgotPassword_MailFromOutboxInUserLocale(t *testing.T) {
+	resetTables(t)
+
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email: "reset-en@example.com", Password: "Pass123456!", Username: "reseten", Locale: "en",
+	})
+	assert.NoError(t, err)
+
+	// отправка недоступна — запрос всё равно успешен, письмо ждёт в outbox
+	testMailer.SetErr(errors.New("smtp down"))
+	assert.NoError(t, testSvc.ForgotPassword(context.Background(), &pb.ForgotPasswordRequest{Email: "reset-en@example.com"}))
+	sent, err := testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	testMailer.SetErr(nil)
+	setClock(t, time.Now().Add(time.Hour))
+	sent, err = testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent) // подтверждение email и сброс пароля
+
+	msg, ok := testMailer.Last("reset-en@example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "Password reset", msg.Subject)
+	assert.Contains(t, msg.HTML, `href="https://app.example.test/reset-password?token=`)
+	token := mailToken(t, msg, "https://app.example.test/reset-password?token=")
+	assert.NoError(t, testSvc.ResetPassword(context.Background(), &pb.ResetPasswordRequest{
+		ResetToken: token, NewPassword: "NewPass123456!",
+	}))
+
+	// после отправки тело письма в БД не хранится
+	var stored model.OutboxEmail
+	assert.NoError(t, testDB.Where("template = ?", mailer.TemplatePasswordReset).First(&stored).Error)
+	assert.Equal(t, model.MailSent, stored.Status)
+	assert.Empty(t, stored.TextBody)
+}
+
+func TestMailOutbox_BackoffAndGiveUp(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	registerUser(t, "bounce@example.com") // письмо с подтверждением email
+	testMailer.SetErr(errors.New("mailbox unavailable"))
+
+	var email model.OutboxEmail
+	for attempt := 1; attempt <= 8; attempt++ {
+		_, err := testSvc.RunPendingMail(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, testDB.First(&email).Error)
+		assert.Equal(t, attempt, email.Attempts)
+		if attempt < 8 {
+			assert.Equal(t, model.MailPending, email.Status)
+			assert.True(t, email.NextAttemptAt.After(testSvc.Clock()))
+
+			// до следующей попытки письмо не трогаем
+			_, err = testSvc.RunPendingMail(context.Background())
+			assert.NoError(t, err)
+			assert.NoError(t, testDB.First(&email).Error)
+			assert.Equal(t, attempt, email.Attempts)
+
+			setClock(t, email.NextAttemptAt)
+		}
+	}
+	assert.Equal(t, model.MailFailed, email.Status)
+	assert.Equal(t, "mailbox unavailable", email.LastError)
+	assert.Empty(t, email.HTMLBody)
+	assert.Empty(t, testMailer.Sent())
+
+	// брошенное письмо удаляется вместе с отправленными по истечении срока хранения
+	pruned, err := testSvc.Repo.PruneEmails(email.UpdatedAt)
+	assert.NoError(t, err)
+	assert.Zero(t, pruned)
+	pruned, err = testSvc.Repo.PruneEmails(email.UpdatedAt.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+}
+
+// -------------------- Magic link ------------------------------
+
+func TestMagicLink_SingleUseAndDisabledWithTwoFactor(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	user := registerUser(t, "magic@example.com")
+	assert.False(t, user.EmailVerified)
+
+	// неизвестный адрес — тот же ответ, письма нет
+	assert.NoError(t, testSvc.RequestMagicLink(context.Background(), &pb.RequestMagicLinkRequest{Email: "ghost@example.com"}))
+	assert.NoError(t, testSvc.RequestMagicLink(context.Background(), &pb.RequestMagicLinkRequest{Email: "magic@example.com"}))
+	_, err := testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, testMailer.Sent(), 2) // подтверждение email и ссылка входа
+
+	token := lastMagicLink(t)
+	resp, err := testSvc.ConsumeMagicLink(context.Background(), &pb.ConsumeMagicLinkRequest{Token: token})
+	assert.NoError(t, err)
+	claims, err := utils2.ParseToken(resp.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprint(user.ID), claims.UserID)
+	// переход по ссылке подтверждает почту
+	assert.True(t, claims.EmailVerified)
+	_, _, err = testSvc.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: resp.RefreshToken})
+	assert.NoError(t, err)
+
+	// ссылка одноразовая
+	_, err = testSvc.ConsumeMagicLink(context.Background(), &pb.ConsumeMagicLinkRequest{Token: token})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// и короткоживущая
+	assert.NoError(t, testSvc.RequestMagicLink(context.Background(), &pb.RequestMagicLinkRequest{Email: "magic@example.com"}))
+	expired := lastMagicLink(t)
+	setClock(t, now.Add(16*time.Minute))
+	_, err = testSvc.ConsumeMagicLink(context.Background(), &pb.ConsumeMagicLinkRequest{Token: expired})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// третий запрос за час ещё проходит, дальше письма молча не отправляются
+	sentBefore := len(testMailer.Sent())
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, testSvc.RequestMagicLink(context.Background(), &pb.RequestMagicLinkRequest{Email: "magic@example.com"}))
+	}
+	_, err = testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, testMailer.Sent(), sentBefore+1)
+
+	// с 2FA вход по ссылке недоступен, даже если письмо ушло раньше
+	setClock(t, now.Add(2*time.Hour))
+	assert.NoError(t, testSvc.RequestMagicLink(context.Background(), &pb.RequestMagicLinkRequest{Email: "magic@example.com"}))
+	pending := lastMagicLink(t)
+	assert.NoError(t, testDB.Model(&model.User{}).Where("id = ?", user.ID).Update("two_factor_enabled", true).Error)
+	_, err = testSvc.ConsumeMagicLink(context.Background(), &pb.ConsumeMagicLinkRequest{Token: pending})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	sentBefore = len(testMailer.Sent())
+	setClock(t, now.Add(4*time.Hour))
+	assert.NoError(t, testSvc.RequestMagicLink(context.Background(), &pb.RequestMagicLinkRequest{Email: "magic@example.com"}))
+	_, err = testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, testMailer.Sent(), sentBefore)
+}
+
+func TestMagicLink_NormalizesEmail(t *testing.T) {
+	resetTables(t)
+	setClock(t, time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	registerUser(t, "mixed@example.com")
+	_, err := testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+
+	// адрес с пробелами и в другом регистре находит того же пользователя
+	assert.NoError(t, testSvc.RequestMagicLink(context.Background(), &pb.RequestMagicLinkRequest{Email: " Mixed@Example.COM "}))
+	_, err = testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, testMailer.Sent(), 2)
+	msg, ok := testMailer.Last("mixed@example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "Вход без пароля", msg.Subject)
+}
+
+// отправляет outbox и достаёт токен из последнего письма со ссылкой входа
+func lastMagicLink(t *testing.T) string {
+	t.Helper()
+	_, err := testSvc.RunPendingMail(context.Background())
+	assert.NoError(t, err)
+	msg, ok := testMailer.Last("magic@example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "Вход без пароля", msg.Subject)
+	// фронтенд получает токен из query строки уже раскодированным
+	token, err := url.QueryUnescape(mailToken(t, msg, "https://app.example.test/magic-link?token="))
+	assert.NoError(t, err)
+	return token
+}
+
+// -------------------- Security audit log ----------------------
+
+func TestSecurityEvents_UserAndAdminQueries(t *testing.T) {
+	resetTables(t)
+
+	admin := registerUser(t, "auditadmin@example.com")
+	t.Setenv("AUTH_ADMIN_IDS", fmt.Sprint(admin.ID))
+	assert.NoError(t, testSvc.BootstrapAdmins())
+
+	ctx := context.WithValue(context.Background(), contextx.ClientIPKey, "203.0.113.7")
+	ctx = context.WithValue(ctx, contextx.UserAgentKey, "Firefox/128")
+
+	_, _, err := testSvc.Register(ctx, &pb.RegisterRequest{Email: "audit@example.com", Password: "Pass123456!", Username: "audit"})
+	assert.NoError(t, err)
+	user, err := testSvc.Repo.GetUserByEmail("audit@example.com")
+	assert.NoError(t, err)
+
+	_, err = testSvc.Login(ctx, &pb.LoginRequest{Email: "audit@example.com", Password: "WrongPass1!"})
+	assert.Error(t, err)
+	_, err = testSvc.Login(ctx, &pb.LoginRequest{Email: "Nobody@example.com", Password: "WrongPass1!"})
+	assert.Error(t, err)
+	resp, err := testSvc.Login(ctx, &pb.LoginRequest{Email: "audit@example.com", Password: "Pass123456!"})
+	assert.NoError(t, err)
+	_, _, err = testSvc.RefreshToken(ctx, &pb.RefreshRequest{RefreshToken: resp.RefreshToken})
+	assert.NoError(t, err)
+	_, _, err = testSvc.UpdatePassword(ctx, user.ID, &pb.UpdatePasswordRequest{
+		CurrentPassword: "Pass123456!", NewPassword: "NewPass123!",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, testSvc.LogoutAll(ctx, user.ID))
+
+	// свои события, новые первыми, постранично
+	events, next, err := testSvc.ListSecurityEvents(user.ID, 3, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{model.EventLogoutAll, model.EventPasswordUpdated, model.EventTokenRefreshed},
+		eventTypes(events))
+	assert.Equal(t, "203.0.113.7", events[0].IP)
+	assert.Equal(t, "Firefox/128", events[0].UserAgent)
+	assert.NotEmpty(t, next)
+
+	events, next, err = testSvc.ListSecurityEvents(user.ID, 3, next)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{model.EventLoginSucceeded, model.EventLoginFailed, model.EventRegistered},
+		eventTypes(events))
+	assert.Equal(t, "wrong_password", events[1].Details)
+	assert.Empty(t, next)
+
+	_, _, err = testSvc.ListSecurityEvents(user.ID, 3, "not-a-token")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// чужой журнал и фильтры — только администратору
+	_, _, err = testSvc.AdminListSecurityEvents(user.ID, &pb.AdminListSecurityEventsRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	events, _, err = testSvc.AdminListSecurityEvents(admin.ID, &pb.AdminListSecurityEventsRequest{
+		Type: model.EventLoginFailed,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	// вход на несуществующий адрес находится по email, аккаунта у события нет
+	assert.Nil(t, events[0].UserID)
+	assert.Equal(t, "nobody@example.com", events[0].Email)
+
+	events, _, err = testSvc.AdminListSecurityEvents(admin.ID, &pb.AdminListSecurityEventsRequest{
+		UserId: fmt.Sprint(user.ID), Ip: "203.0.113.7", Since: time.Now().Add(-time.Hour).Format(time.RFC3339),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, events, 6)
+
+	_, _, err = testSvc.AdminListSecurityEvents(admin.ID, &pb.AdminListSecurityEventsRequest{Since: "yesterday"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func eventTypes(events []model.SecurityEvent) []string {
+	types := make([]string, 0, len(events))
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+// -------------------- Account deletion -----------------------
+
+func registerUser(t *testing.T, email string) *model.User {
+	_, _, err := testSvc.Register(context.Background(), &pb.RegisterRequest{
+		Email:    email,
+		Password: "Pass123456!",
+		Username: email[:strings.Index(email, "@")],
+	})
+	assert.NoError(t, err)
+	user, err := testSvc.Repo.GetUserByEmail(email)
+	assert.NoError(t, err)
+	return user
+}
+
+func TestDeleteAccount_GracePeriodAndCancel(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	user := registerUser(t, "cancel@example.com")
+
+	_, err := testSvc.DeleteAccount(context.Background(), user.ID, "WrongPass1!")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	deletion, err := testSvc.DeleteAccount(context.Background(), user.ID, "Pass123456!")
+	assert.NoError(t, err)
+	assert.Equal(t, model.DeletionPending, deletion.Status)
+	assert.True(t, deletion.ScheduledFor.Equal(now.Add(14*24*time.Hour)))
+
+	// повторный запрос не сдвигает срок
+	setClock(t, now.Add(time.Hour))
+	again, err := testSvc.DeleteAccount(context.Background(), user.ID, "Pass123456!")
+	assert.NoError(t, err)
+	assert.True(t, again.ScheduledFor.Equal(deletion.ScheduledFor))
+
+	// до конца льготного периода ничего не удаляется
+	completed, err := testSvc.RunDueDeletions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, completed)
+
+	assert.NoError(t, testSvc.CancelAccountDeletion(user.ID))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(testSvc.CancelAccountDeletion(user.ID)))
+
+	setClock(t, now.Add(15*24*time.Hour))
+	completed, err = testSvc.RunDueDeletions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, completed)
+
+	_, err = testSvc.Repo.GetUserById(user.ID)
+	assert.NoError(t, err)
+}
+
+func TestDeleteAccount_DeletesOwnersBots(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	owner := registerUser(t, "botmaster@example.com")
+	assert.NoError(t, testSvc.Repo.MarkEmailVerified(owner.ID))
+	bot, err := testSvc.CreateBot(context.Background(), owner.ID, "master_bot")
+	assert.NoError(t, err)
+
+	cleaned := map[string]int{}
+	testSvc.DeletionSteps = []service.DeletionStep{
+		{Name: "post", Run: func(ctx context.Context, userID string) error {
+			cleaned[userID]++
+			return nil
+		}},
+	}
+
+	_, err = testSvc.DeleteAccount(context.Background(), owner.ID, "Pass123456!")
+	assert.NoError(t, err)
+	setClock(t, now.Add(14*24*time.Hour+time.Minute))
+
+	// сага владельца ставит ботов в очередь без льготного периода, следующий проход их удаляет
+	completed, err := testSvc.RunDueDeletions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, completed)
+	completed, err = testSvc.RunDueDeletions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, completed)
+
+	assert.Equal(t, map[string]int{fmt.Sprint(owner.ID): 1, fmt.Sprint(bot.ID): 1}, cleaned)
+	_, err = testSvc.Repo.GetUserById(bot.ID)
+	assert.Error(t, err)
+	bots, err := testSvc.Repo.ListBots(owner.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, bots)
+}
+
+func TestDeleteAccount_SagaResumesAfterFailure(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	user := registerUser(t, "gone@example.com")
+
+	calls := map[string]int{}
+	postDown := true
+	testSvc.DeletionSteps = []service.DeletionStep{
+		{Name: "comment", Run: func(ctx context.Context, userID string) error {
+			assert.Equal(t, fmt.Sprint(user.ID), userID)
+			calls["comment"]++
+			return nil
+		}},
+		{Name: "post", Run: func(ctx context.Context, userID string) error {
+			calls["post"]++
+			if postDown {
+				return status.Error(codes.Unavailable, "post service down")
+			}
+			return nil
+		}},
+		{Name: "user", Run: func(ctx context.Context, userID string) error {
+			calls["user"]++
+			return nil
+		}},
+	}
+
+	_, err := testSvc.DeleteAccount(context.Background(), user.ID, "Pass123456!")
+	assert.NoError(t, err)
+
+	// льготный период истёк, но post сервис недоступен
+	now = now.Add(14*24*time.Hour + time.Minute)
+	setClock(t, now)
+	completed, err := testSvc.RunDueDeletions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, completed)
+
+	deletion, err := testSvc.Repo.FindAccountDeletion(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.DeletionRunning, deletion.Status)
+	assert.Equal(t, 1, deletion.Attempts)
+	assert.Contains(t, deletion.LastError, "post")
+
+	// после запуска саги войти и отменить удаление уже нельзя
+	_, err = testSvc.Login(context.Background(), &pb.LoginRequest{Email: "gone@example.com", Password: "Pass123456!"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(testSvc.CancelAccountDeletion(user.ID)))
+
+	// до таймаута захвата запись не повторяется
+	completed, err = testSvc.RunDueDeletions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 1, calls["post"])
+
+	// сервис поднялся — сага продолжается, выполненный шаг не повторяется
+	postDown = false
+	setClock(t, now.Add(11*time.Minute))
+	completed, err = testSvc.RunDueDeletions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, map[string]int{"comment": 1, "post": 2, "user": 1}, calls)
+
+	_, err = testSvc.Repo.GetUserById(user.ID)
+	assert.Error(t, err)
+	// письма с адресом удалённого пользователя тоже стираются
+	var outbox int64
+	assert.NoError(t, testDB.Model(&model.OutboxEmail{}).Where("user_id = ?", user.ID).Count(&outbox).Error)
+	assert.Zero(t, outbox)
+
+	var tokens int64
+	testDB.Model(&model.RefreshToken{}).Where("user_id = ?", user.ID).Count(&tokens)
+	assert.Equal(t, int64(0), tokens)
+
+	deletion, err = testSvc.Repo.FindAccountDeletion(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.DeletionCompleted, deletion.Status)
+	assert.NotNil(t, deletion.CompletedAt)
+}
+
+// -------------------- Data export -----------------------------
+
+// memoryStore — хранилище архивов в памяти вместо S3
+type memoryStore struct {
+	objects map[string][]byte
+}
+
+func (m *memoryStore) UploadFile(reader io.Reader, fileName string) (string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	m.objects[fileName] = data
+	return "https://test-bucket.s3.test-region.amazonaws.com/" + fileName, nil
+}
+
+func (m *memoryStore) DownloadFile(fileName string) (io.ReadCloser, error) {
+	data, ok := m.objects[fileName]
+	if !ok {
+		return nil, errors.New("no such key")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memoryStore) PresignURL(fileName string, ttl time.Duration) (string, error) {
+	return fmt.Sprintf("https://signed.example/%s?ttl=%d", fileName, int(ttl.Seconds())), nil
+}
+
+func (m *memoryStore) DeleteFile(fileName string) error {
+	delete(m.objects, fileName)
+	return nil
+}
+
+func TestDataExport_BuildsArchive(t *testing.T) {
+	resetTables(t)
+	t.Setenv("AWS_BUCKET", "test-bucket")
+	t.Setenv("AWS_REGION", "test-region")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	const bucket = "https://test-bucket.s3.test-region.amazonaws.com/"
+	user := registerUser(t, "export@example.com")
+	own := fmt.Sprintf("avatars/%d_cat.jpg", user.ID)
+	store := &memoryStore{objects: map[string][]byte{
+		own:                        []byte("jpeg"),
+		"avatars/999999_other.jpg": []byte("foreign"),
+		"exports/999999/1-x.zip":   []byte("foreign export"),
+	}}
+	testSvc.ExportStore = store
+	testSvc.ExportSources = []service.ExportSource{
+		{Name: "posts", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			return &pb.Confirmation{Status: "post of " + userID}, []string{
+				bucket + own,
+				bucket + "avatars/" + userID + "_gone.jpg",
+			}, nil
+		}},
+		// ссылки в сообщениях задаёт клиент: чужие объекты в архив не копируются
+		{Name: "chats", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			return &pb.Confirmation{Status: "chats"}, []string{
+				bucket + "avatars/999999_other.jpg",
+				bucket + "exports/999999/1-x.zip",
+				bucket + "avatars/" + userID + "_../../exports/999999/1-x.zip",
+			}, nil
+		}},
+	}
+
+	export, err := testSvc.RequestDataExport(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ExportPending, export.Status)
+
+	// пока архив собирается, новый запрос возвращает ту же выгрузку
+	again, err := testSvc.RequestDataExport(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, export.ID, again.ID)
+
+	ready, err := testSvc.RunPendingExports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ready)
+
+	got, link, err := testSvc.GetDataExportStatus(user.ID, export.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ExportReady, got.Status)
+	assert.Contains(t, link, got.ObjectKey)
+
+	// чужая выгрузка не видна
+	_, _, err = testSvc.GetDataExportStatus(user.ID+1, export.ID)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	archive, err := zip.NewReader(bytes.NewReader(store.objects[got.ObjectKey]), int64(len(store.objects[got.ObjectKey])))
+	assert.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(r)
+		r.Close()
+		files[f.Name] = string(data)
+	}
+	assert.Contains(t, files["account.json"], "export@example.com")
+	assert.NotContains(t, files["account.json"], user.Password)
+	assert.Contains(t, files["posts.json"], fmt.Sprintf("post of %d", user.ID))
+	assert.Equal(t, "jpeg", files["media/"+own])
+	assert.Contains(t, files["manifest.json"], fmt.Sprintf("avatars/%d_gone.jpg", user.ID))
+	for name, data := range files {
+		assert.NotContains(t, data, "foreign", name)
+	}
+	assert.Contains(t, files["manifest.json"], "external_urls")
+	assert.Contains(t, files["manifest.json"], "exports/999999/1-x.zip")
+
+	// после срока хранения архив удаляется, ссылка больше не выдаётся
+	setClock(t, now.Add(49*time.Hour))
+	_, err = testSvc.RunPendingExports(context.Background())
+	assert.NoError(t, err)
+	got, link, err = testSvc.GetDataExportStatus(user.ID, export.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ExportExpired, got.Status)
+	assert.Empty(t, link)
+	assert.Len(t, store.objects, 3)
+}
+
+func TestDataExport_RetriesFailedSource(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+
+	testSvc.ExportStore = &memoryStore{objects: map[string][]byte{}}
+	testSvc.ExportSources = []service.ExportSource{
+		{Name: "chats", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			return nil, nil, status.Error(codes.Unavailable, "chat service down")
+		}},
+	}
+
+	user := registerUser(t, "retry@example.com")
+	export, err := testSvc.RequestDataExport(context.Background(), user.ID)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		ready, err := testSvc.RunPendingExports(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ready)
+		now = now.Add(11 * time.Minute)
+		setClock(t, now)
+	}
+
+	got, _, err := testSvc.GetDataExportStatus(user.ID, export.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ExportFailed, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Contains(t, got.LastError, "chats")
+
+	// после неудачи можно запросить выгрузку заново
+	next, err := testSvc.RequestDataExport(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, export.ID, next.ID)
+}
+
+// -------------------- OIDC login -----------------------------
+
+// fakeIdP — локальный OIDC провайдер: discovery, JWKS (Ed25519, kid "test") и token endpoint
+// с проверкой PKCE. authorize заменяет страницу входа: запоминает, кто вошёл, и выдаёт code.
+type fakeIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    ed25519.PrivateKey
+	kid    string
+	// audience — кому выписан ID токен; по умолчанию client_id провайдера
+	audience string
+	// nonce — подменяет nonce в ID токене
+	nonce string
+	codes map[string]fakeIdPLogin
+}
+
+type fakeIdPLogin struct {
+	challenge string
+	nonce     string
+	claims    map[string]interface{}
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	_, key, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	idp := &fakeIdP{t: t, key: key, kid: "test", codes: map[string]fakeIdPLogin{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "OKP", "crv": "Ed25519", "alg": "EdDSA", "use": "sig", "kid": "test",
+			"x": base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		login, ok := idp.codes[r.PostForm.Get("code")]
+		delete(idp.codes, r.PostForm.Get("code"))
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != login.challenge ||
+			r.PostForm.Get("client_id") != "socialnet" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idp.idToken(login)})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// provider — настройка, как из OIDC_<NAME>_*
+func (idp *fakeIdP) provider(name string, trustEmail bool) *oidc.Provider {
+	return oidc.NewProvider(oidc.Config{
+		Name:         name,
+		Issuer:       idp.server.URL,
+		ClientID:     "socialnet",
+		ClientSecret: "secret",
+		RedirectURL:  "https://app.example.test/oidc/callback/" + name,
+		TrustEmail:   trustEmail,
+	}, idp.server.Client())
+}
+
+// authorize — пользователь вошёл у провайдера, code возвращается на redirect_uri
+func (idp *fakeIdP) authorize(authURL string, claims map[string]interface{}) (state, code string) {
+	u, err := url.Parse(authURL)
+	assert.NoError(idp.t, err)
+	q := u.Query()
+	assert.Equal(idp.t, "S256", q.Get("code_challenge_method"))
+	code = fmt.Sprintf("code-%d", len(idp.codes)+1) + q.Get("state")[:8]
+	idp.codes[code] = fakeIdPLogin{challenge: q.Get("code_challenge"), nonce: q.Get("nonce"), claims: claims}
+	return q.Get("state"), code
+}
+
+func (idp *fakeIdP) idToken(login fakeIdPLogin) string {
+	now := testSvc.Clock()
+	claims := map[string]interface{}{
+		"iss":   idp.server.URL,
+		"aud":   "socialnet",
+		"iat":   now.Unix(),
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"nonce": login.nonce,
+	}
+	if idp.audience != "" {
+		claims["aud"] = idp.audience
+	}
+	if idp.nonce != "" {
+		claims["nonce"] = idp.nonce
+	}
+	for k, v := range login.claims {
+		claims[k] = v
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "EdDSA", "typ": "JWT", "kid": idp.kid})
+	payload, _ := json.Marshal(claims)
+	signing := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signing + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(idp.key, []byte(signing)))
+}
+
+// oidcLogin — полный круг: ссылка, вход у провайдера, callback
+func oidcLogin(t *testing.T, idp *fakeIdP, provider string, linkUserID uint, claims map[string]interface{}) (*service.OIDCResult, error) {
+	t.Helper()
+	authURL, err := testSvc.StartOIDC(context.Background(), provider, linkUserID)
+	assert.NoError(t, err)
+	state, code := idp.authorize(authURL, claims)
+	return testSvc.CompleteOIDC(context.Background(), provider, state, code)
+}
+
+func setOIDCProviders(t *testing.T, providers ...*oidc.Provider) {
+	testSvc.OIDCProviders = map[string]*oidc.Provider{}
+	for _, p := range providers {
+		testSvc.OIDCProviders[p.Name] = p
+	}
+	t.Cleanup(func() { testSvc.OIDCProviders = map[string]*oidc.Provider{} })
+}
+
+func TestOIDC_LoginCreatesAndLinksAccounts(t *testing.T) {
+	resetTables(t)
+	idp := newFakeIdP(t)
+	setOIDCProviders(t, idp.provider("google", true), idp.provider("partner", false))
+	assert.Equal(t, []string{"google", "partner"}, testSvc.OIDCProviderNames())
+
+	// первый вход — новый аккаунт без пароля, email подтверждён провайдером
+	jane := map[string]interface{}{"sub": "g-1", "email": "Jane@Example.com", "email_verified": true, "preferred_username": "jane.doe"}
+	result, err := oidcLogin(t, idp, "google", 0, jane)
+	assert.NoError(t, err)
+	assert.True(t, result.NewAccount)
+	claims, err := utils2.ParseToken(result.Login.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "jane_doe", claims.Username)
+	assert.True(t, claims.EmailVerified)
+	created, err := testSvc.Repo.GetUserByEmail("jane@example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, created.Password)
+
+	// повторный вход — тот же аккаунт по (provider, sub)
+	result, err = oidcLogin(t, idp, "google", 0, jane)
+	assert.NoError(t, err)
+	assert.False(t, result.NewAccount)
+	claims, err = utils2.ParseToken(result.Login.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprint(created.ID), claims.UserID)
+
+	// существующий аккаунт с неподтверждённой почтой провайдер не получает
+	local := registerUser(t, "local@example.com")
+	bob := map[string]interface{}{"sub": "g-2", "email": "local@example.com", "email_verified": true}
+	_, err = oidcLogin(t, idp, "google", 0, bob)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// после подтверждения — автоматическая привязка, но только у доверенного провайдера
+	assert.NoError(t, testSvc.Repo.MarkEmailVerified(local.ID))
+	_, err = oidcLogin(t, idp, "partner", 0, map[string]interface{}{"sub": "p-2", "email": "local@example.com", "email_verified": true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	result, err = oidcLogin(t, idp, "google", 0, bob)
+	assert.NoError(t, err)
+	assert.False(t, result.NewAccount)
+	claims, err = utils2.ParseToken(result.Login.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprint(local.ID), claims.UserID)
+
+	// явная привязка из настроек; чужой внешний аккаунт не перепривязывается
+	result, err = oidcLogin(t, idp, "partner", local.ID, map[string]interface{}{"sub": "p-2"})
+	assert.NoError(t, err)
+	assert.True(t, result.Linked)
+	assert.Nil(t, result.Login)
+	_, err = oidcLogin(t, idp, "partner", created.ID, map[string]interface{}{"sub": "p-2"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	identities, err := testSvc.ListExternalIdentities(local.ID)
+	assert.NoError(t, err)
+	assert.Len(t, identities, 2)
+
+	// последний способ входа у аккаунта без пароля не отвязать
+	err = testSvc.UnlinkExternalIdentity(context.Background(), created.ID, "google")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.NoError(t, testSvc.UnlinkExternalIdentity(context.Background(), local.ID, "google"))
+	err = testSvc.UnlinkExternalIdentity(context.Background(), local.ID, "google")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	events, err := testSvc.Repo.ListSecurityEvents(repos.SecurityEventFilter{UserID: &local.ID, Limit: 20})
+	assert.NoError(t, err)
+	assert.Contains(t, eventTypes(events), model.EventIdentityLinked)
+	assert.Contains(t, eventTypes(events), model.EventIdentityUnlinked)
+}
+
+func TestOIDC_RejectsForgedResponses(t *testing.T) {
+	resetTables(t)
+	idp := newFakeIdP(t)
+	setOIDCProviders(t, idp.provider("google", true))
+	claims := map[string]interface{}{"sub": "g-1", "email": "forged@example.com", "email_verified": true}
+
+	// state одноразовый и привязан к провайдеру
+	authURL, err := testSvc.StartOIDC(context.Background(), "google", 0)
+	assert.NoError(t, err)
+	state, code := idp.authorize(authURL, claims)
+	_, err = testSvc.CompleteOIDC(context.Background(), "google", state, code)
+	assert.NoError(t, err)
+	_, err = testSvc.CompleteOIDC(context.Background(), "google", state, code)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// code без нашего code_verifier провайдер не обменяет
+	authURL, err = testSvc.StartOIDC(context.Background(), "google", 0)
+	assert.NoError(t, err)
+	state, code = idp.authorize(authURL, claims)
+	idp.codes[code] = fakeIdPLogin{challenge: "other", claims: claims}
+	_, err = testSvc.CompleteOIDC(context.Background(), "google", state, code)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// чужой nonce, чужая аудитория, неизвестный ключ
+	idp.nonce = "replayed"
+	_, err = oidcLogin(t, idp, "google", 0, claims)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	idp.nonce, idp.audience = "", "another-client"
+	_, err = oidcLogin(t, idp, "google", 0, claims)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, idp.key, _ = ed25519.GenerateKey(nil)
+	idp.audience, idp.kid = "", "rotated"
+	_, err = oidcLogin(t, idp, "google", 0, claims)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// неизвестный провайдер
+	_, err = testSvc.StartOIDC(context.Background(), "github", 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestOIDC_StateExpiresAndTwoFactorStillRequired(t *testing.T) {
+	resetTables(t)
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, now)
+	idp := newFakeIdP(t)
+	setOIDCProviders(t, idp.provider("google", true))
+	claims := map[string]interface{}{"sub": "g-1", "email": "mfa@example.com", "email_verified": true}
+
+	result, err := oidcLogin(t, idp, "google", 0, claims)
+	assert.NoError(t, err)
+	assert.True(t, result.NewAccount)
+
+	// брошенный на странице провайдера вход истекает
+	authURL, err := testSvc.StartOIDC(context.Background(), "google", 0)
+	assert.NoError(t, err)
+	state, code := idp.authorize(authURL, claims)
+	setClock(t, now.Add(11*time.Minute))
+	_, err = testSvc.CompleteOIDC(context.Background(), "google", state, code)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// при 2FA провайдер заменяет только пароль
+	assert.NoError(t, testDB.Model(&model.User{}).Where("email = ?", "mfa@example.com").Update("two_factor_enabled", true).Error)
+	result, err = oidcLogin(t, idp, "google", 0, claims)
+	assert.NoError(t, err)
+	assert.True(t, result.Login.TwoFactorRequired)
+	assert.NotEmpty(t, result.Login.ChallengeToken)
+	assert.Empty(t, result.Login.AccessToken)
+}
@@ -3,12 +3,15 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"socialnet/pkg/contextx"
 	"socialnet/pkg/utils"
 	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/model"
 	"socialnet/services/auth/internal/service"
+	"strings"
 	"time"
 )
 
@@ -23,7 +26,7 @@ func NewAuthHandler(service *service.AuthService) *AuthHandler {
 
 func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	fmt.Println("Register")
-	access, refresh, err := h.authService.Register(req)
+	access, refresh, err := h.authService.Register(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -32,42 +35,105 @@ func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 
 func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	fmt.Println("Login")
-	access, refresh, err := h.authService.Login(req)
+	return h.authService.Login(ctx, req)
+}
+
+func (h *AuthHandler) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*httpbody.HttpBody, error) {
+	jwks, err := h.authService.JWKS()
+	if err != nil {
+		return nil, err
+	}
+	return &httpbody.HttpBody{ContentType: "application/json", Data: jwks}, nil
+}
+
+func (h *AuthHandler) VerifyTwoFactor(ctx context.Context, req *pb.VerifyTwoFactorRequest) (*pb.LoginResponse, error) {
+	access, refresh, err := h.authService.VerifyTwoFactor(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.LoginResponse{RefreshToken: refresh, AccessToken: access}, nil
 }
 
+func (h *AuthHandler) EnrollTwoFactor(ctx context.Context, req *pb.EnrollTwoFactorRequest) (*pb.EnrollTwoFactorResponse, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, uri, err := h.authService.EnrollTwoFactor(id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EnrollTwoFactorResponse{Secret: secret, OtpauthUri: uri}, nil
+}
+
+func (h *AuthHandler) ConfirmTwoFactor(ctx context.Context, req *pb.ConfirmTwoFactorRequest) (*pb.RecoveryCodes, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := h.authService.ConfirmTwoFactor(ctx, id, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RecoveryCodes{Codes: recoveryCodes}, nil
+}
+
+func (h *AuthHandler) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.DisableTwoFactor(ctx, id, req.Password, req.Code); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "two-factor disabled"}, nil
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RecoveryCodes, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := h.authService.RegenerateRecoveryCodes(ctx, id, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RecoveryCodes{Codes: recoveryCodes}, nil
+}
+
 func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
-	token, err := h.authService.RefreshToken(req)
+	access, refresh, err := h.authService.RefreshToken(ctx, req)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
-	resp := &pb.RefreshResponse{AccessToken: token}
+	resp := &pb.RefreshResponse{AccessToken: access, RefreshToken: refresh}
 	return resp, nil
 }
 
 func (h *AuthHandler) UpdatePassword(ctx context.Context, req *pb.UpdatePasswordRequest) (*pb.UpdatePasswordResponse, error) {
-	token, err := h.authService.UpdatePassword(req)
+	id, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
-	resp := &pb.UpdatePasswordResponse{AccessToken: token, Message: "successfully"}
+	access, refresh, err := h.authService.UpdatePassword(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.UpdatePasswordResponse{AccessToken: access, RefreshToken: refresh, Message: "successfully"}
 	return resp, nil
 }
 
 func (h *AuthHandler) ForgotPassword(ctx context.Context, req *pb.ForgotPasswordRequest) (*pb.ForgotPasswordResponse, error) {
-	err := h.authService.ForgotPassword(req)
+	err := h.authService.ForgotPassword(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.ForgotPasswordResponse{Status: status.New(codes.OK, "password reset successful").String()}, nil
+	return &pb.ForgotPasswordResponse{Status: status.New(codes.OK, "if the email is registered, a reset link has been sent").String()}, nil
 }
 
 func (h *AuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.Confirmation, error) {
-	err := h.authService.ResetPassword(req)
+	err := h.authService.ResetPassword(ctx, req)
 	fmt.Println("Reset")
 	if err != nil {
 		return nil, err
@@ -75,6 +141,28 @@ func (h *AuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRe
 	return &pb.Confirmation{Status: status.New(codes.OK, "password updated successful").String()}, nil
 }
 
+func (h *AuthHandler) UnlockAccount(ctx context.Context, req *pb.UnlockAccountRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.UnlockAccount(ctx, id, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "account unlocked"}, nil
+}
+
+func (h *AuthHandler) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.SetUserRole(ctx, id, req.UserId, req.Role); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "role updated"}, nil
+}
+
 func (h *AuthHandler) GetProfile(ctx context.Context, req *pb.ProfileRequest) (*pb.ProfileResponse, error) {
 	userId := contextx.GetUserID(ctx)
 	if userId == "" {
@@ -94,9 +182,484 @@ func (h *AuthHandler) GetProfile(ctx context.Context, req *pb.ProfileRequest) (*
 	}
 
 	return &pb.ProfileResponse{
-		Id:        userId,
-		Email:     user.Email,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
+		Id:               userId,
+		Email:            user.Email,
+		Username:         user.Username,
+		CreatedAt:        user.CreatedAt.Format(time.RFC3339),
+		EmailVerified:    user.EmailVerified,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+		Role:             user.Role,
+		Bot:              user.Bot,
+		ImpersonatorId:   contextx.GetActorID(ctx),
+	}, nil
+}
+
+func (h *AuthHandler) RequestMagicLink(ctx context.Context, req *pb.RequestMagicLinkRequest) (*pb.Confirmation, error) {
+	if err := h.authService.RequestMagicLink(ctx, req); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "if the email is registered, a sign-in link has been sent"}, nil
+}
+
+func (h *AuthHandler) ConsumeMagicLink(ctx context.Context, req *pb.ConsumeMagicLinkRequest) (*pb.LoginResponse, error) {
+	return h.authService.ConsumeMagicLink(ctx, req)
+}
+
+func (h *AuthHandler) ListOIDCProviders(ctx context.Context, req *pb.ListOIDCProvidersRequest) (*pb.OIDCProviders, error) {
+	return &pb.OIDCProviders{Providers: h.authService.OIDCProviderNames()}, nil
+}
+
+func (h *AuthHandler) StartOIDCLogin(ctx context.Context, req *pb.StartOIDCLoginRequest) (*pb.OIDCAuthorization, error) {
+	authURL, err := h.authService.StartOIDC(ctx, req.Provider, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OIDCAuthorization{AuthorizationUrl: authURL}, nil
+}
+
+func (h *AuthHandler) CompleteOIDCLogin(ctx context.Context, req *pb.CompleteOIDCLoginRequest) (*pb.OIDCLoginResponse, error) {
+	result, err := h.authService.CompleteOIDC(ctx, req.Provider, req.State, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.OIDCLoginResponse{Linked: result.Linked, NewAccount: result.NewAccount}
+	if result.Login != nil {
+		resp.AccessToken = result.Login.AccessToken
+		resp.RefreshToken = result.Login.RefreshToken
+		resp.TwoFactorRequired = result.Login.TwoFactorRequired
+		resp.ChallengeToken = result.Login.ChallengeToken
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) LinkOIDCProvider(ctx context.Context, req *pb.LinkOIDCProviderRequest) (*pb.OIDCAuthorization, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authURL, err := h.authService.StartOIDC(ctx, req.Provider, id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OIDCAuthorization{AuthorizationUrl: authURL}, nil
+}
+
+func (h *AuthHandler) ListLinkedAccounts(ctx context.Context, req *pb.ListLinkedAccountsRequest) (*pb.LinkedAccounts, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := h.authService.ListExternalIdentities(id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.LinkedAccounts{Accounts: make([]*pb.LinkedAccount, 0, len(identities))}
+	for _, identity := range identities {
+		resp.Accounts = append(resp.Accounts, &pb.LinkedAccount{
+			Provider:    identity.Provider,
+			Email:       identity.Email,
+			LinkedAt:    identity.CreatedAt.Format(time.RFC3339),
+			LastLoginAt: identity.LastLoginAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) UnlinkOIDCProvider(ctx context.Context, req *pb.UnlinkOIDCProviderRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.UnlinkExternalIdentity(ctx, id, req.Provider); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "provider unlinked"}, nil
+}
+
+func (h *AuthHandler) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.Confirmation, error) {
+	if err := h.authService.VerifyEmail(req); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "email verified"}, nil
+}
+
+func (h *AuthHandler) ResendVerification(ctx context.Context, req *pb.ResendVerificationRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.ResendVerification(id); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "verification email sent"}, nil
+}
+
+func (h *AuthHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.Sessions, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := h.authService.ListSessions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.Sessions{Sessions: make([]*pb.Session, 0, len(sessions))}
+	for _, t := range sessions {
+		resp.Sessions = append(resp.Sessions, &pb.Session{
+			Id:         t.FamilyID,
+			UserAgent:  t.UserAgent,
+			Ip:         t.IP,
+			CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+			LastUsedAt: t.LastUsedAt.Format(time.RFC3339),
+			ExpiresAt:  t.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.RevokeSession(ctx, id, req.SessionId); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "session revoked"}, nil
+}
+
+func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.Logout(ctx, id, req.RefreshToken); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "logged out"}, nil
+}
+
+func (h *AuthHandler) LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.LogoutAll(ctx, id); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "logged out from all devices"}, nil
+}
+
+func (h *AuthHandler) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.AccountDeletion, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deletion, err := h.authService.DeleteAccount(ctx, id, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AccountDeletion{
+		Status:       deletion.Status,
+		ScheduledFor: deletion.ScheduledFor.Format(time.RFC3339),
+	}, nil
+}
+
+func (h *AuthHandler) CancelAccountDeletion(ctx context.Context, req *pb.CancelAccountDeletionRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.CancelAccountDeletion(id); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "account deletion cancelled"}, nil
+}
+
+func (h *AuthHandler) RequestDataExport(ctx context.Context, req *pb.RequestDataExportRequest) (*pb.DataExport, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	export, err := h.authService.RequestDataExport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dataExportToPB(export, ""), nil
+}
+
+func (h *AuthHandler) GetDataExportStatus(ctx context.Context, req *pb.GetDataExportStatusRequest) (*pb.DataExport, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exportID, err := utils.StringToUint(req.ExportId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid export id")
+	}
+	export, link, err := h.authService.GetDataExportStatus(id, exportID)
+	if err != nil {
+		return nil, err
+	}
+	return dataExportToPB(export, link), nil
+}
+
+func dataExportToPB(export *model.DataExport, link string) *pb.DataExport {
+	resp := &pb.DataExport{
+		Id:          fmt.Sprint(export.ID),
+		Status:      export.Status,
+		CreatedAt:   export.CreatedAt.Format(time.RFC3339),
+		DownloadUrl: link,
+		SizeBytes:   export.SizeBytes,
+	}
+	if export.CompletedAt != nil {
+		resp.CompletedAt = export.CompletedAt.Format(time.RFC3339)
+	}
+	if export.ExpiresAt != nil {
+		resp.ExpiresAt = export.ExpiresAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func (h *AuthHandler) RequestEmailChange(ctx context.Context, req *pb.RequestEmailChangeRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.RequestEmailChange(ctx, id, req.NewEmail, req.Password); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "confirmation sent to the new email"}, nil
+}
+
+func (h *AuthHandler) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeRequest) (*pb.Confirmation, error) {
+	if err := h.authService.ConfirmEmailChange(ctx, req.Token); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "email changed"}, nil
+}
+
+func (h *AuthHandler) ChangeUsername(ctx context.Context, req *pb.ChangeUsernameRequest) (*pb.ChangeUsernameResponse, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, access, err := h.authService.ChangeUsername(ctx, id, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ChangeUsernameResponse{Username: user.Username, AccessToken: access}, nil
+}
+
+func (h *AuthHandler) CreatePersonalAccessToken(ctx context.Context, req *pb.CreatePersonalAccessTokenRequest) (*pb.CreatedPersonalAccessToken, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	raw, token, err := h.authService.CreatePersonalAccessToken(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreatedPersonalAccessToken{Token: raw, Info: personalTokenToPB(token)}, nil
+}
+
+func (h *AuthHandler) ListPersonalAccessTokens(ctx context.Context, req *pb.ListPersonalAccessTokensRequest) (*pb.PersonalAccessTokens, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := h.authService.ListPersonalAccessTokens(id, req.BotId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.PersonalAccessTokens{Tokens: make([]*pb.PersonalAccessToken, 0, len(tokens))}
+	for i := range tokens {
+		resp.Tokens = append(resp.Tokens, personalTokenToPB(&tokens[i]))
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) RevokePersonalAccessToken(ctx context.Context, req *pb.RevokePersonalAccessTokenRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.RevokePersonalAccessToken(id, req.Id); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "token revoked"}, nil
+}
+
+func (h *AuthHandler) CreateBot(ctx context.Context, req *pb.CreateBotRequest) (*pb.Bot, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bot, err := h.authService.CreateBot(ctx, id, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	return botToPB(bot), nil
+}
+
+func (h *AuthHandler) ListBots(ctx context.Context, req *pb.ListBotsRequest) (*pb.Bots, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bots, err := h.authService.ListBots(id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.Bots{Bots: make([]*pb.Bot, 0, len(bots))}
+	for i := range bots {
+		resp.Bots = append(resp.Bots, botToPB(&bots[i]))
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) ValidatePersonalAccessToken(ctx context.Context, req *pb.ValidatePersonalAccessTokenRequest) (*pb.TokenIdentity, error) {
+	user, scopes, err := h.authService.ValidatePersonalAccessToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TokenIdentity{
+		UserId:        fmt.Sprint(user.ID),
+		Username:      user.Username,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		Scopes:        scopes,
+		Bot:           user.Bot,
 	}, nil
 }
+
+func (h *AuthHandler) Impersonate(ctx context.Context, req *pb.ImpersonateRequest) (*pb.ImpersonationToken, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, imp, err := h.authService.Impersonate(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ImpersonationToken{
+		AccessToken: token,
+		SessionId:   imp.ID,
+		ExpiresAt:   imp.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (h *AuthHandler) EndImpersonation(ctx context.Context, req *pb.EndImpersonationRequest) (*pb.Confirmation, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authService.EndImpersonation(ctx, id, req.SessionId); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "impersonation ended"}, nil
+}
+
+func (h *AuthHandler) RecordImpersonatedRequest(ctx context.Context, req *pb.RecordImpersonatedRequestRequest) (*pb.Confirmation, error) {
+	if err := h.authService.RecordImpersonatedRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return &pb.Confirmation{Status: "recorded"}, nil
+}
+
+func (h *AuthHandler) IntrospectToken(ctx context.Context, req *pb.IntrospectTokenRequest) (*pb.TokenIntrospection, error) {
+	return h.authService.IntrospectToken(req.Token)
+}
+
+func (h *AuthHandler) ListSecurityEvents(ctx context.Context, req *pb.ListSecurityEventsRequest) (*pb.SecurityEvents, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, next, err := h.authService.ListSecurityEvents(id, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SecurityEvents{Events: make([]*pb.SecurityEvent, 0, len(events)), NextPageToken: next}
+	for i := range events {
+		event := securityEventToPB(&events[i])
+		// email нужен только администратору, свой адрес пользователь и так знает
+		event.Email = ""
+		resp.Events = append(resp.Events, event)
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) AdminListSecurityEvents(ctx context.Context, req *pb.AdminListSecurityEventsRequest) (*pb.SecurityEvents, error) {
+	id, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, next, err := h.authService.AdminListSecurityEvents(id, req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SecurityEvents{Events: make([]*pb.SecurityEvent, 0, len(events)), NextPageToken: next}
+	for i := range events {
+		resp.Events = append(resp.Events, securityEventToPB(&events[i]))
+	}
+	return resp, nil
+}
+
+func securityEventToPB(event *model.SecurityEvent) *pb.SecurityEvent {
+	resp := &pb.SecurityEvent{
+		Id:        fmt.Sprint(event.ID),
+		Type:      event.Type,
+		Ip:        event.IP,
+		UserAgent: event.UserAgent,
+		Details:   event.Details,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+		Email:     event.Email,
+	}
+	if event.UserID != nil {
+		resp.UserId = fmt.Sprint(*event.UserID)
+	}
+	if event.ActorID != nil {
+		resp.ActorId = fmt.Sprint(*event.ActorID)
+	}
+	return resp
+}
+
+func personalTokenToPB(token *model.PersonalAccessToken) *pb.PersonalAccessToken {
+	resp := &pb.PersonalAccessToken{
+		Id:        fmt.Sprint(token.ID),
+		Name:      token.Name,
+		Prefix:    token.Prefix,
+		Scopes:    strings.Fields(token.Scopes),
+		CreatedAt: token.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: token.ExpiresAt.Format(time.RFC3339),
+		AccountId: fmt.Sprint(token.UserID),
+	}
+	if token.LastUsedAt != nil {
+		resp.LastUsedAt = token.LastUsedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func botToPB(bot *model.User) *pb.Bot {
+	return &pb.Bot{
+		Id:        fmt.Sprint(bot.ID),
+		Username:  bot.Username,
+		CreatedAt: bot.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// currentUserID — user_id из metadata, проставленной gateway
+func currentUserID(ctx context.Context) (uint, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return 0, status.Error(codes.Unauthenticated, "missing user_id in context")
+	}
+	id, err := utils.StringToUint(userId)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	return id, nil
+}
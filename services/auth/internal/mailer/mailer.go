@@ -0,0 +1,131 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message — готовое письмо: текстовая и HTML версии одного шаблона
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer — доставка писем. Сервис не отправляет письма напрямую:
+// они кладутся в outbox и отправляются фоновым обработчиком с повторами.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// FromEnv — MAIL_DRIVER: smtp, file (MAIL_DIR) или log. Драйвер обязателен: письма только
+// в лог должны быть явным выбором, а не следствием забытой настройки.
+func FromEnv() (Mailer, error) {
+	driver := strings.ToLower(os.Getenv("MAIL_DRIVER"))
+	if driver == "" {
+		return nil, fmt.Errorf("MAIL_DRIVER is required: smtp, file or log")
+	}
+
+	switch driver {
+	case "smtp":
+		return NewSMTPMailerFromEnv()
+	case "file":
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			return nil, fmt.Errorf("MAIL_DIR is required for the file mail driver")
+		}
+		return NewFileMailer(dir), nil
+	case "log":
+		return NewFileMailer(""), nil
+	}
+	return nil, fmt.Errorf("unknown MAIL_DRIVER %q", driver)
+}
+
+// ---------------- File / log ----------------
+
+// FileMailer — для разработки: каждое письмо в отдельный .eml файл в Dir,
+// без Dir — только строка в логе
+type FileMailer struct {
+	Dir string
+
+	mu sync.Mutex
+	n  int
+}
+
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{Dir: dir}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if m.Dir == "" {
+		log.Printf("✉️ [mail] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Text)
+		return nil
+	}
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.n++
+	name := fmt.Sprintf("%s-%03d.eml", time.Now().UTC().Format("20060102T150405"), m.n)
+	m.mu.Unlock()
+
+	f, err := os.Create(filepath.Join(m.Dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = newGomailMessage("dev@localhost", msg).WriteTo(f)
+	return err
+}
+
+// ---------------- Fake ----------------
+
+// FakeMailer — для тестов: письма остаются в памяти, Err имитирует сбой доставки
+type FakeMailer struct {
+	mu   sync.Mutex
+	sent []Message
+	Err  error
+}
+
+func (m *FakeMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func (m *FakeMailer) SetErr(err error) {
+	m.mu.Lock()
+	m.Err = err
+	m.mu.Unlock()
+}
+
+// Sent — копия отправленных писем
+func (m *FakeMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.sent...)
+}
+
+// Last — последнее письмо на адрес
+func (m *FakeMailer) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.sent) - 1; i >= 0; i-- {
+		if m.sent[i].To == to {
+			return m.sent[i], true
+		}
+	}
+	return Message{}, false
+}
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"gopkg.in/gomail.v2"
+	"os"
+	"strconv"
+)
+
+type SMTPMailer struct {
+	from   string
+	dialer *gomail.Dialer
+}
+
+// NewSMTPMailerFromEnv — SMTP_HOST, SMTP_PORT (587), SMTP_USER, SMTP_PASS, SMTP_FROM (по умолчанию SMTP_USER)
+func NewSMTPMailerFromEnv() (*SMTPMailer, error) {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil, fmt.Errorf("SMTP_HOST is not set")
+	}
+	port := 587
+	if raw := os.Getenv("SMTP_PORT"); raw != "" {
+		p, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT: %v", err)
+		}
+		port = p
+	}
+	user := os.Getenv("SMTP_USER")
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = user
+	}
+	return &SMTPMailer{
+		from:   from,
+		dialer: gomail.NewDialer(host, port, user, os.Getenv("SMTP_PASS")),
+	}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	return m.dialer.DialAndSend(newGomailMessage(m.from, msg))
+}
+
+// newGomailMessage — multipart/alternative: текст для простых клиентов, HTML для остальных
+func newGomailMessage(from string, msg Message) *gomail.Message {
+	m := gomail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", msg.To)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetBody("text/plain", msg.Text)
+	if msg.HTML != "" {
+		m.AddAlternative("text/html", msg.HTML)
+	}
+	return m
+}
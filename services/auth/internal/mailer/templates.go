@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+// Шаблоны писем: templates/<locale>/<name>.tmpl с блоками subject, text и html
+const (
+	TemplatePasswordReset      = "password_reset"
+	TemplateVerifyEmail        = "verify_email"
+	TemplateEmailChangeConfirm = "email_change_confirm"
+	TemplateEmailChangeNotice  = "email_change_notice"
+	TemplateExportReady        = "export_ready"
+	TemplateMagicLink          = "magic_link"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+type Templates struct {
+	defaultLocale string
+	text          map[string]*texttemplate.Template // ключ locale/name
+	html          map[string]*htmltemplate.Template
+}
+
+// DefaultTemplates — встроенные шаблоны, язык по умолчанию из MAIL_DEFAULT_LOCALE (ru)
+var DefaultTemplates = sync.OnceValue(func() *Templates {
+	locale := os.Getenv("MAIL_DEFAULT_LOCALE")
+	if locale == "" {
+		locale = "ru"
+	}
+	t, err := LoadTemplates(templateFS, locale)
+	if err != nil {
+		panic(fmt.Sprintf("mail templates: %v", err))
+	}
+	return t
+})
+
+func LoadTemplates(fsys fs.FS, defaultLocale string) (*Templates, error) {
+	t := &Templates{
+		defaultLocale: defaultLocale,
+		text:          map[string]*texttemplate.Template{},
+		html:          map[string]*htmltemplate.Template{},
+	}
+	files, err := fs.Glob(fsys, "templates/*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		locale := path.Base(path.Dir(file))
+		key := locale + "/" + strings.TrimSuffix(path.Base(file), ".tmpl")
+
+		// subject и text без HTML экранирования, html — с ним
+		text, err := texttemplate.ParseFS(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		html, err := htmltemplate.ParseFS(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		t.text[key], t.html[key] = text, html
+	}
+	if !t.HasLocale(defaultLocale) {
+		return nil, fmt.Errorf("no templates for default locale %q", defaultLocale)
+	}
+	return t, nil
+}
+
+func (t *Templates) HasLocale(locale string) bool {
+	if locale == "" {
+		return false
+	}
+	for key := range t.text {
+		if strings.HasPrefix(key, locale+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Render — письмо на языке locale, при отсутствии перевода — на языке по умолчанию
+func (t *Templates) Render(locale, name string, data any) (Message, error) {
+	key := locale + "/" + name
+	if _, ok := t.text[key]; !ok {
+		key = t.defaultLocale + "/" + name
+	}
+	text, ok := t.text[key]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown mail template %q", name)
+	}
+
+	var subject, body, html bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := text.ExecuteTemplate(&body, "text", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.html[key].ExecuteTemplate(&html, "html", data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
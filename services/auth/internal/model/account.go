@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// EmailChange — ожидающая подтверждения смена email, у пользователя одна (новый запрос заменяет старый)
+type EmailChange struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"uniqueIndex;not null"`
+	NewEmail  string    `gorm:"size:100;not null"`
+	Token     string    `gorm:"uniqueIndex;not null"` // SHA-256 хэш
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// UsernameHold — прежний username после смены какое-то время закреплён за владельцем,
+// чтобы его не занял кто-то другой и не выдавал себя за него
+type UsernameHold struct {
+	ID        uint      `gorm:"primaryKey"`
+	Username  string    `gorm:"uniqueIndex;size:50;not null"` // в нижнем регистре
+	UserID    uint      `gorm:"index;not null"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Типы событий журнала безопасности
+const (
+	EventRegistered           = "registered"
+	EventLoginSucceeded       = "login_succeeded"
+	EventLoginFailed          = "login_failed"
+	EventMagicLinkRequested   = "magic_link_requested"
+	EventTokenRefreshed       = "token_refreshed"
+	EventRefreshTokenReused   = "refresh_token_reused"
+	EventPasswordUpdated      = "password_updated"
+	EventPasswordResetRequest = "password_reset_requested"
+	EventPasswordReset        = "password_reset"
+	EventSessionRevoked       = "session_revoked"
+	EventLogout               = "logout"
+	EventLogoutAll            = "logout_all"
+	EventTwoFactorEnabled     = "two_factor_enabled"
+	EventTwoFactorDisabled    = "two_factor_disabled"
+	EventTwoFactorFailed      = "two_factor_failed"
+	EventRecoveryCodesRenewed = "recovery_codes_regenerated"
+	EventEmailChangeRequested = "email_change_requested"
+	EventEmailChanged         = "email_changed"
+	EventAccountUnlocked      = "account_unlocked"
+	EventRoleChanged          = "role_changed"
+	EventIdentityLinked       = "identity_linked"
+	EventIdentityUnlinked     = "identity_unlinked"
+	EventImpersonationStarted = "impersonation_started"
+	EventImpersonationEnded   = "impersonation_ended"
+	EventImpersonatedRequest  = "impersonated_request"
+)
+
+// SecurityEvent — запись журнала безопасности, только добавляется.
+// При удалении аккаунта записи остаются: журнал нужен для разбора инцидентов.
+// UserID пустой у неудачного входа на неизвестный email, тогда событие находится по Email.
+type SecurityEvent struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    *uint     `gorm:"index"`
+	Type      string    `gorm:"size:32;index;not null"`
+	Email     string    `gorm:"size:255;index"`
+	IP        string    `gorm:"size:64;index"`
+	UserAgent string    `gorm:"size:255"`
+	Details   string    `gorm:"size:255"`
+	ActorID   *uint     // администратор, если действие выполнено не самим пользователем
+	CreatedAt time.Time `gorm:"index;not null"`
+}
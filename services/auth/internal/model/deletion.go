@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Статусы удаления аккаунта
+const (
+	DeletionPending   = "pending"   // идёт льготный период, можно отменить
+	DeletionRunning   = "running"   // сага запущена, отменить уже нельзя
+	DeletionCompleted = "completed" // данные удалены во всех сервисах
+	DeletionCancelled = "cancelled"
+)
+
+// Запрос на удаление аккаунта и состояние саги очистки
+type AccountDeletion struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"uniqueIndex;not null"`
+	Status       string    `gorm:"size:16;index;not null"`
+	ScheduledFor time.Time `gorm:"index;not null"` // конец льготного периода
+	Attempts     int       `gorm:"not null;default:0"`
+	LastError    string
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Выполненный шаг саги: после рестарта уже пройденные шаги не повторяются
+type AccountDeletionStep struct {
+	ID          uint   `gorm:"primaryKey"`
+	DeletionID  uint   `gorm:"uniqueIndex:idx_deletion_step;not null"`
+	Name        string `gorm:"uniqueIndex:idx_deletion_step;size:32;not null"`
+	CompletedAt time.Time
+}
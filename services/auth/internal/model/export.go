@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Статусы выгрузки персональных данных
+const (
+	ExportPending = "pending"
+	ExportRunning = "running"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+	ExportExpired = "expired" // архив удалён из хранилища, нужна новая выгрузка
+)
+
+// Архив "скачать мои данные": собирается фоном, хранится в S3 до ExpiresAt
+type DataExport struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"index;not null"`
+	Status      string `gorm:"size:16;index;not null"`
+	ObjectKey   string `gorm:"size:255"`
+	SizeBytes   int64
+	Attempts    int `gorm:"not null;default:0"`
+	LastError   string
+	ExpiresAt   *time.Time `gorm:"index"`
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
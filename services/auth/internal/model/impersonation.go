@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Impersonation — администратор работает под аккаунтом пользователя (поддержка воспроизводит баг).
+// ID попадает в sid токена: завершённая или истёкшая запись отзывает токен.
+type Impersonation struct {
+	ID        string `gorm:"primaryKey;size:36"`
+	AdminID   uint   `gorm:"index;not null"`
+	UserID    uint   `gorm:"index;not null"`
+	Reason    string `gorm:"size:255;not null"`
+	ExpiresAt time.Time
+	EndedAt   *time.Time
+	CreatedAt time.Time
+}
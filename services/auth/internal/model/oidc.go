@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// OIDCState — незавершённый вход через внешнего провайдера: state из ссылки (хэш),
+// PKCE code_verifier и nonce для проверки ID токена. UserID задан при привязке к своему аккаунту.
+type OIDCState struct {
+	ID           uint   `gorm:"primaryKey"`
+	State        string `gorm:"uniqueIndex"`
+	Provider     string `gorm:"size:32;not null"`
+	CodeVerifier string `gorm:"not null"`
+	Nonce        string `gorm:"not null"`
+	UserID       *uint
+	ExpiresAt    time.Time `gorm:"index"`
+	CreatedAt    time.Time
+}
+
+// ExternalIdentity — аккаунт у провайдера (iss + sub), привязанный к пользователю.
+// У пользователя не больше одной привязки на провайдера.
+type ExternalIdentity struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_identity_user_provider"`
+	Provider    string `gorm:"size:32;not null;uniqueIndex:idx_identity_subject;uniqueIndex:idx_identity_user_provider"`
+	Subject     string `gorm:"size:255;not null;uniqueIndex:idx_identity_subject"`
+	Email       string `gorm:"size:100"`
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Статусы письма в outbox
+const (
+	MailPending = "pending" // ждёт отправки или повтора после NextAttemptAt
+	MailSending = "sending"
+	MailSent    = "sent"
+	MailFailed  = "failed" // попытки исчерпаны
+)
+
+// OutboxEmail — письмо ставится в очередь вместе с изменением данных и отправляется фоном.
+// После отправки тело письма стирается: в нём ссылки со сбросом пароля и т.п.
+type OutboxEmail struct {
+	ID            uint   `gorm:"primaryKey"`
+	UserID        uint   `gorm:"index"`
+	Template      string `gorm:"size:32;not null"`
+	To            string `gorm:"size:100;not null"`
+	Subject       string `gorm:"size:255;not null"`
+	TextBody      string
+	HTMLBody      string
+	Status        string    `gorm:"size:16;index;not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"index;not null"`
+	LastError     string
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
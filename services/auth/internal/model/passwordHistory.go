@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// PasswordHistory — хэши прежних паролей, чтобы запретить их повторное использование.
+// Текущий пароль хранится в User.Password, сюда попадает при смене.
+type PasswordHistory struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index;not null"`
+	Hash      string `gorm:"not null"`
+	CreatedAt time.Time
+}
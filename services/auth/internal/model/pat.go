@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Personal access токен для скриптов и ботов. Сам токен показывается один раз при создании,
+// в БД — только SHA-256 хэш и короткий префикс, по которому токен можно узнать в списке.
+type PersonalAccessToken struct {
+	ID         uint      `gorm:"primaryKey"`
+	UserID     uint      `gorm:"index;not null"` // владелец токена: пользователь или бот
+	Name       string    `gorm:"size:64;not null"`
+	TokenHash  string    `gorm:"uniqueIndex;not null"`
+	Prefix     string    `gorm:"size:16;not null"`
+	Scopes     string    `gorm:"not null"` // через пробел, как scope в OAuth
+	ExpiresAt  time.Time `gorm:"not null"`
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
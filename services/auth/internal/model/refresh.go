@@ -2,11 +2,18 @@ package model
 
 import "time"
 
-// Refresh токен
+// Refresh токен. Одна сессия (устройство) = одно семейство токенов:
+// при каждом обновлении старый токен отзывается и выдаётся новый с тем же FamilyID.
 type RefreshToken struct {
-	ID        uint      `gorm:"primaryKey"`
-	UserID    uint      `gorm:"index;not null"`
-	Token     string    `gorm:"not null"`
-	ExpiresAt time.Time `gorm:"not null"`
-	CreatedAt time.Time
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"index;not null"`
+	FamilyID     string    `gorm:"index;size:36;not null"`
+	Token        string    `gorm:"uniqueIndex;not null"` // SHA-256 хэш, сам токен не храним
+	UserAgent    string    `gorm:"size:255"`
+	IP           string    `gorm:"size:64"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	LastUsedAt   time.Time
+	RevokedAt    *time.Time
+	ReplacedByID *uint
+	CreatedAt    time.Time
 }
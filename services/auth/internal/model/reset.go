@@ -9,3 +9,13 @@ type PasswordReset struct {
 	ExpiresAt time.Time
 	CreatedAt time.Time
 }
+
+// MagicLink — одноразовая ссылка входа без пароля, устроена как PasswordReset,
+// но хранится SHA-256 хэш токена: по ссылке сразу выдаётся сессия
+type MagicLink struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index"`
+	Token     string `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Одноразовый код восстановления 2FA, храним только SHA-256 хэш
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index;not null"`
+	CodeHash  string `gorm:"size:64;not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// Промежуточный шаг входа: пароль уже проверен, ждём код второго фактора
+type TwoFactorChallenge struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"index;not null"`
+	Token     string    `gorm:"uniqueIndex;not null"` // SHA-256 хэш
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
@@ -3,9 +3,21 @@ package model
 import "time"
 
 type User struct {
-	ID        uint   `gorm:"primaryKey"`
-	Email     string `gorm:"uniqueIndex;size:100;not null"`
-	Username  string `gorm:"uniqueIndex;size:50;not null"`
-	Password  string `gorm:"not null"`
-	CreatedAt time.Time
+	ID                uint   `gorm:"primaryKey"`
+	Email             string `gorm:"uniqueIndex;size:100;not null"`
+	Username          string `gorm:"uniqueIndex;size:50;not null"`
+	Password          string `gorm:"not null"`
+	EmailVerified     bool   `gorm:"not null;default:false"`
+	Role              string `gorm:"size:16;not null;default:user"` // user, moderator, admin
+	TwoFactorEnabled  bool   `gorm:"not null;default:false"`
+	TwoFactorSecret   string `gorm:"size:64"`
+	TwoFactorLastStep int64  `gorm:"not null;default:0"`     // последний принятый TOTP интервал, защита от повтора кода
+	Bot               bool   `gorm:"not null;default:false"` // вход только по personal access токенам
+	OwnerID           *uint  `gorm:"index"`                  // создатель бота
+	UsernameChangedAt *time.Time
+	HandleSynced      bool       `gorm:"not null;default:false;index"` // username дошёл до профиля в user сервисе
+	HandleRejected    bool       `gorm:"not null;default:false"`       // user сервис отверг username, повтор только после смены имени
+	TokensValidAfter  *time.Time // access токены, выпущенные раньше, отозваны (выход везде, смена пароля)
+	Locale            string     `gorm:"size:8"` // язык писем, пусто — MAIL_DEFAULT_LOCALE
+	CreatedAt         time.Time
 }
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// EmailVerification — токен подтверждения email, устроен как PasswordReset
+type EmailVerification struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index"`
+	Token     string `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,289 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"socialnet/pkg/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+// не чаще раза в interval перезапрашиваем JWKS из-за токена с незнакомым kid
+const jwksRefreshInterval = 30 * time.Second
+
+// Config — провайдер OpenID Connect (Google, GitLab, свой у партнёра и т.п.).
+// Нужен именно OIDC с ID токеном: обычный OAuth2 без него (например GitHub) не подходит.
+type Config struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// TrustEmail — подтверждённый провайдером email можно привязать к существующему аккаунту.
+	// Включать только для провайдеров, которые действительно проверяют адреса.
+	TrustEmail bool
+}
+
+// Provider — discovery документ и ключи провайдера загружаются при первом обращении и кэшируются
+type Provider struct {
+	Config
+	client *http.Client
+
+	mu          sync.Mutex
+	meta        *metadata
+	keys        *utils.KeySet
+	keysFetched time.Time
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func NewProvider(cfg Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &Provider{Config: cfg, client: client}
+}
+
+// ProvidersFromEnv — OIDC_PROVIDERS=google,partner и для каждого OIDC_<NAME>_ISSUER, _CLIENT_ID,
+// _CLIENT_SECRET, необязательные _REDIRECT_URL (по умолчанию <appBaseURL>/oidc/callback/<name>),
+// _SCOPES (через пробел) и _TRUST_EMAIL=true
+func ProvidersFromEnv(appBaseURL string) (map[string]*Provider, error) {
+	providers := map[string]*Provider{}
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		cfg := Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+			TrustEmail:   os.Getenv(prefix+"TRUST_EMAIL") == "true",
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			return nil, fmt.Errorf("oidc provider %s: %sISSUER and %sCLIENT_ID are required", name, prefix, prefix)
+		}
+		if cfg.RedirectURL == "" {
+			cfg.RedirectURL = strings.TrimRight(appBaseURL, "/") + "/oidc/callback/" + name
+		}
+		providers[name] = NewProvider(cfg, nil)
+	}
+	return providers, nil
+}
+
+// AuthCodeURL — ссылка на страницу входа провайдера (authorization code + PKCE S256)
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.ClientID},
+		"redirect_uri":          {p.RedirectURL},
+		"scope":                 {strings.Join(p.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange — обмен authorization code на токены, возвращает ID токен (ещё не проверенный)
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"client_id":     {p.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	if p.ClientSecret != "" {
+		form.Set("client_secret", p.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var resp struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &resp)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK || resp.Error != "" {
+		return "", fmt.Errorf("token endpoint: %d %s %s", status, resp.Error, resp.ErrorDescription)
+	}
+	if resp.IDToken == "" {
+		return "", errors.New("token endpoint returned no id_token")
+	}
+	return resp.IDToken, nil
+}
+
+// VerifyIDToken — подпись, iss, aud, срок действия и nonce
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string, now time.Time) (*utils.IDTokenClaims, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := p.keySet(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := keys.ParseIDToken(raw, meta.Issuer, p.ClientID, now)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+	return claims, nil
+}
+
+func (p *Provider) metadata(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	wellKnown := strings.TrimRight(p.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, err
+	}
+	var meta metadata
+	status, err := p.doJSON(req, &meta)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("discovery: status %d", status)
+	}
+	// подменённый discovery документ не должен выдать себя за другого провайдера
+	if strings.TrimRight(meta.Issuer, "/") != strings.TrimRight(p.Issuer, "/") {
+		return nil, fmt.Errorf("discovery: issuer %q does not match %q", meta.Issuer, p.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("discovery: incomplete provider metadata")
+	}
+	p.meta = &meta
+	return p.meta, nil
+}
+
+// keySet — ключи провайдера; незнакомый kid означает ротацию, тогда перечитываем JWKS
+func (p *Provider) keySet(ctx context.Context, raw string) (*utils.KeySet, error) {
+	kid := tokenKID(raw)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys != nil && (p.keys.HasKey(kid) || time.Since(p.keysFetched) < jwksRefreshInterval) {
+		return p.keys, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.meta.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	keys, err := utils.ParseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	p.keys, p.keysFetched = keys, time.Now()
+	return p.keys, nil
+}
+
+func (p *Provider) doJSON(req *http.Request, out interface{}) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("invalid response from %s: %v", req.URL.Host, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// tokenKID — kid из заголовка JWT без проверки подписи
+func tokenKID(raw string) string {
+	header, _, _ := strings.Cut(raw, ".")
+	data, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return ""
+	}
+	var h struct {
+		Kid string `json:"kid"`
+	}
+	_ = json.Unmarshal(data, &h)
+	return h.Kid
+}
+
+// NewPKCE — code_verifier и code_challenge (S256), RFC 7636
+func NewPKCE() (string, string, error) {
+	verifier, err := RandomString()
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// RandomString — 256 бит в base64url, для state, nonce и code_verifier
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
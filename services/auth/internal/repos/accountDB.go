@@ -0,0 +1,113 @@
+package repos
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"socialnet/services/auth/internal/model"
+	"strings"
+	"time"
+)
+
+// ErrUsernameTaken — имя заняли между проверкой и сменой
+var ErrUsernameTaken = errors.New("username taken")
+
+func (r *UserRepo) EmailTaken(email string) (bool, error) {
+	var n int64
+	err := r.Db.Model(&model.User{}).Where("LOWER(email) = LOWER(?)", email).Count(&n).Error
+	return n > 0, err
+}
+
+// SaveEmailChange — заменяет предыдущий запрос пользователя
+func (r *UserRepo) SaveEmailChange(change *model.EmailChange) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", change.UserID).Delete(&model.EmailChange{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+func (r *UserRepo) FindEmailChange(tokenHash string) (*model.EmailChange, error) {
+	var change model.EmailChange
+	if err := r.Db.Where("token = ?", tokenHash).First(&change).Error; err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ApplyEmailChange — новый адрес сразу подтверждён (ссылка пришла на него).
+// Ссылки сброса пароля и подтверждения, отправленные на старый адрес, больше не действуют.
+func (r *UserRepo) ApplyEmailChange(change *model.EmailChange) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{}).Where("id = ?", change.UserID).Updates(map[string]interface{}{
+			"email":          change.NewEmail,
+			"email_verified": true,
+		}).Error; err != nil {
+			return err
+		}
+		for _, m := range []interface{}{&model.EmailChange{}, &model.PasswordReset{}, &model.EmailVerification{}} {
+			if err := tx.Where("user_id = ?", change.UserID).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindUsernameHold — действующее закрепление имени, без учёта регистра
+func (r *UserRepo) FindUsernameHold(username string, now time.Time) (*model.UsernameHold, error) {
+	var hold model.UsernameHold
+	err := r.Db.Where("username = ? AND expires_at > ?", strings.ToLower(username), now).First(&hold).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// ChangeUsername — новое имя пользователю, старое закрепляется за ним до holdUntil.
+// Своё же закрепление нового имени (возврат прежнего имени) снимается.
+func (r *UserRepo) ChangeUsername(userID uint, oldName, newName string, now, holdUntil time.Time) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		var n int64
+		if err := tx.Model(&model.User{}).
+			Where("LOWER(username) = LOWER(?) AND id <> ?", newName, userID).Count(&n).Error; err != nil {
+			return err
+		}
+		if n > 0 {
+			return ErrUsernameTaken
+		}
+		if err := tx.Model(&model.UsernameHold{}).
+			Where("username = ? AND user_id <> ? AND expires_at > ?", strings.ToLower(newName), userID, now).
+			Count(&n).Error; err != nil {
+			return err
+		}
+		if n > 0 {
+			return ErrUsernameTaken
+		}
+
+		if err := tx.Where("username = ?", strings.ToLower(newName)).Delete(&model.UsernameHold{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"username":            newName,
+			"username_changed_at": now,
+			"handle_synced":       false,
+			"handle_rejected":     false,
+		}).Error; err != nil {
+			return err
+		}
+		if strings.EqualFold(oldName, newName) {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "username"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id", "expires_at", "created_at"}),
+		}).Create(&model.UsernameHold{
+			Username:  strings.ToLower(oldName),
+			UserID:    userID,
+			ExpiresAt: holdUntil,
+			CreatedAt: now,
+		}).Error
+	})
+}
@@ -0,0 +1,128 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
+)
+
+// AttemptStore — счётчики попыток входа и блокировки с ограниченным сроком жизни.
+// В проде — Redis (общий для всех реплик auth), в тестах и без Redis — память процесса.
+type AttemptStore interface {
+	// Incr — увеличивает счётчик; окно window отсчитывается от первой попытки
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Block — ключ активен ttl, повторный вызов продлевает срок
+	Block(ctx context.Context, key string, ttl time.Duration) error
+	// BlockedFor — сколько осталось до снятия блокировки, 0 — ключа нет
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// ---------------- Redis ----------------
+
+type RedisAttemptStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisAttemptStore(rdb *redis.Client) *RedisAttemptStore {
+	return &RedisAttemptStore{rdb: rdb}
+}
+
+func (s *RedisAttemptStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := s.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	// NX: срок ставится только первой попыткой, иначе окно сдвигалось бы бесконечно
+	pipe.ExpireNX(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (s *RedisAttemptStore) Block(ctx context.Context, key string, ttl time.Duration) error {
+	return s.rdb.Set(ctx, key, 1, ttl).Err()
+}
+
+func (s *RedisAttemptStore) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.rdb.PTTL(ctx, key).Result()
+	if errors.Is(err, redis.Nil) || ttl < 0 {
+		return 0, nil
+	}
+	return ttl, err
+}
+
+func (s *RedisAttemptStore) Delete(ctx context.Context, keys ...string) error {
+	return s.rdb.Del(ctx, keys...).Err()
+}
+
+// ---------------- Memory ----------------
+
+type memoryEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+type MemoryAttemptStore struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entries map[string]memoryEntry
+}
+
+func NewMemoryAttemptStore(now func() time.Time) *MemoryAttemptStore {
+	if now == nil {
+		now = time.Now
+	}
+	return &MemoryAttemptStore{now: now, entries: map[string]memoryEntry{}}
+}
+
+// get — вызывать под mu, просроченные записи удаляются при обращении
+func (s *MemoryAttemptStore) get(key string) (memoryEntry, bool) {
+	e, ok := s.entries[key]
+	if ok && !s.now().Before(e.expiresAt) {
+		delete(s.entries, key)
+		return memoryEntry{}, false
+	}
+	return e, ok
+}
+
+func (s *MemoryAttemptStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.get(key)
+	if !ok {
+		e = memoryEntry{expiresAt: s.now().Add(window)}
+	}
+	e.count++
+	s.entries[key] = e
+	return e.count, nil
+}
+
+func (s *MemoryAttemptStore) Block(ctx context.Context, key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryEntry{count: 1, expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+func (s *MemoryAttemptStore) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.get(key)
+	if !ok {
+		return 0, nil
+	}
+	return e.expiresAt.Sub(s.now()), nil
+}
+
+func (s *MemoryAttemptStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return nil
+}
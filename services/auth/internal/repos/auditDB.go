@@ -0,0 +1,57 @@
+package repos
+
+import (
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+// SecurityEventFilter — пустые поля не фильтруют; BeforeID — курсор страницы (события новее уже показаны)
+type SecurityEventFilter struct {
+	UserID   *uint
+	ActorID  *uint
+	Email    string
+	Type     string
+	IP       string
+	Since    *time.Time
+	Until    *time.Time
+	BeforeID uint
+	Limit    int
+}
+
+// AddSecurityEvent — журнал только пополняется, методов изменения и удаления нет
+func (r *UserRepo) AddSecurityEvent(event *model.SecurityEvent) error {
+	return r.Db.Create(event).Error
+}
+
+// ListSecurityEvents — новые первыми; id растёт вместе с created_at, поэтому курсор — id
+func (r *UserRepo) ListSecurityEvents(f SecurityEventFilter) ([]model.SecurityEvent, error) {
+	q := r.Db.Model(&model.SecurityEvent{})
+	if f.UserID != nil {
+		q = q.Where("user_id = ?", *f.UserID)
+	}
+	if f.ActorID != nil {
+		q = q.Where("actor_id = ?", *f.ActorID)
+	}
+	if f.Email != "" {
+		q = q.Where("email = ?", f.Email)
+	}
+	if f.Type != "" {
+		q = q.Where("type = ?", f.Type)
+	}
+	if f.IP != "" {
+		q = q.Where("ip = ?", f.IP)
+	}
+	if f.Since != nil {
+		q = q.Where("created_at >= ?", *f.Since)
+	}
+	if f.Until != nil {
+		q = q.Where("created_at < ?", *f.Until)
+	}
+	if f.BeforeID > 0 {
+		q = q.Where("id < ?", f.BeforeID)
+	}
+
+	var events []model.SecurityEvent
+	err := q.Order("id DESC").Limit(f.Limit).Find(&events).Error
+	return events, err
+}
@@ -1,6 +1,7 @@
 package repos
 
 import (
+	"errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
@@ -9,6 +10,12 @@ import (
 	"time"
 )
 
+// RefreshTokenTTL — срок жизни refresh токена
+const RefreshTokenTTL = 7 * 24 * time.Hour
+
+// ErrTokenReused — refresh токен уже был использован для ротации
+var ErrTokenReused = errors.New("refresh token already used")
+
 type UserRepo struct {
 	Db *gorm.DB
 }
@@ -27,16 +34,12 @@ func (r *UserRepo) RegisterDB(user *model.User) (uint, error) {
 	return user.ID, nil
 }
 
-func (r *UserRepo) SaveToken(token string, id uint) error {
-	// Удаляем старые токены пользователя
-	if err := r.Db.Where("user_id = ?", id).Delete(&model.RefreshToken{}).Error; err != nil {
-		return utils.ErrorHandler(err, "Cannot clear old refresh tokens")
+func (r *UserRepo) SaveToken(refresh *model.RefreshToken) error {
+	if refresh.ExpiresAt.IsZero() {
+		refresh.ExpiresAt = time.Now().Add(RefreshTokenTTL)
 	}
-
-	refresh := &model.RefreshToken{
-		Token:     token,
-		UserID:    id,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	if refresh.LastUsedAt.IsZero() {
+		refresh.LastUsedAt = time.Now()
 	}
 	if err := r.Db.Create(refresh).Error; err != nil {
 		return utils.ErrorHandler(err, "Cannot add refresh token")
@@ -53,6 +56,13 @@ func (r *UserRepo) GetUserByEmail(email string) (*model.User, error) {
 	return user, nil
 }
 
+// UsernameTaken — без учёта регистра; exceptID — сам пользователь (смена регистра своего имени), 0 — никто
+func (r *UserRepo) UsernameTaken(username string, exceptID uint) (bool, error) {
+	var n int64
+	err := r.Db.Model(&model.User{}).Where("LOWER(username) = LOWER(?) AND id <> ?", username, exceptID).Count(&n).Error
+	return n > 0, err
+}
+
 func (r *UserRepo) GetUserById(id uint) (*model.User, error) {
 	user := &model.User{}
 	if err := r.Db.Where("id = ?", id).First(&user).Error; err != nil {
@@ -61,34 +71,137 @@ func (r *UserRepo) GetUserById(id uint) (*model.User, error) {
 	return user, nil
 }
 
-func (r *UserRepo) FindRefreshTokenById(id uint) (*model.RefreshToken, error) {
+// DropLegacyRefreshTokens — вызывается до AutoMigrate. Токены, выданные до сессий, хранятся
+// открытым текстом и без семейства: по хэшу они всё равно не найдутся, а NOT NULL колонку
+// family_id Postgres к таблице со строками не добавит. Такие токены удаляются (пользователи
+// входят заново), повторный вызов ничего не делает.
+func (r *UserRepo) DropLegacyRefreshTokens() (int64, error) {
+	migrator := r.Db.Migrator()
+	if !migrator.HasTable(&model.RefreshToken{}) || migrator.HasColumn(&model.RefreshToken{}, "FamilyID") {
+		return 0, nil
+	}
+	res := r.Db.Where("1 = 1").Delete(&model.RefreshToken{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *UserRepo) FindRefreshToken(tokenHash string) (*model.RefreshToken, error) {
 	refresh := &model.RefreshToken{}
-	if err := r.Db.Where("user_id = ?", id).First(refresh).Error; err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+	if err := r.Db.Where("token = ?", tokenHash).First(refresh).Error; err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
 	}
 	return refresh, nil
 }
 
-func (r *UserRepo) CheckRefreshToken(refresh string) (uint, error) {
-	token := &model.RefreshToken{}
+// RotateToken — отзывает старый токен и сохраняет его замену в одной транзакции.
+// Возвращает ErrTokenReused, если старый токен уже был отозван параллельным запросом.
+func (r *UserRepo) RotateToken(old, next *model.RefreshToken) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Update("revoked_at", now)
+		if res.Error != nil {
+			return utils.ErrorHandler(res.Error, "Cannot revoke refresh token")
+		}
+		if res.RowsAffected == 0 {
+			return ErrTokenReused
+		}
 
-	// Сначала ищем в БД
-	if err := r.Db.Where("token = ?", refresh).First(token).Error; err != nil {
-		return 0, status.Error(codes.Unauthenticated, "invalid refresh token")
-	}
+		if next.ExpiresAt.IsZero() {
+			next.ExpiresAt = now.Add(RefreshTokenTTL)
+		}
+		next.LastUsedAt = now
+		if err := tx.Create(next).Error; err != nil {
+			return utils.ErrorHandler(err, "Cannot add refresh token")
+		}
 
-	// Потом проверяем срок действия
-	if time.Now().After(token.ExpiresAt) {
-		return 0, status.Error(codes.Unauthenticated, "refresh token expired")
-	}
+		return tx.Model(&model.RefreshToken{}).
+			Where("id = ?", old.ID).
+			Update("replaced_by_id", next.ID).Error
+	})
+}
+
+// RevokeTokenFamily — отзывает все токены сессии
+func (r *UserRepo) RevokeTokenFamily(familyID string) error {
+	return r.Db.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeUserSession — отзывает сессию, только если она принадлежит пользователю
+func (r *UserRepo) RevokeUserSession(userID uint, familyID string) (int64, error) {
+	res := r.Db.Model(&model.RefreshToken{}).
+		Where("user_id = ? AND family_id = ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", time.Now())
+	return res.RowsAffected, res.Error
+}
 
-	return token.UserID, nil
+// RevokeAllUserTokens — все сессии пользователя и уже выданные access токены, в том числе без sid
+func (r *UserRepo) RevokeAllUserTokens(userID uint) error {
+	now := time.Now()
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", userID).Update("tokens_valid_after", now).Error
+	})
+}
+
+// SessionActive — в семействе есть неотозванный и не истёкший refresh токен
+func (r *UserRepo) SessionActive(userID uint, familyID string) (bool, error) {
+	var n int64
+	err := r.Db.Model(&model.RefreshToken{}).
+		Where("user_id = ? AND family_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, familyID, time.Now()).
+		Count(&n).Error
+	return n > 0, err
+}
+
+// ListActiveSessions — в каждой сессии активен только последний выданный токен
+func (r *UserRepo) ListActiveSessions(userID uint) ([]model.RefreshToken, error) {
+	var tokens []model.RefreshToken
+	err := r.Db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&tokens).Error
+	return tokens, err
 }
 
 func (r *UserRepo) UpdatePassword(id uint, newPassword string) error {
 	return r.Db.Model(&model.User{}).Where("id = ?", id).Update("password", newPassword).Error
 }
 
+// ChangePassword — новый пароль, прежний хэш уходит в историю, в ней остаются keep последних
+func (r *UserRepo) ChangePassword(userID uint, newHash, oldHash string, keep int) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{}).Where("id = ?", userID).Update("password", newHash).Error; err != nil {
+			return err
+		}
+		if keep <= 0 {
+			return tx.Where("user_id = ?", userID).Delete(&model.PasswordHistory{}).Error
+		}
+		// у аккаунта из внешнего провайдера пароля ещё не было
+		if oldHash == "" {
+			return nil
+		}
+		if err := tx.Create(&model.PasswordHistory{UserID: userID, Hash: oldHash}).Error; err != nil {
+			return err
+		}
+		newest := tx.Model(&model.PasswordHistory{}).Select("id").
+			Where("user_id = ?", userID).Order("id DESC").Limit(keep)
+		return tx.Where("user_id = ? AND id NOT IN (?)", userID, newest).Delete(&model.PasswordHistory{}).Error
+	})
+}
+
+// RecentPasswordHashes — хэши прежних паролей, новые первыми
+func (r *UserRepo) RecentPasswordHashes(userID uint, limit int) ([]string, error) {
+	var hashes []string
+	err := r.Db.Model(&model.PasswordHistory{}).Where("user_id = ?", userID).
+		Order("id DESC").Limit(limit).Pluck("hash", &hashes).Error
+	return hashes, err
+}
+
 func (r *UserRepo) SaveResetToken(userID uint, token string) error {
 	reset := &model.PasswordReset{
 		UserID:    userID,
@@ -109,3 +222,187 @@ func (r *UserRepo) FindResetToken(token string) (*model.PasswordReset, error) {
 func (r *UserRepo) DeleteResetToken(token string) error {
 	return r.Db.Where("token = ?", token).Delete(&model.PasswordReset{}).Error
 }
+
+func (r *UserRepo) SaveMagicLink(link *model.MagicLink) error {
+	return r.Db.Create(link).Error
+}
+
+func (r *UserRepo) FindMagicLink(token string) (*model.MagicLink, error) {
+	link := &model.MagicLink{}
+	if err := r.Db.Where("token = ?", token).First(link).Error; err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// UseMagicLink — удаление и есть использование: из двух параллельных запросов сессию получит один
+func (r *UserRepo) UseMagicLink(id uint) (int64, error) {
+	res := r.Db.Where("id = ?", id).Delete(&model.MagicLink{})
+	return res.RowsAffected, res.Error
+}
+
+// DeleteMagicLinks — после входа остальные ссылки из писем больше не нужны
+func (r *UserRepo) DeleteMagicLinks(userID uint) error {
+	return r.Db.Where("user_id = ?", userID).Delete(&model.MagicLink{}).Error
+}
+
+func (r *UserRepo) SaveVerificationToken(userID uint, token string) error {
+	verification := &model.EmailVerification{
+		UserID:    userID,
+		Token:     token,
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+	return r.Db.Create(verification).Error
+}
+
+func (r *UserRepo) FindVerificationToken(token string) (*model.EmailVerification, error) {
+	verification := &model.EmailVerification{}
+	if err := r.Db.Where("token = ?", token).First(verification).Error; err != nil {
+		return nil, err
+	}
+	return verification, nil
+}
+
+func (r *UserRepo) DeleteVerificationTokens(userID uint) error {
+	return r.Db.Where("user_id = ?", userID).Delete(&model.EmailVerification{}).Error
+}
+
+// MarkEmailVerified — подтверждает email и удаляет все токены подтверждения пользователя
+func (r *UserRepo) MarkEmailVerified(userID uint) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{}).Where("id = ?", userID).Update("email_verified", true).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&model.EmailVerification{}).Error
+	})
+}
+
+// BackfillEmailVerified — вызывается до AutoMigrate. Если в users ещё нет email_verified,
+// колонка добавляется и все существующие аккаунты считаются подтверждёнными: они появились
+// до проверки email, и иначе потеряли бы право писать. Возвращает число таких аккаунтов,
+// повторный вызов ничего не делает.
+func (r *UserRepo) BackfillEmailVerified() (int64, error) {
+	migrator := r.Db.Migrator()
+	if !migrator.HasTable(&model.User{}) || migrator.HasColumn(&model.User{}, "EmailVerified") {
+		return 0, nil
+	}
+	// DDL в одной транзакции с UPDATE: при сбое колонка не останется без заполнения
+	var backfilled int64
+	err := r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().AddColumn(&model.User{}, "EmailVerified"); err != nil {
+			return err
+		}
+		res := tx.Model(&model.User{}).Where("1 = 1").Update("email_verified", true)
+		backfilled = res.RowsAffected
+		return res.Error
+	})
+	return backfilled, err
+}
+
+func (r *UserRepo) SetUserRole(userID uint, role string) error {
+	return r.Db.Model(&model.User{}).Where("id = ?", userID).Update("role", role).Error
+}
+
+// GrantRole — назначение роли списку пользователей (первичные администраторы из конфигурации)
+func (r *UserRepo) GrantRole(userIDs []uint, role string) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	res := r.Db.Model(&model.User{}).Where("id IN ? AND role <> ?", userIDs, role).Update("role", role)
+	return res.RowsAffected, res.Error
+}
+
+// SaveTwoFactorSecret — секрет сохраняется, но 2FA включается только после подтверждения кодом
+func (r *UserRepo) SaveTwoFactorSecret(userID uint, secret string) error {
+	return r.Db.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"two_factor_secret":    secret,
+		"two_factor_enabled":   false,
+		"two_factor_last_step": 0,
+	}).Error
+}
+
+func (r *UserRepo) EnableTwoFactor(userID uint, step int64, codeHashes []string) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_enabled":   true,
+			"two_factor_last_step": step,
+		}).Error; err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func (r *UserRepo) DisableTwoFactor(userID uint) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_enabled":   false,
+			"two_factor_secret":    "",
+			"two_factor_last_step": 0,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&model.TwoFactorChallenge{}).Error
+	})
+}
+
+func (r *UserRepo) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	codes := make([]model.RecoveryCode, 0, len(codeHashes))
+	for _, h := range codeHashes {
+		codes = append(codes, model.RecoveryCode{UserID: userID, CodeHash: h})
+	}
+	return tx.Create(&codes).Error
+}
+
+// AdvanceTwoFactorStep — условное обновление: при гонке двух запросов с одним кодом пройдёт только один
+func (r *UserRepo) AdvanceTwoFactorStep(userID uint, step int64) (bool, error) {
+	res := r.Db.Model(&model.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	return res.RowsAffected == 1, res.Error
+}
+
+func (r *UserRepo) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	res := r.Db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return res.RowsAffected == 1, res.Error
+}
+
+func (r *UserRepo) SaveTwoFactorChallenge(challenge *model.TwoFactorChallenge) error {
+	return r.Db.Create(challenge).Error
+}
+
+func (r *UserRepo) FindTwoFactorChallenge(tokenHash string) (*model.TwoFactorChallenge, error) {
+	var challenge model.TwoFactorChallenge
+	if err := r.Db.Where("token = ?", tokenHash).First(&challenge).Error; err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// ConsumeChallengeAttempt — попытка списывается до проверки кода одним условным UPDATE,
+// поэтому параллельные запросы не превысят limit. false — попытки исчерпаны.
+func (r *UserRepo) ConsumeChallengeAttempt(id uint, limit int) (bool, error) {
+	res := r.Db.Model(&model.TwoFactorChallenge{}).Where("id = ? AND attempts < ?", id, limit).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	return res.RowsAffected > 0, res.Error
+}
+
+// DeleteTwoFactorChallenge — challenge одноразовый, 0 строк значит его уже использовали
+func (r *UserRepo) DeleteTwoFactorChallenge(id uint) (int64, error) {
+	res := r.Db.Where("id = ?", id).Delete(&model.TwoFactorChallenge{})
+	return res.RowsAffected, res.Error
+}
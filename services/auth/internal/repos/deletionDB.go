@@ -0,0 +1,190 @@
+package repos
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+func (r *UserRepo) FindAccountDeletion(userID uint) (*model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	if err := r.Db.Where("user_id = ?", userID).First(&deletion).Error; err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// ScheduleAccountDeletion — новый запрос или повтор после отмены (строка на пользователя одна)
+func (r *UserRepo) ScheduleAccountDeletion(userID uint, scheduledFor time.Time) (*model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	err := r.Db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ?", userID).First(&deletion).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			deletion = model.AccountDeletion{
+				UserID:       userID,
+				Status:       model.DeletionPending,
+				ScheduledFor: scheduledFor,
+			}
+			return tx.Create(&deletion).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		// шаги прошлой попытки не считаются — данные могли появиться заново
+		if err := tx.Where("deletion_id = ?", deletion.ID).Delete(&model.AccountDeletionStep{}).Error; err != nil {
+			return err
+		}
+		deletion.Status = model.DeletionPending
+		deletion.ScheduledFor = scheduledFor
+		deletion.Attempts = 0
+		deletion.LastError = ""
+		return tx.Save(&deletion).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// ScheduleBotDeletions — удаление ботов владельца без льготного периода. Уже запущенные
+// и завершённые удаления не трогаются, поэтому повтор шага саги владельца безопасен.
+func (r *UserRepo) ScheduleBotDeletions(ownerID uint, now time.Time) (int, error) {
+	bots, err := r.ListBots(ownerID)
+	if err != nil {
+		return 0, err
+	}
+	scheduled := 0
+	for _, bot := range bots {
+		existing, err := r.FindAccountDeletion(bot.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return scheduled, err
+		}
+		if existing != nil && (existing.Status == model.DeletionRunning || existing.Status == model.DeletionCompleted) {
+			continue
+		}
+		if _, err := r.ScheduleAccountDeletion(bot.ID, now); err != nil {
+			return scheduled, err
+		}
+		scheduled++
+	}
+	return scheduled, nil
+}
+
+// CancelAccountDeletion — отмена возможна только до запуска саги
+func (r *UserRepo) CancelAccountDeletion(userID uint) (int64, error) {
+	res := r.Db.Model(&model.AccountDeletion{}).
+		Where("user_id = ? AND status = ?", userID, model.DeletionPending).
+		Update("status", model.DeletionCancelled)
+	return res.RowsAffected, res.Error
+}
+
+// ClaimDueDeletions — переводит в running удаления с истёкшим льготным периодом
+// и зависшие running (обработчик упал или шаг завершился ошибкой раньше staleBefore).
+// Захват условный, поэтому одну запись не возьмут две реплики auth.
+func (r *UserRepo) ClaimDueDeletions(now, staleBefore time.Time, limit int) ([]model.AccountDeletion, error) {
+	var candidates []model.AccountDeletion
+	err := r.Db.
+		Where("(status = ? AND scheduled_for <= ?) OR (status = ? AND updated_at < ?)",
+			model.DeletionPending, now, model.DeletionRunning, staleBefore).
+		Order("scheduled_for").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]model.AccountDeletion, 0, len(candidates))
+	for _, d := range candidates {
+		res := r.Db.Model(&model.AccountDeletion{}).
+			Where("id = ? AND status = ? AND updated_at = ?", d.ID, d.Status, d.UpdatedAt).
+			Updates(map[string]interface{}{"status": model.DeletionRunning, "updated_at": now})
+		if res.Error != nil {
+			return claimed, res.Error
+		}
+		if res.RowsAffected == 1 {
+			d.Status = model.DeletionRunning
+			d.UpdatedAt = now
+			claimed = append(claimed, d)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *UserRepo) CompletedDeletionSteps(deletionID uint) (map[string]bool, error) {
+	var names []string
+	if err := r.Db.Model(&model.AccountDeletionStep{}).
+		Where("deletion_id = ?", deletionID).
+		Pluck("name", &names).Error; err != nil {
+		return nil, err
+	}
+	done := make(map[string]bool, len(names))
+	for _, name := range names {
+		done[name] = true
+	}
+	return done, nil
+}
+
+// CompleteDeletionStep — отметка шага, заодно продлевает захват записи
+func (r *UserRepo) CompleteDeletionStep(deletionID uint, name string, now time.Time) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.AccountDeletionStep{DeletionID: deletionID, Name: name, CompletedAt: now}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.AccountDeletion{}).Where("id = ?", deletionID).
+			Update("updated_at", now).Error
+	})
+}
+
+// FailDeletion — запись остаётся running и будет подхвачена снова после таймаута захвата
+func (r *UserRepo) FailDeletion(deletionID uint, reason string, now time.Time) error {
+	return r.Db.Model(&model.AccountDeletion{}).Where("id = ?", deletionID).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": reason,
+			"updated_at": now,
+		}).Error
+}
+
+// PurgeUserAuthData — последний шаг саги: учётные данные удаляются вместе с отметкой о завершении
+func (r *UserRepo) PurgeUserAuthData(deletionID, userID uint, now time.Time) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		for _, m := range []interface{}{
+			&model.RefreshToken{},
+			&model.PasswordReset{},
+			&model.MagicLink{},
+			&model.OIDCState{},
+			&model.ExternalIdentity{},
+			&model.Impersonation{},
+			&model.PasswordHistory{},
+			&model.EmailVerification{},
+			&model.RecoveryCode{},
+			&model.TwoFactorChallenge{},
+			&model.DataExport{},
+			&model.EmailChange{},
+			&model.UsernameHold{},
+			&model.OutboxEmail{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+		// боты удаляются своими сагами (ScheduleBotDeletions), а до тех пор без токенов в них не войти
+		if err := deleteUserTokens(tx, userID); err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", userID).Delete(&model.User{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.AccountDeletion{}).Where("id = ?", deletionID).
+			Updates(map[string]interface{}{
+				"status":       model.DeletionCompleted,
+				"completed_at": now,
+				"last_error":   "",
+				"updated_at":   now,
+			}).Error
+	})
+}
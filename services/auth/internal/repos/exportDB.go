@@ -0,0 +1,101 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+func (r *UserRepo) CreateDataExport(export *model.DataExport) error {
+	return r.Db.Create(export).Error
+}
+
+// FindDataExport — только выгрузка самого пользователя
+func (r *UserRepo) FindDataExport(userID, exportID uint) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := r.Db.Where("id = ? AND user_id = ?", exportID, userID).First(&export).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// LatestDataExport — последняя выгрузка пользователя в любом статусе
+func (r *UserRepo) LatestDataExport(userID uint) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := r.Db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").First(&export).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ClaimPendingExports — как и у удаления аккаунта: новые и брошенные running, захват условный
+func (r *UserRepo) ClaimPendingExports(now, staleBefore time.Time, limit int) ([]model.DataExport, error) {
+	var candidates []model.DataExport
+	err := r.Db.
+		Where("status = ? OR (status = ? AND updated_at < ?)", model.ExportPending, model.ExportRunning, staleBefore).
+		Order("created_at").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]model.DataExport, 0, len(candidates))
+	for _, e := range candidates {
+		res := r.Db.Model(&model.DataExport{}).
+			Where("id = ? AND status = ? AND updated_at = ?", e.ID, e.Status, e.UpdatedAt).
+			Updates(map[string]interface{}{"status": model.ExportRunning, "updated_at": now})
+		if res.Error != nil {
+			return claimed, res.Error
+		}
+		if res.RowsAffected == 1 {
+			e.Status = model.ExportRunning
+			e.UpdatedAt = now
+			claimed = append(claimed, e)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *UserRepo) CompleteDataExport(exportID uint, objectKey string, size int64, expiresAt, now time.Time) error {
+	return r.Db.Model(&model.DataExport{}).Where("id = ?", exportID).
+		Updates(map[string]interface{}{
+			"status":       model.ExportReady,
+			"object_key":   objectKey,
+			"size_bytes":   size,
+			"expires_at":   expiresAt,
+			"completed_at": now,
+			"last_error":   "",
+			"updated_at":   now,
+		}).Error
+}
+
+// FailDataExport — после maxAttempts попыток выгрузка помечается failed, иначе ждёт повтора
+func (r *UserRepo) FailDataExport(exportID uint, reason string, maxAttempts int, now time.Time) error {
+	return r.Db.Model(&model.DataExport{}).Where("id = ?", exportID).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"status":     gorm.Expr("CASE WHEN attempts + 1 >= ? THEN ? ELSE status END", maxAttempts, model.ExportFailed),
+			"last_error": reason,
+			"updated_at": now,
+		}).Error
+}
+
+func (r *UserRepo) ListExpiredExports(now time.Time, limit int) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.Db.Where("status = ? AND expires_at <= ?", model.ExportReady, now).
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *UserRepo) MarkExportExpired(exportID uint) error {
+	return r.Db.Model(&model.DataExport{}).Where("id = ?", exportID).
+		Updates(map[string]interface{}{"status": model.ExportExpired, "object_key": ""}).Error
+}
+
+func (r *UserRepo) ListUserExports(userID uint) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.Db.Where("user_id = ?", userID).Find(&exports).Error
+	return exports, err
+}
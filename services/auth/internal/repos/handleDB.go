@@ -0,0 +1,30 @@
+package repos
+
+import "socialnet/services/auth/internal/model"
+
+// PendingHandleSyncs — аккаунты, чей username ещё не записан в профиль user сервиса.
+// Отвергнутые user сервисом ждут смены имени и в выборку не попадают.
+func (r *UserRepo) PendingHandleSyncs(limit int) ([]model.User, error) {
+	var users []model.User
+	err := r.Db.Select("id", "username").Where("handle_synced = ? AND handle_rejected = ?", false, false).
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// MarkHandleSynced — отмечает, только если username не поменялся, пока шла синхронизация.
+// Иначе аккаунт остаётся (или снова становится) ожидающим: до user сервиса могло дойти старое имя.
+func (r *UserRepo) MarkHandleSynced(userID uint, username string) error {
+	res := r.Db.Model(&model.User{}).Where("id = ? AND username = ?", userID, username).
+		Update("handle_synced", true)
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.Error
+	}
+	return r.Db.Model(&model.User{}).Where("id = ?", userID).Update("handle_synced", false).Error
+}
+
+// MarkHandleRejected — user сервис не принял username; помечается, только если имя
+// не поменялось за это время
+func (r *UserRepo) MarkHandleRejected(userID uint, username string) error {
+	return r.Db.Model(&model.User{}).Where("id = ? AND username = ?", userID, username).
+		Update("handle_rejected", true).Error
+}
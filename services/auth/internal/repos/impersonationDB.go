@@ -0,0 +1,26 @@
+package repos
+
+import (
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+func (r *UserRepo) SaveImpersonation(imp *model.Impersonation) error {
+	return r.Db.Create(imp).Error
+}
+
+func (r *UserRepo) FindImpersonation(id string) (*model.Impersonation, error) {
+	imp := &model.Impersonation{}
+	if err := r.Db.Where("id = ?", id).First(imp).Error; err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// EndImpersonation — завершить может только тот администратор, который её начал
+func (r *UserRepo) EndImpersonation(id string, adminID uint, now time.Time) (int64, error) {
+	res := r.Db.Model(&model.Impersonation{}).
+		Where("id = ? AND admin_id = ? AND ended_at IS NULL", id, adminID).
+		Update("ended_at", now)
+	return res.RowsAffected, res.Error
+}
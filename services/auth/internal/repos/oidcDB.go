@@ -0,0 +1,77 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+func (r *UserRepo) SaveOIDCState(state *model.OIDCState) error {
+	return r.Db.Create(state).Error
+}
+
+// TakeOIDCState — state одноразовый: находим и удаляем, повторный callback получит ErrRecordNotFound
+func (r *UserRepo) TakeOIDCState(stateHash string) (*model.OIDCState, error) {
+	state := &model.OIDCState{}
+	err := r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state = ?", stateHash).First(state).Error; err != nil {
+			return err
+		}
+		res := tx.Where("id = ?", state.ID).Delete(&model.OIDCState{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// DeleteExpiredOIDCStates — брошенные на странице провайдера входы
+func (r *UserRepo) DeleteExpiredOIDCStates(now time.Time) error {
+	return r.Db.Where("expires_at < ?", now).Delete(&model.OIDCState{}).Error
+}
+
+func (r *UserRepo) FindExternalIdentity(provider, subject string) (*model.ExternalIdentity, error) {
+	identity := &model.ExternalIdentity{}
+	if err := r.Db.Where("provider = ? AND subject = ?", provider, subject).First(identity).Error; err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (r *UserRepo) SaveExternalIdentity(identity *model.ExternalIdentity) error {
+	return r.Db.Create(identity).Error
+}
+
+func (r *UserRepo) TouchExternalIdentity(id uint, email string, now time.Time) error {
+	return r.Db.Model(&model.ExternalIdentity{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"email": email, "last_login_at": now}).Error
+}
+
+func (r *UserRepo) ListExternalIdentities(userID uint) ([]model.ExternalIdentity, error) {
+	var identities []model.ExternalIdentity
+	err := r.Db.Where("user_id = ?", userID).Order("provider").Find(&identities).Error
+	return identities, err
+}
+
+func (r *UserRepo) DeleteExternalIdentity(userID uint, provider string) (int64, error) {
+	res := r.Db.Where("user_id = ? AND provider = ?", userID, provider).Delete(&model.ExternalIdentity{})
+	return res.RowsAffected, res.Error
+}
+
+// RegisterExternalUser — новый аккаунт сразу с привязкой, чтобы не остался пользователь без способа входа
+func (r *UserRepo) RegisterExternalUser(user *model.User, identity *model.ExternalIdentity) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
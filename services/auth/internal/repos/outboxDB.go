@@ -0,0 +1,84 @@
+package repos
+
+import (
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+func (r *UserRepo) EnqueueEmail(email *model.OutboxEmail) error {
+	return r.Db.Create(email).Error
+}
+
+// ClaimDueEmails — письма, которым пора уходить, и зависшие в sending (обработчик упал посреди отправки)
+func (r *UserRepo) ClaimDueEmails(now, staleBefore time.Time, limit int) ([]model.OutboxEmail, error) {
+	var candidates []model.OutboxEmail
+	err := r.Db.
+		Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND updated_at < ?)",
+			model.MailPending, now, model.MailSending, staleBefore).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]model.OutboxEmail, 0, len(candidates))
+	for _, e := range candidates {
+		res := r.Db.Model(&model.OutboxEmail{}).
+			Where("id = ? AND status = ? AND updated_at = ?", e.ID, e.Status, e.UpdatedAt).
+			Updates(map[string]interface{}{"status": model.MailSending, "updated_at": now})
+		if res.Error != nil {
+			return claimed, res.Error
+		}
+		if res.RowsAffected == 1 {
+			e.Status = model.MailSending
+			e.UpdatedAt = now
+			claimed = append(claimed, e)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *UserRepo) MarkEmailSent(id uint, now time.Time) error {
+	return r.Db.Model(&model.OutboxEmail{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     model.MailSent,
+			"sent_at":    now,
+			"text_body":  "",
+			"html_body":  "",
+			"last_error": "",
+			"updated_at": now,
+		}).Error
+}
+
+// RetryEmail — следующая попытка в nextAttempt
+func (r *UserRepo) RetryEmail(id uint, attempts int, reason string, nextAttempt, now time.Time) error {
+	return r.Db.Model(&model.OutboxEmail{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          model.MailPending,
+			"attempts":        attempts,
+			"last_error":      reason,
+			"next_attempt_at": nextAttempt,
+			"updated_at":      now,
+		}).Error
+}
+
+// FailEmail — попытки исчерпаны, тело письма больше не нужно
+func (r *UserRepo) FailEmail(id uint, attempts int, reason string, now time.Time) error {
+	return r.Db.Model(&model.OutboxEmail{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     model.MailFailed,
+			"attempts":   attempts,
+			"last_error": reason,
+			"text_body":  "",
+			"html_body":  "",
+			"updated_at": now,
+		}).Error
+}
+
+// PruneEmails — удаляет отправленные и брошенные письма, обновлённые раньше before
+func (r *UserRepo) PruneEmails(before time.Time) (int64, error) {
+	res := r.Db.Where("status IN ? AND updated_at < ?", []string{model.MailSent, model.MailFailed}, before).
+		Delete(&model.OutboxEmail{})
+	return res.RowsAffected, res.Error
+}
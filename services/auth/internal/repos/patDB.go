@@ -0,0 +1,75 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+func (r *UserRepo) CreatePersonalAccessToken(token *model.PersonalAccessToken) error {
+	return r.Db.Create(token).Error
+}
+
+// FindPersonalAccessToken — по хэшу; отозванные и просроченные токены тоже возвращаются,
+// решение принимает сервис
+func (r *UserRepo) FindPersonalAccessToken(tokenHash string) (*model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	if err := r.Db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *UserRepo) GetPersonalAccessToken(id uint) (*model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	if err := r.Db.First(&token, id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ListPersonalAccessTokens — действующие токены аккаунта, новые первыми
+func (r *UserRepo) ListPersonalAccessTokens(userID uint, now time.Time) ([]model.PersonalAccessToken, error) {
+	var tokens []model.PersonalAccessToken
+	err := r.Db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("created_at desc").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *UserRepo) CountPersonalAccessTokens(userID uint, now time.Time) (int64, error) {
+	var n int64
+	err := r.Db.Model(&model.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).Count(&n).Error
+	return n, err
+}
+
+func (r *UserRepo) RevokePersonalAccessToken(id uint, now time.Time) (int64, error) {
+	res := r.Db.Model(&model.PersonalAccessToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", now)
+	return res.RowsAffected, res.Error
+}
+
+// TouchPersonalAccessToken — last_used_at обновляется не чаще раза в минуту
+func (r *UserRepo) TouchPersonalAccessToken(id uint, now time.Time) error {
+	return r.Db.Model(&model.PersonalAccessToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-time.Minute)).
+		Update("last_used_at", now).Error
+}
+
+func (r *UserRepo) ListBots(ownerID uint) ([]model.User, error) {
+	var bots []model.User
+	err := r.Db.Where("owner_id = ? AND bot = ?", ownerID, true).Order("created_at asc").Find(&bots).Error
+	return bots, err
+}
+
+func (r *UserRepo) CountBots(ownerID uint) (int64, error) {
+	var n int64
+	err := r.Db.Model(&model.User{}).Where("owner_id = ? AND bot = ?", ownerID, true).Count(&n).Error
+	return n, err
+}
+
+// deleteUserTokens — токены пользователя и его ботов (удаление аккаунта)
+func deleteUserTokens(tx *gorm.DB, userID uint) error {
+	bots := tx.Model(&model.User{}).Select("id").Where("owner_id = ?", userID)
+	return tx.Where("user_id = ? OR user_id IN (?)", userID, bots).Delete(&model.PersonalAccessToken{}).Error
+}
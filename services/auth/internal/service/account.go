@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"log"
+	"regexp"
+	"socialnet/pkg/contextx"
+	"socialnet/pkg/logger"
+	"socialnet/services/auth/internal/mailer"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/repos"
+	"socialnet/services/auth/internal/utils"
+	"strings"
+	"time"
+)
+
+const (
+	emailChangeTTL         = 24 * time.Hour
+	usernameChangeCooldown = 30 * 24 * time.Hour
+	usernameHoldPeriod     = 90 * 24 * time.Hour
+)
+
+var (
+	emailRe    = regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}$`)
+	usernameRe = regexp.MustCompile(`^[a-zA-Z0-9_]{3,32}$`)
+)
+
+// reservedUsernames — служебные имена, которые нельзя занять (сравнение без учёта регистра)
+var reservedUsernames = map[string]bool{
+	"admin": true, "administrator": true, "root": true, "system": true,
+	"support": true, "help": true, "security": true, "moderator": true,
+	"staff": true, "official": true, "socialnet": true, "api": true,
+	"me": true, "null": true, "undefined": true, "deleted": true,
+}
+
+// RequestEmailChange — ссылка подтверждения уходит на новый адрес, на старый — уведомление.
+// Email меняется только после перехода по ссылке.
+func (s *AuthService) RequestEmailChange(ctx context.Context, userID uint, newEmail, password string) error {
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+	if !emailRe.MatchString(newEmail) {
+		return status.Error(codes.InvalidArgument, "invalid mail")
+	}
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	if user.Bot {
+		return status.Error(codes.PermissionDenied, "bots have no email")
+	}
+	if err := s.checkLoginAllowed(ctx, user.Email); err != nil {
+		return err
+	}
+	if err := utils.VerifyPassword(user.Password, password); err != nil {
+		s.registerLoginFailure(ctx, user.Email, user)
+		return status.Error(codes.InvalidArgument, "password incorrect")
+	}
+	s.resetLoginFailures(ctx, user.Email)
+	if strings.EqualFold(user.Email, newEmail) {
+		return status.Error(codes.InvalidArgument, "new email matches the current one")
+	}
+	taken, err := s.Repo.EmailTaken(newEmail)
+	if err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	if taken {
+		return status.Error(codes.AlreadyExists, "email is already in use")
+	}
+
+	token, err := utils.GenerateUUID()
+	if err != nil {
+		return status.Error(codes.Internal, "internal error")
+	}
+	if err := s.Repo.SaveEmailChange(&model.EmailChange{
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		Token:     utils.HashToken(token),
+		ExpiresAt: s.Clock().Add(emailChangeTTL),
+	}); err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+
+	if err := s.enqueueMail(user, newEmail, mailer.TemplateEmailChangeConfirm, mailData{
+		Link: s.appLink("/confirm-email", token),
+	}); err != nil {
+		log.Printf("❌ email change confirmation for user %d not queued: %v", user.ID, err)
+		return status.Error(codes.Internal, "failed to send email")
+	}
+
+	// владелец старого адреса должен узнать о попытке, даже если это не он
+	if err := s.enqueueMail(user, user.Email, mailer.TemplateEmailChangeNotice, mailData{
+		NewEmail: maskEmail(newEmail),
+	}); err != nil {
+		log.Printf("⚠️ email change notice for user %d not queued: %v", user.ID, err)
+	}
+
+	logger.Log.Infow("✉️ email change requested", "account_id", user.ID)
+	s.audit(ctx, model.EventEmailChangeRequested, user, "to "+maskEmail(newEmail))
+	return nil
+}
+
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, token string) error {
+	if token == "" {
+		return status.Error(codes.InvalidArgument, "token required")
+	}
+	change, err := s.Repo.FindEmailChange(utils.HashToken(token))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid confirmation token")
+	}
+	if !s.Clock().Before(change.ExpiresAt) {
+		return status.Error(codes.InvalidArgument, "confirmation token expired")
+	}
+	// адрес могли занять, пока письмо шло
+	taken, err := s.Repo.EmailTaken(change.NewEmail)
+	if err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	if taken {
+		return status.Error(codes.AlreadyExists, "email is already in use")
+	}
+
+	if err := s.Repo.ApplyEmailChange(change); err != nil {
+		return status.Error(codes.Internal, "failed to change email")
+	}
+	logger.Log.Infow("✉️ email changed", "account_id", change.UserID)
+	s.auditUser(ctx, model.EventEmailChanged, change.UserID, "to "+maskEmail(change.NewEmail))
+	return nil
+}
+
+// ChangeUsername — не чаще раза в usernameChangeCooldown; прежнее имя закрепляется за
+// пользователем на usernameHoldPeriod. Возвращает access токен с новым name.
+func (s *AuthService) ChangeUsername(ctx context.Context, userID uint, username string) (*model.User, string, error) {
+	username = strings.TrimSpace(username)
+	if !usernameRe.MatchString(username) {
+		return nil, "", status.Error(codes.InvalidArgument, "username must be 3-32 letters, digits or underscores")
+	}
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return nil, "", status.Error(codes.NotFound, "user not found")
+	}
+	if user.Username == username {
+		return nil, "", status.Error(codes.InvalidArgument, "new username matches the current one")
+	}
+
+	now := s.Clock()
+	if user.UsernameChangedAt != nil {
+		if next := user.UsernameChangedAt.Add(usernameChangeCooldown); now.Before(next) {
+			return nil, "", status.Error(codes.FailedPrecondition,
+				fmt.Sprintf("username can be changed again after %s", next.Format(time.RFC3339)))
+		}
+	}
+	if err := s.checkUsernameAvailable(username, user.ID); err != nil {
+		return nil, "", err
+	}
+
+	oldName := user.Username
+	err = s.Repo.ChangeUsername(user.ID, oldName, username, now, now.Add(usernameHoldPeriod))
+	if errors.Is(err, repos.ErrUsernameTaken) {
+		return nil, "", status.Error(codes.AlreadyExists, "username is taken")
+	}
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, "failed to change username")
+	}
+	user.Username = username
+	user.UsernameChangedAt = &now
+	s.syncHandle(ctx, user)
+
+	// новый токен остаётся в текущей сессии
+	access, err := signAccessToken(user, contextx.GetSessionID(ctx))
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, "failed to generate token")
+	}
+	logger.Log.Infow("🏷️ username changed", "account_id", user.ID, "from", oldName, "to", username)
+	return user, access, nil
+}
+
+// checkUsernameAvailable — userID=0 для нового аккаунта
+func (s *AuthService) checkUsernameAvailable(username string, userID uint) error {
+	if reservedUsernames[strings.ToLower(username)] {
+		return status.Error(codes.InvalidArgument, "username is reserved")
+	}
+	taken, err := s.Repo.UsernameTaken(username, userID)
+	if err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	if taken {
+		return status.Error(codes.AlreadyExists, "username is taken")
+	}
+	hold, err := s.Repo.FindUsernameHold(username, s.Clock())
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.Internal, "db error")
+	}
+	if hold != nil && hold.UserID != userID {
+		return status.Error(codes.AlreadyExists, "username is taken")
+	}
+	return nil
+}
+
+// maskEmail — j***@example.com: в уведомлении на старый адрес новый целиком не показываем
+func maskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return email
+	}
+	return local[:1] + "***@" + domain
+}
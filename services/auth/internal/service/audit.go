@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"socialnet/pkg/contextx"
+	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/repos"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	securityEventsPageSize    = 50
+	securityEventsMaxPageSize = 200
+)
+
+// audit — событие от имени пользователя (user == nil, если аккаунт не найден).
+// Ошибка записи не прерывает действие: журнал не должен ломать вход.
+func (s *AuthService) audit(ctx context.Context, kind string, user *model.User, details string) {
+	event := &model.SecurityEvent{Type: kind, Details: details}
+	if user != nil {
+		id := user.ID
+		event.UserID = &id
+		event.Email = strings.ToLower(user.Email)
+	}
+	s.saveSecurityEvent(ctx, event)
+}
+
+// auditUser — когда под рукой только id пользователя
+func (s *AuthService) auditUser(ctx context.Context, kind string, userID uint, details string) {
+	s.saveSecurityEvent(ctx, &model.SecurityEvent{Type: kind, UserID: &userID, Details: details})
+}
+
+// auditByAdmin — действие администратора над чужим аккаунтом
+func (s *AuthService) auditByAdmin(ctx context.Context, kind string, adminID uint, user *model.User, details string) {
+	id := user.ID
+	s.saveSecurityEvent(ctx, &model.SecurityEvent{
+		Type:    kind,
+		UserID:  &id,
+		Email:   strings.ToLower(user.Email),
+		ActorID: &adminID,
+		Details: details,
+	})
+}
+
+// auditLoginFailure — email сохраняем как ввели, чтобы видеть перебор и по несуществующим адресам
+func (s *AuthService) auditLoginFailure(ctx context.Context, email string, user *model.User, reason string) {
+	event := &model.SecurityEvent{
+		Type:    model.EventLoginFailed,
+		Email:   truncate(strings.ToLower(strings.TrimSpace(email)), 255),
+		Details: reason,
+	}
+	if user != nil {
+		id := user.ID
+		event.UserID = &id
+	}
+	s.saveSecurityEvent(ctx, event)
+}
+
+func (s *AuthService) saveSecurityEvent(ctx context.Context, event *model.SecurityEvent) {
+	event.IP = truncate(contextx.GetClientIP(ctx), 64)
+	event.UserAgent = truncate(contextx.GetUserAgent(ctx), 255)
+	event.CreatedAt = s.Clock()
+	if err := s.Repo.AddSecurityEvent(event); err != nil {
+		log.Printf("❌ security event %s not saved: %v", event.Type, err)
+	}
+}
+
+// ListSecurityEvents — журнал самого пользователя
+func (s *AuthService) ListSecurityEvents(userID uint, pageSize int32, pageToken string) ([]model.SecurityEvent, string, error) {
+	return s.listSecurityEvents(repos.SecurityEventFilter{UserID: &userID}, pageSize, pageToken)
+}
+
+// AdminListSecurityEvents — журнал по всем аккаунтам с фильтрами
+func (s *AuthService) AdminListSecurityEvents(adminID uint, req *pb.AdminListSecurityEventsRequest) ([]model.SecurityEvent, string, error) {
+	if err := s.requireAdmin(adminID); err != nil {
+		return nil, "", err
+	}
+
+	filter := repos.SecurityEventFilter{
+		Email: strings.ToLower(strings.TrimSpace(req.Email)),
+		Type:  req.Type,
+		IP:    req.Ip,
+	}
+	if req.UserId != "" {
+		uid, err := strconv.ParseUint(req.UserId, 10, 64)
+		if err != nil {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid user id")
+		}
+		id := uint(uid)
+		filter.UserID = &id
+	}
+	if req.ActorId != "" {
+		aid, err := strconv.ParseUint(req.ActorId, 10, 64)
+		if err != nil {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid actor id")
+		}
+		id := uint(aid)
+		filter.ActorID = &id
+	}
+	var err error
+	if filter.Since, err = parseOptionalTime(req.Since); err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, "invalid since")
+	}
+	if filter.Until, err = parseOptionalTime(req.Until); err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, "invalid until")
+	}
+
+	return s.listSecurityEvents(filter, req.PageSize, req.PageToken)
+}
+
+func (s *AuthService) listSecurityEvents(filter repos.SecurityEventFilter, pageSize int32, pageToken string) ([]model.SecurityEvent, string, error) {
+	switch {
+	case pageSize <= 0:
+		filter.Limit = securityEventsPageSize
+	case pageSize > securityEventsMaxPageSize:
+		filter.Limit = securityEventsMaxPageSize
+	default:
+		filter.Limit = int(pageSize)
+	}
+	if pageToken != "" {
+		before, err := decodeEventCursor(pageToken)
+		if err != nil {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		filter.BeforeID = before
+	}
+
+	// берём на одну запись больше, чтобы знать, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+	events, err := s.Repo.ListSecurityEvents(filter)
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, "failed to load security events")
+	}
+
+	next := ""
+	if len(events) > limit {
+		events = events[:limit]
+		next = encodeEventCursor(events[limit-1].ID)
+	}
+	return events, next, nil
+}
+
+func encodeEventCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeEventCursor(token string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// truncate — user agent и email приходят от клиента и могут не влезть в колонку
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	// обрезка могла разрезать многобайтовый символ
+	return strings.ToValidUTF8(value[:max], "")
+}
@@ -1,38 +1,95 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
-	"regexp"
+	"os"
+	"socialnet/pkg/contextx"
 	utils2 "socialnet/pkg/utils"
 	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/mailer"
 	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/oidc"
 	"socialnet/services/auth/internal/repos"
 	"socialnet/services/auth/internal/utils"
+	"strings"
 	"time"
 )
 
 type AuthService struct {
 	Repo *repos.UserRepo
+	// Attempts — счётчики неудачных попыток входа и запросов сброса пароля
+	Attempts repos.AttemptStore
+	// Clock — источник времени для TOTP и challenge токенов, в тестах подменяется фиксированным
+	Clock func() time.Time
+	// DeletionSteps — шаги саги удаления аккаунта в других сервисах
+	DeletionSteps []DeletionStep
+	// ExportSources, ExportStore — сборка архива персональных данных;
+	// без ExportStore выгрузка отключена
+	ExportSources []ExportSource
+	ExportStore   ExportStore
+	// NotifyExportReady — уведомление в приложении о готовом архиве (необязательно)
+	NotifyExportReady func(ctx context.Context, userID, exportID string) error
+	// SyncHandle — запись username как handle профиля в user сервисе (необязательно)
+	SyncHandle func(ctx context.Context, userID, handle string) error
+	// Mailer — доставка писем из outbox, Templates — их тексты на языках пользователей
+	Mailer    mailer.Mailer
+	Templates *mailer.Templates
+	// AppBaseURL — адрес фронтенда для ссылок в письмах
+	AppBaseURL string
+	// PasswordPolicy — требования к новым паролям (Register, UpdatePassword, ResetPassword)
+	PasswordPolicy *utils.PasswordPolicy
+	// OIDCProviders — внешние провайдеры входа по имени из OIDC_PROVIDERS
+	OIDCProviders map[string]*oidc.Provider
 }
 
-func NewAuthService(repo *repos.UserRepo) *AuthService {
-	return &AuthService{Repo: repo}
+func NewAuthService(repo *repos.UserRepo, attempts repos.AttemptStore) *AuthService {
+	return &AuthService{
+		Repo:       repo,
+		Attempts:   attempts,
+		Clock:      time.Now,
+		Mailer:     mailer.NewFileMailer(""),
+		Templates:  mailer.DefaultTemplates(),
+		AppBaseURL: appBaseURL(),
+		// без файла из окружения — встроенный список частых паролей
+		PasswordPolicy: utils.DefaultPasswordPolicy(),
+		OIDCProviders:  map[string]*oidc.Provider{},
+	}
 }
 
-func (s *AuthService) Register(req *pb.RegisterRequest) (string, string, error) {
+// appBaseURL — адрес фронтенда для ссылок в письмах (APP_BASE_URL)
+func appBaseURL() string {
+	base := os.Getenv("APP_BASE_URL")
+	if base == "" {
+		base = "http://localhost:3000"
+	}
+	return strings.TrimRight(base, "/")
+}
+
+func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (string, string, error) {
 
 	log.Printf("Register: %s", req.Username)
 	// валидация
-	re := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}$`)
-	if !re.MatchString(req.Email) {
+	if !emailRe.MatchString(req.Email) {
 		return "", "", status.Error(codes.InvalidArgument, "invalid mail")
 	}
-	if err := utils2.ValidateStruct(req); err != nil {
+	// locale необязателен, поэтому без ValidateStruct
+	if strings.TrimSpace(req.Password) == "" || strings.TrimSpace(req.Username) == "" {
 		return "", "", status.Error(codes.InvalidArgument, "required all fields")
 	}
+	if !usernameRe.MatchString(req.Username) {
+		return "", "", status.Error(codes.InvalidArgument, "username must be 3-32 letters, digits or underscores")
+	}
+	if err := s.checkNewPassword(nil, req.Password); err != nil {
+		return "", "", err
+	}
+	if err := s.checkUsernameAvailable(req.Username, 0); err != nil {
+		return "", "", err
+	}
 
 	// хэшируем пароль
 	encPass, err := utils.PasswordHashing(req.Password)
@@ -45,130 +102,187 @@ func (s *AuthService) Register(req *pb.RegisterRequest) (string, string, error)
 		Email:    req.Email,
 		Username: req.Username,
 		Password: encPass,
+		Role:     utils2.RoleUser,
+	}
+	if s.Templates.HasLocale(req.Locale) {
+		user.Locale = req.Locale
 	}
 
 	// сохраняем в БД
-	userID, err := s.Repo.RegisterDB(user)
-	if err != nil {
+	if _, err := s.Repo.RegisterDB(user); err != nil {
 		return "", "", status.Error(codes.Internal, "db error")
 	}
 
-	// создаём access token
-	accessToken, err := utils2.SignToken(fmt.Sprint(userID), user.Username)
-	if err != nil {
-		return "", "", status.Error(codes.Internal, "internal error")
-	}
-
-	// создаём refresh token
-	refreshToken, err := utils2.GenerateRefreshToken()
-	if err != nil {
-		return "", "", status.Error(codes.Internal, "internal error")
+	// письмо с подтверждением не должно ломать регистрацию — его можно запросить повторно
+	if err := s.sendVerification(user); err != nil {
+		log.Printf("⚠️ verification email for user %d not queued: %v", user.ID, err)
 	}
+	s.audit(ctx, model.EventRegistered, user, "")
+	s.syncHandle(ctx, user)
 
-	// сохраняем refresh в БД
-	if err = s.Repo.SaveToken(refreshToken, userID); err != nil {
-		return "", "", status.Error(codes.Internal, "db error")
-	}
-
-	return accessToken, refreshToken, nil
+	// создаём access + refresh токены новой сессии
+	return s.startSession(ctx, user)
 }
 
-func (s *AuthService) Login(req *pb.LoginRequest) (string, string, error) {
+// Login — при включённой 2FA токены не выдаются: возвращаем challenge токен,
+// который обменивается на сессию через VerifyTwoFactor.
+func (s *AuthService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	// валидация
 	if err := utils2.ValidateStruct(req); err != nil {
-		return "", "", status.Error(codes.InvalidArgument, "required all fields")
+		return nil, status.Error(codes.InvalidArgument, "required all fields")
+	}
+	if err := s.checkLoginAllowed(ctx, req.Email); err != nil {
+		s.auditLoginFailure(ctx, req.Email, nil, "rate_limited")
+		return nil, err
 	}
+
+	// неизвестный email и неверный пароль неотличимы ни по ответу, ни по времени
 	user, err := s.Repo.GetUserByEmail(req.Email)
 	if err != nil {
-		return "", "", status.Error(codes.NotFound, "user not exists")
+		_ = utils.VerifyPassword(dummyPasswordHash(), req.Password)
+		s.registerLoginFailure(ctx, req.Email, nil)
+		s.auditLoginFailure(ctx, req.Email, nil, "unknown_email")
+		return nil, status.Error(codes.InvalidArgument, "email or password wrong")
 	}
 
+	// у ботов нет пароля — только personal access токены
+	if user.Bot {
+		_ = utils.VerifyPassword(dummyPasswordHash(), req.Password)
+		s.registerLoginFailure(ctx, req.Email, user)
+		s.auditLoginFailure(ctx, req.Email, user, "bot_account")
+		return nil, status.Error(codes.InvalidArgument, "email or password wrong")
+	}
 	if err = utils.VerifyPassword(user.Password, req.Password); err != nil {
-		return "", "", status.Error(codes.InvalidArgument, "email or password wrong")
+		s.registerLoginFailure(ctx, req.Email, user)
+		s.auditLoginFailure(ctx, req.Email, user, "wrong_password")
+		return nil, status.Error(codes.InvalidArgument, "email or password wrong")
 	}
+	s.resetLoginFailures(ctx, req.Email)
+	s.upgradePasswordHash(user, req.Password)
 
-	refresh, err := s.Repo.FindRefreshTokenById(user.ID)
-	if err != nil {
-		return "", "", err
+	if s.deletionInProgress(user.ID) {
+		return nil, status.Error(codes.FailedPrecondition, "account is being deleted")
 	}
-	var refreshToken string
-	if time.Now().Unix() > refresh.ExpiresAt.Unix() {
-		refreshToken, err = utils2.GenerateRefreshToken()
+
+	if user.TwoFactorEnabled {
+		challenge, err := s.startTwoFactorChallenge(user)
 		if err != nil {
-			return "", "", status.Error(codes.Internal, "internal error")
+			return nil, err
 		}
-		if err := s.Repo.SaveToken(refreshToken, user.ID); err != nil {
-			return "", "", err
-		}
-	} else {
-		refreshToken = refresh.Token
+		return &pb.LoginResponse{TwoFactorRequired: true, ChallengeToken: challenge}, nil
 	}
 
-	accessToken, err := utils2.SignToken(fmt.Sprint(user.ID), user.Username)
+	// каждый вход — отдельная сессия, остальные устройства не трогаем
+	access, refresh, err := s.startSession(ctx, user)
 	if err != nil {
-		return "", "", status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
-	return accessToken, refreshToken, nil
+	s.audit(ctx, model.EventLoginSucceeded, user, "password")
+	return &pb.LoginResponse{AccessToken: access, RefreshToken: refresh}, nil
 }
 
-func (s *AuthService) RefreshToken(req *pb.RefreshRequest) (string, error) {
-	id, err := s.Repo.CheckRefreshToken(req.RefreshToken)
+// RefreshToken — выдаёт новую пару токенов, старый refresh токен больше не действует.
+// Повторное предъявление уже использованного токена означает его утечку:
+// отзываем всю сессию, чтобы украденная копия стала бесполезной.
+func (s *AuthService) RefreshToken(ctx context.Context, req *pb.RefreshRequest) (string, string, error) {
+	if req.RefreshToken == "" {
+		return "", "", status.Error(codes.InvalidArgument, "refresh token required")
+	}
+	current, err := s.Repo.FindRefreshToken(utils.HashToken(req.RefreshToken))
 	if err != nil {
-		return "", utils2.ErrorHandler(err, "invalid token ")
+		return "", "", err
 	}
 
-	user, err := s.Repo.GetUserById(id)
-	if err != nil {
-		return "", status.Error(codes.NotFound, "user not found")
+	if current.RevokedAt != nil {
+		s.revokeReusedFamily(ctx, current)
+		return "", "", status.Error(codes.Unauthenticated, "refresh token reused")
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return "", "", status.Error(codes.Unauthenticated, "refresh token expired")
 	}
 
-	token, err := utils2.SignToken(fmt.Sprint(user.ID), user.Username)
+	user, err := s.Repo.GetUserById(current.UserID)
 	if err != nil {
-		return "", utils2.ErrorHandler(err, "internal error")
+		return "", "", status.Error(codes.NotFound, "user not found")
 	}
 
-	return token, nil
-}
+	refreshToken, err := utils2.GenerateRefreshToken()
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "internal error")
+	}
+	next := &model.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  current.FamilyID,
+		Token:     utils.HashToken(refreshToken),
+		UserAgent: deviceUserAgent(ctx, current.UserAgent),
+		IP:        deviceIP(ctx, current.IP),
+		CreatedAt: current.CreatedAt, // время начала сессии сохраняется при ротации
+	}
+	if err := s.Repo.RotateToken(current, next); err != nil {
+		if errors.Is(err, repos.ErrTokenReused) {
+			s.revokeReusedFamily(ctx, current)
+			return "", "", status.Error(codes.Unauthenticated, "refresh token reused")
+		}
+		return "", "", status.Error(codes.Internal, "db error")
+	}
 
-func (s *AuthService) UpdatePassword(req *pb.UpdatePasswordRequest) (string, error) {
-	uid, err := utils2.StringToUint(req.Id)
+	accessToken, err := signAccessToken(user, current.FamilyID)
 	if err != nil {
-		return "", status.Error(codes.Internal, "internal error")
+		return "", "", status.Error(codes.Internal, "internal error")
 	}
-	user, err := s.Repo.GetUserById(uid)
+
+	s.audit(ctx, model.EventTokenRefreshed, user, "session "+current.FamilyID)
+	return accessToken, refreshToken, nil
+}
+
+// UpdatePassword — все прежние сессии и access токены отзываются,
+// вызывающий получает новую сессию
+func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, req *pb.UpdatePasswordRequest) (string, string, error) {
+	user, err := s.Repo.GetUserById(userID)
 	if err != nil {
-		return "", status.Error(codes.NotFound, "user not found")
+		return "", "", status.Error(codes.NotFound, "user not found")
 	}
 
+	// текущий пароль подбирается так же, как при входе: те же счётчики и блокировки
+	if err := s.checkLoginAllowed(ctx, user.Email); err != nil {
+		return "", "", err
+	}
 	if err := utils.VerifyPassword(user.Password, req.CurrentPassword); err != nil {
-		return "", status.Error(codes.InvalidArgument, "current password incorrect")
+		s.registerLoginFailure(ctx, user.Email, user)
+		return "", "", status.Error(codes.InvalidArgument, "current password incorrect")
 	}
+	s.resetLoginFailures(ctx, user.Email)
 
-	hashed, err := utils.PasswordHashing(req.NewPassword)
-	if err != nil {
-		return "", status.Error(codes.Internal, "failed to hash password")
+	if err := s.checkNewPassword(user, req.NewPassword); err != nil {
+		return "", "", err
 	}
-
-	if err := s.Repo.UpdatePassword(user.ID, hashed); err != nil {
-		return "", status.Error(codes.Internal, "failed to update password")
+	if err := s.setPassword(user, req.NewPassword); err != nil {
+		return "", "", err
 	}
+	if err := s.Repo.RevokeAllUserTokens(user.ID); err != nil {
+		return "", "", status.Error(codes.Internal, "failed to revoke sessions")
+	}
+	s.audit(ctx, model.EventPasswordUpdated, user, "")
 
-	access, err := utils2.SignToken(fmt.Sprint(user.ID), user.Username)
+	return s.startSession(ctx, user)
+}
+
+// ForgotPassword — ответ одинаковый для зарегистрированных и неизвестных email
+func (s *AuthService) ForgotPassword(ctx context.Context, req *pb.ForgotPasswordRequest) error {
+	allowed, err := s.allowMailRequest(ctx, "reset", req.Email)
 	if err != nil {
-		return "", status.Error(codes.Internal, "failed to generate token")
+		return err
+	}
+	if !allowed {
+		return nil
 	}
-	return access, nil
-}
 
-func (s *AuthService) ForgotPassword(req *pb.ForgotPasswordRequest) error {
 	user, err := s.Repo.GetUserByEmail(req.Email)
 	if err != nil {
-		return status.Error(codes.NotFound, "user not found")
+		return nil
 	}
 
-	to := req.Email
 	token, err := utils.GenerateUUID()
-	resetLink := "http://localhost:3000/reset-password?token=" + token
 	if err != nil {
 		return status.Error(codes.Internal, "failed to generate reset token")
 	}
@@ -176,19 +290,18 @@ func (s *AuthService) ForgotPassword(req *pb.ForgotPasswordRequest) error {
 		return status.Error(codes.Internal, "failed to save reset token")
 	}
 
-	// Отправляем письмо
-	subject := "Password Reset Request"
-	body := "<p>Для сброса пароля перейдите по ссылке:</p>" +
-		"<a href='" + resetLink + "'>" + resetLink + "</a>"
-
-	if err = utils.SendEmail(to, subject, body); err != nil {
-		return status.Error(codes.Internal, "failed to send email")
+	// письмо уходит из outbox; ошибка тоже не должна отличать существующий аккаунт
+	if err := s.enqueueMail(user, user.Email, mailer.TemplatePasswordReset, mailData{
+		Link: s.appLink("/reset-password", token),
+	}); err != nil {
+		log.Printf("❌ reset email for user %d not queued: %v", user.ID, err)
 	}
+	s.audit(ctx, model.EventPasswordResetRequest, user, "")
 
 	return nil
 }
 
-func (s *AuthService) ResetPassword(req *pb.ResetPasswordRequest) error {
+func (s *AuthService) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) error {
 	reset, err := s.Repo.FindResetToken(req.ResetToken)
 	if err != nil {
 		return status.Error(codes.InvalidArgument, "invalid reset token")
@@ -198,19 +311,210 @@ func (s *AuthService) ResetPassword(req *pb.ResetPasswordRequest) error {
 		return status.Error(codes.InvalidArgument, "reset token expired")
 	}
 
-	hashed, err := utils.PasswordHashing(req.NewPassword)
+	user, err := s.Repo.GetUserById(reset.UserID)
 	if err != nil {
-		return status.Error(codes.Internal, "failed to hash password")
+		return status.Error(codes.InvalidArgument, "invalid reset token")
+	}
+	if err := s.checkNewPassword(user, req.NewPassword); err != nil {
+		return err
+	}
+	if err := s.setPassword(user, req.NewPassword); err != nil {
+		return err
+	}
+	// ссылка одноразовая
+	if err := s.Repo.DeleteResetToken(req.ResetToken); err != nil {
+		log.Printf("❌ failed to delete reset token of user %d: %v", user.ID, err)
+	}
+	// пароль сбрасывают, когда он мог утечь: выходим со всех устройств
+	if err := s.Repo.RevokeAllUserTokens(user.ID); err != nil {
+		return status.Error(codes.Internal, "failed to revoke sessions")
 	}
 
-	if err := s.Repo.UpdatePassword(reset.UserID, hashed); err != nil {
-		return status.Error(codes.Internal, "failed to update password")
+	// владение почтой подтверждено — снимаем блокировку входа
+	s.resetLoginFailures(ctx, user.Email)
+	s.audit(ctx, model.EventPasswordReset, user, "")
+	return nil
+}
+
+// VerifyEmail — подтверждает email по токену из письма.
+// Новый claim email_verified появится в access токене после RefreshToken.
+func (s *AuthService) VerifyEmail(req *pb.VerifyEmailRequest) error {
+	if req.Token == "" {
+		return status.Error(codes.InvalidArgument, "token required")
+	}
+	verification, err := s.Repo.FindVerificationToken(req.Token)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid verification token")
+	}
+
+	if verification.ExpiresAt.Before(time.Now()) {
+		return status.Error(codes.InvalidArgument, "verification token expired")
 	}
 
-	fmt.Println(req.NewPassword)
+	if err := s.Repo.MarkEmailVerified(verification.UserID); err != nil {
+		return status.Error(codes.Internal, "failed to verify email")
+	}
 	return nil
 }
 
+func (s *AuthService) ResendVerification(userID uint) error {
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	if user.EmailVerified {
+		return status.Error(codes.FailedPrecondition, "email already verified")
+	}
+
+	// старые ссылки больше не нужны
+	if err := s.Repo.DeleteVerificationTokens(user.ID); err != nil {
+		return status.Error(codes.Internal, "failed to reset verification token")
+	}
+	if err := s.sendVerification(user); err != nil {
+		return status.Error(codes.Internal, "failed to send email")
+	}
+	return nil
+}
+
+func (s *AuthService) sendVerification(user *model.User) error {
+	token, err := utils.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	if err := s.Repo.SaveVerificationToken(user.ID, token); err != nil {
+		return err
+	}
+
+	return s.enqueueMail(user, user.Email, mailer.TemplateVerifyEmail, mailData{
+		Link: s.appLink("/verify-email", token),
+	})
+}
+
+func (s *AuthService) ListSessions(userID uint) ([]model.RefreshToken, error) {
+	sessions, err := s.Repo.ListActiveSessions(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load sessions")
+	}
+	return sessions, nil
+}
+
+func (s *AuthService) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	if sessionID == "" {
+		return status.Error(codes.InvalidArgument, "session id required")
+	}
+	revoked, err := s.Repo.RevokeUserSession(userID, sessionID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to revoke session")
+	}
+	if revoked == 0 {
+		return status.Error(codes.NotFound, "session not found")
+	}
+	s.auditUser(ctx, model.EventSessionRevoked, userID, "session "+sessionID)
+	return nil
+}
+
+// Logout — завершает сессию, к которой относится переданный refresh токен
+func (s *AuthService) Logout(ctx context.Context, userID uint, refreshToken string) error {
+	if refreshToken == "" {
+		return status.Error(codes.InvalidArgument, "refresh token required")
+	}
+	token, err := s.Repo.FindRefreshToken(utils.HashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if token.UserID != userID {
+		return status.Error(codes.PermissionDenied, "not your session")
+	}
+	if err := s.Repo.RevokeTokenFamily(token.FamilyID); err != nil {
+		return status.Error(codes.Internal, "failed to logout")
+	}
+	s.auditUser(ctx, model.EventLogout, userID, "session "+token.FamilyID)
+	return nil
+}
+
+func (s *AuthService) LogoutAll(ctx context.Context, userID uint) error {
+	if err := s.Repo.RevokeAllUserTokens(userID); err != nil {
+		return status.Error(codes.Internal, "failed to logout")
+	}
+	s.auditUser(ctx, model.EventLogoutAll, userID, "")
+	return nil
+}
+
+// signAccessToken — claims access токена берутся из актуальной записи пользователя,
+// поэтому смена роли вступает в силу при следующем обновлении токена.
+// sessionID связывает токен с сессией: после её отзыва токен отклоняется gateway.
+func signAccessToken(user *model.User, sessionID string) (string, error) {
+	role := user.Role
+	if role == "" {
+		role = utils2.RoleUser
+	}
+	return utils2.SignToken(fmt.Sprint(user.ID), user.Username, role, user.EmailVerified, sessionID)
+}
+
+// startSession — новая сессия: access токен + refresh токен нового семейства
+func (s *AuthService) startSession(ctx context.Context, user *model.User) (string, string, error) {
+	refreshToken, err := utils2.GenerateRefreshToken()
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "internal error")
+	}
+	familyID, err := utils.GenerateUUID()
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "internal error")
+	}
+	accessToken, err := signAccessToken(user, familyID)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "internal error")
+	}
+
+	if err := s.Repo.SaveToken(&model.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		Token:     utils.HashToken(refreshToken),
+		UserAgent: contextx.GetUserAgent(ctx),
+		IP:        contextx.GetClientIP(ctx),
+	}); err != nil {
+		return "", "", status.Error(codes.Internal, "db error")
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func (s *AuthService) revokeReusedFamily(ctx context.Context, token *model.RefreshToken) {
+	log.Printf("⚠️ refresh token reuse detected: user=%d session=%s", token.UserID, token.FamilyID)
+	if err := s.Repo.RevokeTokenFamily(token.FamilyID); err != nil {
+		log.Printf("❌ failed to revoke session %s: %v", token.FamilyID, err)
+	}
+	s.auditUser(ctx, model.EventRefreshTokenReused, token.UserID, "session "+token.FamilyID)
+}
+
+// deviceUserAgent / deviceIP — при ротации обновляем данные устройства, если они пришли
+func deviceUserAgent(ctx context.Context, fallback string) string {
+	if ua := contextx.GetUserAgent(ctx); ua != "" {
+		return ua
+	}
+	return fallback
+}
+
+func deviceIP(ctx context.Context, fallback string) string {
+	if ip := contextx.GetClientIP(ctx); ip != "" {
+		return ip
+	}
+	return fallback
+}
+
+// JWKS — публичные ключи, которыми проверяются access токены
+func (s *AuthService) JWKS() ([]byte, error) {
+	keys, err := utils2.DefaultKeySet()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "signing keys unavailable")
+	}
+	jwks, err := keys.JWKS()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return jwks, nil
+}
+
 func (s *AuthService) GetProfile(userID uint) (*model.User, error) {
 	user, err := s.Repo.GetUserById(userID)
 	if err != nil {
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"os"
+	"socialnet/pkg/config"
+	"socialnet/pkg/interceptor"
+	"socialnet/pkg/logger"
+	"socialnet/pkg/users"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+	chatpb "socialnet/services/chat/gen"
+	commentpb "socialnet/services/comment/gen"
+	likepb "socialnet/services/like/gen"
+	notificationpb "socialnet/services/notification/gen"
+	postpb "socialnet/services/post/gen"
+	userpb "socialnet/services/user/gen"
+	"time"
+)
+
+// Удаление аккаунта — сага: после льготного периода по очереди вызываются шаги очистки
+// в остальных сервисах, каждый шаг отмечается в БД. Шаги идемпотентны, поэтому после
+// падения любого участника сага просто продолжается с первого невыполненного шага.
+const (
+	defaultDeletionGrace = 14 * 24 * time.Hour
+	// через столько running запись без прогресса считается брошенной и захватывается снова
+	deletionRetryAfter  = 10 * time.Minute
+	deletionBatchSize   = 20
+	deletionStepTimeout = 30 * time.Second
+)
+
+// DeletionStep — очистка данных пользователя в одном сервисе
+type DeletionStep struct {
+	Name string
+	Run  func(ctx context.Context, userID string) error
+}
+
+// DeleteAccount — планирует удаление; до ScheduledFor его можно отменить
+func (s *AuthService) DeleteAccount(ctx context.Context, userID uint, password string) (*model.AccountDeletion, error) {
+	if password == "" {
+		return nil, status.Error(codes.InvalidArgument, "password required")
+	}
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err := utils.VerifyPassword(user.Password, password); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "password incorrect")
+	}
+
+	// повторный запрос не сдвигает срок
+	if existing, err := s.Repo.FindAccountDeletion(user.ID); err == nil &&
+		(existing.Status == model.DeletionPending || existing.Status == model.DeletionRunning) {
+		return existing, nil
+	}
+
+	deletion, err := s.Repo.ScheduleAccountDeletion(user.ID, s.Clock().Add(deletionGrace()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to schedule deletion")
+	}
+
+	logger.Log.Infow("🗑️ account deletion scheduled",
+		"account_id", user.ID,
+		"scheduled_for", deletion.ScheduledFor.Format(time.RFC3339),
+	)
+	return deletion, nil
+}
+
+func (s *AuthService) CancelAccountDeletion(userID uint) error {
+	cancelled, err := s.Repo.CancelAccountDeletion(userID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to cancel deletion")
+	}
+	if cancelled == 0 {
+		return status.Error(codes.FailedPrecondition, "no pending deletion")
+	}
+	logger.Log.Infow("↩️ account deletion cancelled", "account_id", userID)
+	return nil
+}
+
+// deletionInProgress — после запуска саги войти в аккаунт уже нельзя
+func (s *AuthService) deletionInProgress(userID uint) bool {
+	deletion, err := s.Repo.FindAccountDeletion(userID)
+	return err == nil && deletion.Status == model.DeletionRunning
+}
+
+// RunDueDeletions — один проход обработчика, возвращает число завершённых удалений
+func (s *AuthService) RunDueDeletions(ctx context.Context) (int, error) {
+	now := s.Clock()
+	due, err := s.Repo.ClaimDueDeletions(now, now.Add(-deletionRetryAfter), deletionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, deletion := range due {
+		if err := s.runDeletion(ctx, &deletion); err != nil {
+			log.Printf("❌ account deletion %d (user %d) failed: %v", deletion.ID, deletion.UserID, err)
+			continue
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+func (s *AuthService) runDeletion(ctx context.Context, deletion *model.AccountDeletion) error {
+	// новые токены по старым сессиям больше не выдаются
+	if err := s.Repo.RevokeAllUserTokens(deletion.UserID); err != nil {
+		return err
+	}
+	// боты без владельца не остаются: их данные во всех сервисах чистит такая же сага
+	if n, err := s.Repo.ScheduleBotDeletions(deletion.UserID, s.Clock()); err != nil {
+		return err
+	} else if n > 0 {
+		logger.Log.Infow("🤖 bot deletions scheduled", "account_id", deletion.UserID, "bots", n)
+	}
+
+	done, err := s.Repo.CompletedDeletionSteps(deletion.ID)
+	if err != nil {
+		return err
+	}
+
+	userID := fmt.Sprint(deletion.UserID)
+	for _, step := range s.DeletionSteps {
+		if done[step.Name] {
+			continue
+		}
+
+		stepCtx, cancel := context.WithTimeout(ctx, deletionStepTimeout)
+		err := step.Run(stepCtx, userID)
+		cancel()
+		if err != nil {
+			reason := fmt.Sprintf("%s: %v", step.Name, err)
+			if ferr := s.Repo.FailDeletion(deletion.ID, reason, s.Clock()); ferr != nil {
+				log.Printf("❌ failed to record deletion error: %v", ferr)
+			}
+			return errors.New(reason)
+		}
+
+		if err := s.Repo.CompleteDeletionStep(deletion.ID, step.Name, s.Clock()); err != nil {
+			return err
+		}
+	}
+
+	if err := s.deleteUserExports(deletion.UserID); err != nil {
+		return err
+	}
+	if err := s.Repo.PurgeUserAuthData(deletion.ID, deletion.UserID, s.Clock()); err != nil {
+		return err
+	}
+	logger.Log.Infow("🗑️ account deleted", "account_id", deletion.UserID, "deletion_id", deletion.ID)
+	return nil
+}
+
+// StartDeletionWorker — периодический запуск саги до отмены ctx
+func (s *AuthService) StartDeletionWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RunDueDeletions(ctx); err != nil {
+				log.Printf("❌ deletion worker: %v", err)
+			}
+		}
+	}
+}
+
+// RemoteDeletionSteps — шаги очистки в остальных сервисах.
+// Профиль удаляется последним: пока он есть, пользователь виден в поиске и подписках.
+func RemoteDeletionSteps(clients *config.GRPCClients) []DeletionStep {
+	return []DeletionStep{
+		{Name: "post", Run: func(ctx context.Context, userID string) error {
+			client, err := clients.GetPostClient("localhost:50053")
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteUserData(interceptor.WithInternalToken(ctx), &postpb.DeleteUserDataRequest{UserId: userID})
+			return err
+		}},
+		{Name: "comment", Run: func(ctx context.Context, userID string) error {
+			client, err := clients.GetCommentClient("localhost:50054")
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteUserData(interceptor.WithInternalToken(ctx), &commentpb.DeleteUserDataRequest{UserId: userID})
+			return err
+		}},
+		{Name: "like", Run: func(ctx context.Context, userID string) error {
+			client, err := clients.GetLikeClient("localhost:50055")
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteUserData(interceptor.WithInternalToken(ctx), &likepb.DeleteUserDataRequest{UserId: userID})
+			return err
+		}},
+		{Name: "chat", Run: func(ctx context.Context, userID string) error {
+			client, err := clients.GetChatClient("localhost:50056")
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteUserData(interceptor.WithInternalToken(ctx), &chatpb.DeleteUserDataRequest{UserId: userID})
+			return err
+		}},
+		{Name: "notification", Run: func(ctx context.Context, userID string) error {
+			client, err := clients.GetNotifClient("localhost:50057")
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteUserData(interceptor.WithInternalToken(ctx), &notificationpb.DeleteUserDataRequest{UserId: userID})
+			return err
+		}},
+		{Name: "user", Run: func(ctx context.Context, userID string) error {
+			client, err := clients.GetUserClient(users.Addr())
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteUser(interceptor.WithInternalToken(ctx), &userpb.DeleteUserRequest{Id: userID})
+			return err
+		}},
+	}
+}
+
+// deletionGrace — льготный период, ACCOUNT_DELETION_GRACE в формате time.ParseDuration
+func deletionGrace() time.Duration {
+	if v := os.Getenv("ACCOUNT_DELETION_GRACE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return d
+		}
+		log.Printf("⚠️ invalid ACCOUNT_DELETION_GRACE %q, using default", v)
+	}
+	return defaultDeletionGrace
+}
@@ -0,0 +1,491 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"io"
+	"log"
+	"os"
+	"socialnet/pkg/config"
+	"socialnet/pkg/interceptor"
+	"socialnet/pkg/logger"
+	"socialnet/pkg/storage"
+	"socialnet/pkg/users"
+	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/mailer"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+	chatpb "socialnet/services/chat/gen"
+	commentpb "socialnet/services/comment/gen"
+	likepb "socialnet/services/like/gen"
+	notificationpb "socialnet/services/notification/gen"
+	postpb "socialnet/services/post/gen"
+	userpb "socialnet/services/user/gen"
+	"strings"
+	"time"
+)
+
+const (
+	// столько живут архив и ссылка на него; presigned ссылка S3 не может жить дольше 7 дней
+	exportTTL = 48 * time.Hour
+	// повторный запрос в течение exportReuseWindow отдаёт готовый архив вместо новой сборки
+	exportReuseWindow   = 24 * time.Hour
+	exportRetryAfter    = 10 * time.Minute
+	exportMaxAttempts   = 3
+	exportBatchSize     = 5
+	exportSourceTimeout = time.Minute
+)
+
+// ExportSource — часть архива: данные одного сервиса и ссылки на его медиа
+type ExportSource struct {
+	Name    string // файл <Name>.json в архиве
+	Collect func(ctx context.Context, userID string) (proto.Message, []string, error)
+}
+
+// ExportStore — хранилище архивов и медиа, в проде *storage.S3Client
+type ExportStore interface {
+	UploadFile(reader io.Reader, fileName string) (string, error)
+	DownloadFile(fileName string) (io.ReadCloser, error)
+	PresignURL(fileName string, ttl time.Duration) (string, error)
+	DeleteFile(fileName string) error
+}
+
+// RequestDataExport — ставит сборку архива в очередь. Пока предыдущая выгрузка
+// собирается или недавно готова, возвращается она.
+func (s *AuthService) RequestDataExport(ctx context.Context, userID uint) (*model.DataExport, error) {
+	if s.ExportStore == nil {
+		return nil, status.Error(codes.Unavailable, "data export is not configured")
+	}
+
+	latest, err := s.Repo.LatestDataExport(userID)
+	if err == nil {
+		switch {
+		case latest.Status == model.ExportPending || latest.Status == model.ExportRunning:
+			return latest, nil
+		case latest.Status == model.ExportReady && s.Clock().Sub(latest.CreatedAt) < exportReuseWindow:
+			return latest, nil
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	export := &model.DataExport{
+		UserID:    userID,
+		Status:    model.ExportPending,
+		CreatedAt: s.Clock(),
+		UpdatedAt: s.Clock(),
+	}
+	if err := s.Repo.CreateDataExport(export); err != nil {
+		return nil, status.Error(codes.Internal, "failed to request export")
+	}
+	logger.Log.Infow("📦 data export requested", "account_id", userID, "export_id", export.ID)
+	return export, nil
+}
+
+// GetDataExportStatus — для готового архива каждый раз выдаётся свежая ссылка,
+// но не дольше срока хранения самого архива
+func (s *AuthService) GetDataExportStatus(userID, exportID uint) (*model.DataExport, string, error) {
+	export, err := s.Repo.FindDataExport(userID, exportID)
+	if err != nil {
+		return nil, "", status.Error(codes.NotFound, "export not found")
+	}
+	if export.Status != model.ExportReady || export.ExpiresAt == nil {
+		return export, "", nil
+	}
+
+	ttl := export.ExpiresAt.Sub(s.Clock())
+	if ttl <= 0 {
+		export.Status = model.ExportExpired
+		return export, "", nil
+	}
+	if s.ExportStore == nil {
+		return export, "", status.Error(codes.Unavailable, "data export is not configured")
+	}
+	link, err := s.ExportStore.PresignURL(export.ObjectKey, ttl)
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, "failed to create download link")
+	}
+	return export, link, nil
+}
+
+// RunPendingExports — один проход обработчика: собирает архивы и удаляет просроченные
+func (s *AuthService) RunPendingExports(ctx context.Context) (int, error) {
+	if s.ExportStore == nil {
+		return 0, nil
+	}
+	s.expireExports()
+
+	now := s.Clock()
+	exports, err := s.Repo.ClaimPendingExports(now, now.Add(-exportRetryAfter), exportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	ready := 0
+	for _, export := range exports {
+		if err := s.buildExport(ctx, &export); err != nil {
+			log.Printf("❌ data export %d (user %d) failed: %v", export.ID, export.UserID, err)
+			if ferr := s.Repo.FailDataExport(export.ID, err.Error(), exportMaxAttempts, s.Clock()); ferr != nil {
+				log.Printf("❌ failed to record export error: %v", ferr)
+			}
+			continue
+		}
+		ready++
+	}
+	return ready, nil
+}
+
+// StartExportWorker — периодический запуск сборки архивов до отмены ctx
+func (s *AuthService) StartExportWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RunPendingExports(ctx); err != nil {
+				log.Printf("❌ export worker: %v", err)
+			}
+		}
+	}
+}
+
+// exportManifest — описание архива, первым файлом
+type exportManifest struct {
+	UserID       string   `json:"user_id"`
+	GeneratedAt  string   `json:"generated_at"`
+	Files        []string `json:"files"`
+	Media        []string `json:"media"`
+	MissingMedia []string `json:"missing_media,omitempty"` // объекты, которых уже нет в хранилище
+	ExternalURLs []string `json:"external_urls,omitempty"` // ссылки не на наше хранилище, не скачиваются
+}
+
+func (s *AuthService) buildExport(ctx context.Context, export *model.DataExport) error {
+	user, err := s.Repo.GetUserById(export.UserID)
+	if err != nil {
+		return fmt.Errorf("user not found: %v", err)
+	}
+	userID := fmt.Sprint(user.ID)
+
+	// архив собирается во временный файл: медиа может быть много
+	tmp, err := os.CreateTemp("", "export-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	zw := zip.NewWriter(tmp)
+	manifest := exportManifest{UserID: userID, GeneratedAt: s.Clock().Format(time.RFC3339)}
+	var mediaURLs []string
+
+	for _, source := range append(s.localExportSources(user), s.ExportSources...) {
+		sourceCtx, cancel := context.WithTimeout(ctx, exportSourceTimeout)
+		data, media, err := source.Collect(sourceCtx, userID)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s: %v", source.Name, err)
+		}
+
+		body, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(data)
+		if err != nil {
+			return err
+		}
+		name := source.Name + ".json"
+		if err := writeZipFile(zw, name, body); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, name)
+		mediaURLs = append(mediaURLs, media...)
+	}
+
+	seen := map[string]bool{}
+	for _, url := range mediaURLs {
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+
+		// ссылки в сообщениях и т.п. задаёт клиент: копируются только собственные загрузки пользователя
+		key, ok := storage.KeyFromURL(url)
+		if !ok || !ownMediaKey(userID, key) {
+			manifest.ExternalURLs = append(manifest.ExternalURLs, url)
+			continue
+		}
+		if err := s.copyMedia(zw, key); err != nil {
+			log.Printf("⚠️ export %d: media %s skipped: %v", export.ID, key, err)
+			manifest.MissingMedia = append(manifest.MissingMedia, key)
+			continue
+		}
+		manifest.Media = append(manifest.Media, "media/"+key)
+	}
+
+	body, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, "manifest.json", body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	// имя объекта не угадать: даже при открытом бакете архив доступен только по ссылке
+	suffix, err := utils.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("exports/%d/%d-%s.zip", user.ID, export.ID, suffix)
+	if _, err := s.ExportStore.UploadFile(tmp, key); err != nil {
+		return fmt.Errorf("upload: %v", err)
+	}
+
+	now := s.Clock()
+	expiresAt := now.Add(exportTTL)
+	if err := s.Repo.CompleteDataExport(export.ID, key, size, expiresAt, now); err != nil {
+		return err
+	}
+	logger.Log.Infow("📦 data export ready", "account_id", user.ID, "export_id", export.ID, "size", size)
+
+	s.notifyExportReady(ctx, user, export.ID, key)
+	return nil
+}
+
+// notifyExportReady — письмо со ссылкой и уведомление в приложении; ошибки не откатывают выгрузку
+func (s *AuthService) notifyExportReady(ctx context.Context, user *model.User, exportID uint, key string) {
+	link, err := s.ExportStore.PresignURL(key, exportTTL)
+	if err != nil {
+		log.Printf("❌ export link for user %d not created: %v", user.ID, err)
+		return
+	}
+
+	if err := s.enqueueMail(user, user.Email, mailer.TemplateExportReady, mailData{
+		Link:  link,
+		Hours: int(exportTTL.Hours()),
+	}); err != nil {
+		log.Printf("⚠️ export email for user %d not queued: %v", user.ID, err)
+	}
+
+	if s.NotifyExportReady != nil {
+		if err := s.NotifyExportReady(ctx, fmt.Sprint(user.ID), fmt.Sprint(exportID)); err != nil {
+			log.Printf("⚠️ export notification for user %d not sent: %v", user.ID, err)
+		}
+	}
+}
+
+// expireExports — архивы с истёкшим сроком удаляются из хранилища
+func (s *AuthService) expireExports() {
+	expired, err := s.Repo.ListExpiredExports(s.Clock(), exportBatchSize*10)
+	if err != nil {
+		log.Printf("❌ failed to list expired exports: %v", err)
+		return
+	}
+	for _, export := range expired {
+		if err := s.ExportStore.DeleteFile(export.ObjectKey); err != nil {
+			log.Printf("❌ failed to delete export %d: %v", export.ID, err)
+			continue
+		}
+		if err := s.Repo.MarkExportExpired(export.ID); err != nil {
+			log.Printf("❌ failed to mark export %d expired: %v", export.ID, err)
+		}
+	}
+}
+
+// deleteUserExports — архивы удаляемого аккаунта убираются из хранилища до удаления строк
+func (s *AuthService) deleteUserExports(userID uint) error {
+	if s.ExportStore == nil {
+		return nil
+	}
+	exports, err := s.Repo.ListUserExports(userID)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if export.ObjectKey == "" {
+			continue
+		}
+		if err := s.ExportStore.DeleteFile(export.ObjectKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownMediaKey — объект загружен сервисами для самого пользователя: аватар, обложка, картинки
+// постов (avatars/<id>_..., covers/<id>_...). Ключи с ".." отбрасываются, они идут в имя файла в архиве.
+func ownMediaKey(userID, key string) bool {
+	if strings.Contains(key, "..") {
+		return false
+	}
+	for _, prefix := range []string{"avatars/", "covers/"} {
+		if strings.HasPrefix(key, prefix+userID+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *AuthService) copyMedia(zw *zip.Writer, key string) error {
+	src, err := s.ExportStore.DownloadFile(key)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := zw.Create("media/" + key)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+func writeZipFile(zw *zip.Writer, name string, body []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// localExportSources — данные самого auth сервиса: учётная запись и активные сессии.
+// Хэши паролей, секрет 2FA и коды восстановления в архив не попадают.
+func (s *AuthService) localExportSources(user *model.User) []ExportSource {
+	return []ExportSource{
+		{Name: "account", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			return &pb.ProfileResponse{
+				Id:               userID,
+				Email:            user.Email,
+				Username:         user.Username,
+				CreatedAt:        user.CreatedAt.Format(time.RFC3339),
+				EmailVerified:    user.EmailVerified,
+				TwoFactorEnabled: user.TwoFactorEnabled,
+			}, nil, nil
+		}},
+		{Name: "sessions", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			sessions, err := s.Repo.ListActiveSessions(user.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			resp := &pb.Sessions{}
+			for _, t := range sessions {
+				resp.Sessions = append(resp.Sessions, &pb.Session{
+					Id:         t.FamilyID,
+					UserAgent:  t.UserAgent,
+					Ip:         t.IP,
+					CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+					LastUsedAt: t.LastUsedAt.Format(time.RFC3339),
+					ExpiresAt:  t.ExpiresAt.Format(time.RFC3339),
+				})
+			}
+			return resp, nil, nil
+		}},
+	}
+}
+
+// RemoteExportSources — данные остальных сервисов через их внутренний ExportUserData
+func RemoteExportSources(clients *config.GRPCClients) []ExportSource {
+	return []ExportSource{
+		{Name: "profile", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			client, err := clients.GetUserClient(users.Addr())
+			if err != nil {
+				return nil, nil, err
+			}
+			resp, err := client.ExportUserData(interceptor.WithInternalToken(ctx), &userpb.ExportUserDataRequest{UserId: userID})
+			if err != nil {
+				return nil, nil, err
+			}
+			return resp, []string{resp.GetProfile().GetAvatarUrl(), resp.GetProfile().GetCoverUrl()}, nil
+		}},
+		{Name: "posts", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			client, err := clients.GetPostClient("localhost:50053")
+			if err != nil {
+				return nil, nil, err
+			}
+			resp, err := client.ExportUserData(interceptor.WithInternalToken(ctx), &postpb.ExportUserDataRequest{UserId: userID})
+			if err != nil {
+				return nil, nil, err
+			}
+			var media []string
+			for _, p := range resp.Posts {
+				media = append(media, p.ImageUrl)
+			}
+			return resp, media, nil
+		}},
+		{Name: "comments", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			client, err := clients.GetCommentClient("localhost:50054")
+			if err != nil {
+				return nil, nil, err
+			}
+			resp, err := client.ExportUserData(interceptor.WithInternalToken(ctx), &commentpb.ExportUserDataRequest{UserId: userID})
+			return resp, nil, err
+		}},
+		{Name: "likes", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			client, err := clients.GetLikeClient("localhost:50055")
+			if err != nil {
+				return nil, nil, err
+			}
+			resp, err := client.ExportUserData(interceptor.WithInternalToken(ctx), &likepb.ExportUserDataRequest{UserId: userID})
+			return resp, nil, err
+		}},
+		{Name: "chats", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			client, err := clients.GetChatClient("localhost:50056")
+			if err != nil {
+				return nil, nil, err
+			}
+			resp, err := client.ExportUserData(interceptor.WithInternalToken(ctx), &chatpb.ExportUserDataRequest{UserId: userID})
+			if err != nil {
+				return nil, nil, err
+			}
+			var media []string
+			for _, m := range resp.Messages {
+				media = append(media, m.MediaUrl)
+			}
+			return resp, media, nil
+		}},
+		{Name: "notifications", Collect: func(ctx context.Context, userID string) (proto.Message, []string, error) {
+			client, err := clients.GetNotifClient("localhost:50057")
+			if err != nil {
+				return nil, nil, err
+			}
+			resp, err := client.ExportUserData(interceptor.WithInternalToken(ctx), &notificationpb.ExportUserDataRequest{UserId: userID})
+			return resp, nil, err
+		}},
+	}
+}
+
+// RemoteExportNotifier — системное уведомление о готовом архиве; ссылку клиент берёт из GetDataExportStatus
+func RemoteExportNotifier(clients *config.GRPCClients) func(ctx context.Context, userID, exportID string) error {
+	return func(ctx context.Context, userID, exportID string) error {
+		client, err := clients.GetNotifClient("localhost:50057")
+		if err != nil {
+			return err
+		}
+		_, err = client.CreateNotification(ctx, &notificationpb.CreateNotificationRequest{
+			UserId:      userID,
+			Type:        "system",
+			ReferenceId: exportID,
+			Content:     "Архив с вашими данными готов к скачиванию",
+		})
+		return err
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"socialnet/pkg/config"
+	"socialnet/pkg/interceptor"
+	"socialnet/pkg/users"
+	"socialnet/services/auth/internal/model"
+	userpb "socialnet/services/user/gen"
+	"time"
+)
+
+const handleSyncBatchSize = 100
+
+// syncHandle — username сразу в профиль; при сбое аккаунт остаётся ожидающим
+// и его досинхронизирует StartHandleSyncWorker
+func (s *AuthService) syncHandle(ctx context.Context, user *model.User) {
+	if s.SyncHandle == nil {
+		return
+	}
+	if err := s.SyncHandle(ctx, fmt.Sprint(user.ID), user.Username); err != nil {
+		log.Printf("⚠️ handle of user %d not synced, will retry: %v", user.ID, err)
+		return
+	}
+	if err := s.Repo.MarkHandleSynced(user.ID, user.Username); err != nil {
+		log.Printf("⚠️ failed to mark handle of user %d synced: %v", user.ID, err)
+	}
+}
+
+// RunHandleSync — один проход: ожидающие аккаунты (новые, переименованные и созданные
+// до появления handle). Аккаунты в процессе удаления пропускаются, иначе профиль
+// в user сервисе появился бы снова. Отвергнутые имена помечаются и больше не
+// отправляются; при остальных ошибках проход прерывается до следующего тика.
+func (s *AuthService) RunHandleSync(ctx context.Context) (int, error) {
+	if s.SyncHandle == nil {
+		return 0, nil
+	}
+	pending, err := s.Repo.PendingHandleSyncs(handleSyncBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	synced := 0
+	for _, user := range pending {
+		if s.deletionInProgress(user.ID) {
+			continue
+		}
+		if err := s.SyncHandle(ctx, fmt.Sprint(user.ID), user.Username); err != nil {
+			// legacy username не по правилам handle: повтор ничего не даст, а аккаунт
+			// в начале выборки не должен останавливать остальных
+			if status.Code(err) == codes.InvalidArgument {
+				log.Printf("⚠️ handle %q of user %d rejected: %v", user.Username, user.ID, err)
+				if err := s.Repo.MarkHandleRejected(user.ID, user.Username); err != nil {
+					return synced, err
+				}
+				continue
+			}
+			return synced, err
+		}
+		if err := s.Repo.MarkHandleSynced(user.ID, user.Username); err != nil {
+			return synced, err
+		}
+		synced++
+	}
+	return synced, nil
+}
+
+// StartHandleSyncWorker — периодическая досинхронизация handle до отмены ctx
+func (s *AuthService) StartHandleSyncWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RunHandleSync(ctx); err != nil {
+				log.Printf("❌ handle sync worker: %v", err)
+			}
+		}
+	}
+}
+
+// RemoteHandleSync — запись handle через внутренний UserService.SetHandle
+func RemoteHandleSync(clients *config.GRPCClients) func(ctx context.Context, userID, handle string) error {
+	return func(ctx context.Context, userID, handle string) error {
+		client, err := clients.GetUserClient(users.Addr())
+		if err != nil {
+			return err
+		}
+		_, err = client.SetHandle(interceptor.WithInternalToken(ctx), &userpb.SetHandleRequest{UserId: userID, Handle: handle})
+		return err
+	}
+}
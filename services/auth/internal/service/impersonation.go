@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"socialnet/pkg/logger"
+	utils2 "socialnet/pkg/utils"
+	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+	"strings"
+	"time"
+)
+
+// impersonationTTL — токен имперсонации не продлевается, после него нужна новая
+const impersonationTTL = 15 * time.Minute
+
+// Impersonate — короткоживущий access токен пользователя с claim act (администратор).
+// Других администраторов имперсонировать нельзя: так можно было бы получить их права.
+func (s *AuthService) Impersonate(ctx context.Context, adminID uint, req *pb.ImpersonateRequest) (string, *model.Impersonation, error) {
+	if err := s.requireAdmin(adminID); err != nil {
+		return "", nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return "", nil, status.Error(codes.InvalidArgument, "reason required")
+	}
+	targetID, err := utils2.StringToUint(req.UserId)
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if targetID == adminID {
+		return "", nil, status.Error(codes.InvalidArgument, "cannot impersonate yourself")
+	}
+	target, err := s.Repo.GetUserById(targetID)
+	if err != nil {
+		return "", nil, status.Error(codes.NotFound, "user not found")
+	}
+	if target.Role == utils2.RoleAdmin {
+		return "", nil, status.Error(codes.PermissionDenied, "admins cannot be impersonated")
+	}
+	if s.deletionInProgress(target.ID) {
+		return "", nil, status.Error(codes.FailedPrecondition, "account is being deleted")
+	}
+
+	id, err := utils.GenerateUUID()
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, "internal error")
+	}
+	now := s.Clock()
+	imp := &model.Impersonation{
+		ID:        id,
+		AdminID:   adminID,
+		UserID:    target.ID,
+		Reason:    truncate(reason, 255),
+		ExpiresAt: now.Add(impersonationTTL),
+		CreatedAt: now,
+	}
+	if err := s.Repo.SaveImpersonation(imp); err != nil {
+		return "", nil, status.Error(codes.Internal, "db error")
+	}
+
+	role := target.Role
+	if role == "" {
+		role = utils2.RoleUser
+	}
+	token, err := utils2.SignImpersonationToken(fmt.Sprint(target.ID), target.Username, role,
+		target.EmailVerified, imp.ID, fmt.Sprint(adminID), impersonationTTL)
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, "failed to generate token")
+	}
+
+	s.auditByAdmin(ctx, model.EventImpersonationStarted, adminID, target, truncate("session "+imp.ID+": "+imp.Reason, 255))
+	logger.Log.Infow("🎭 impersonation started", "admin_id", adminID, "account_id", target.ID, "session", imp.ID)
+	return token, imp, nil
+}
+
+// EndImpersonation — отзывает токен имперсонации раньше срока
+func (s *AuthService) EndImpersonation(ctx context.Context, adminID uint, sessionID string) error {
+	imp, err := s.Repo.FindImpersonation(sessionID)
+	if err != nil || imp.AdminID != adminID {
+		return status.Error(codes.NotFound, "impersonation not found")
+	}
+	ended, err := s.Repo.EndImpersonation(sessionID, adminID, s.Clock())
+	if err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	if ended == 0 {
+		return status.Error(codes.FailedPrecondition, "impersonation already ended")
+	}
+	s.saveSecurityEvent(ctx, &model.SecurityEvent{
+		Type:    model.EventImpersonationEnded,
+		UserID:  &imp.UserID,
+		ActorID: &adminID,
+		Details: "session " + imp.ID,
+	})
+	return nil
+}
+
+// impersonationActive — токен с act действует, пока запись не завершена и не истекла,
+// а выдавший её администратор не лишился роли
+func (s *AuthService) impersonationActive(claims *utils2.Claims, userID uint) (bool, error) {
+	imp, err := s.Repo.FindImpersonation(claims.SessionID)
+	if err != nil {
+		return false, nil
+	}
+	if imp.UserID != userID || fmt.Sprint(imp.AdminID) != claims.Actor.Subject ||
+		imp.EndedAt != nil || !s.Clock().Before(imp.ExpiresAt) {
+		return false, nil
+	}
+	admin, err := s.Repo.GetUserById(imp.AdminID)
+	if err != nil || admin.Role != utils2.RoleAdmin {
+		return false, nil
+	}
+	return true, nil
+}
+
+// RecordImpersonatedRequest — gateway пишет в журнал каждый запрос под имперсонацией
+func (s *AuthService) RecordImpersonatedRequest(ctx context.Context, req *pb.RecordImpersonatedRequestRequest) error {
+	userID, err := utils2.StringToUint(req.UserId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	actorID, err := utils2.StringToUint(req.ActorId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid actor id")
+	}
+	event := &model.SecurityEvent{
+		Type:      model.EventImpersonatedRequest,
+		UserID:    &userID,
+		ActorID:   &actorID,
+		IP:        truncate(req.Ip, 64),
+		UserAgent: truncate(req.UserAgent, 255),
+		Details:   truncate(fmt.Sprintf("%s %s %d session %s", req.Method, req.Path, req.Status, req.SessionId), 255),
+		CreatedAt: s.Clock(),
+	}
+	if err := s.Repo.AddSecurityEvent(event); err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	utils2 "socialnet/pkg/utils"
+	pb "socialnet/services/auth/gen"
+	"time"
+)
+
+// IntrospectToken — подпись проверяет и gateway, здесь — отзыв: токен выпущен до
+// "выхода везде" / смены пароля (TokensValidAfter) или его сессия (sid) завершена.
+// Неактивный токен — не ошибка, а ответ active=false.
+func (s *AuthService) IntrospectToken(raw string) (*pb.TokenIntrospection, error) {
+	inactive := &pb.TokenIntrospection{}
+	claims, err := utils2.ParseToken(raw)
+	if err != nil || claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return inactive, nil
+	}
+	userID, err := utils2.StringToUint(claims.UserID)
+	if err != nil {
+		return inactive, nil
+	}
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		// удалённый аккаунт
+		return inactive, nil
+	}
+
+	// iat с точностью до секунды: токен новой сессии, выданный в ту же секунду, что и отзыв,
+	// должен остаться действительным. Старые токены этой секунды отсекает проверка сессии.
+	if user.TokensValidAfter != nil && claims.IssuedAt.Time.Before(user.TokensValidAfter.Truncate(time.Second)) {
+		return inactive, nil
+	}
+	if claims.Actor != nil {
+		// имперсонация: sid — запись Impersonation, а не сессия пользователя
+		active, err := s.impersonationActive(claims, user.ID)
+		if err != nil || !active {
+			return inactive, err
+		}
+	} else if claims.SessionID != "" {
+		active, err := s.Repo.SessionActive(user.ID, claims.SessionID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "db error")
+		}
+		if !active {
+			return inactive, nil
+		}
+	}
+
+	return &pb.TokenIntrospection{
+		Active:    true,
+		UserId:    claims.UserID,
+		SessionId: claims.SessionID,
+		IssuedAt:  claims.IssuedAt.Unix(),
+		ExpiresAt: claims.ExpiresAt.Unix(),
+		ActorId:   actorID(claims),
+	}, nil
+}
+
+func actorID(claims *utils2.Claims) string {
+	if claims.Actor == nil {
+		return ""
+	}
+	return claims.Actor.Subject
+}
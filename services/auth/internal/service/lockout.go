@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"math"
+	"socialnet/pkg/contextx"
+	"socialnet/pkg/logger"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Защита от перебора паролей.
+// Счётчики ведутся по email (а не по id), чтобы несуществующий аккаунт
+// вёл себя так же, как существующий, и по IP клиента.
+const (
+	loginFailureWindow   = 15 * time.Minute
+	loginDelayAfter      = 3 // с этой неудачи между попытками вводится растущая пауза
+	loginMaxDelay        = 30 * time.Second
+	accountLockThreshold = 10
+	accountLockDuration  = 15 * time.Minute
+	ipLockThreshold      = 50
+	ipLockDuration       = 15 * time.Minute
+
+	// письма сброса пароля и ссылки входа, счётчики у каждого вида свои
+	mailRequestWindow      = time.Hour
+	mailRequestsPerAccount = 3
+	mailRequestsPerIP      = 20
+)
+
+func accountKey(kind, email string) string {
+	return "auth:" + kind + ":acct:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(kind, ip string) string {
+	return "auth:" + kind + ":ip:" + ip
+}
+
+// checkLoginAllowed — вызывается до проверки пароля: при блокировке пароль даже не сравниваем
+func (s *AuthService) checkLoginAllowed(ctx context.Context, email string) error {
+	keys := []string{accountKey("login-lock", email), accountKey("login-delay", email)}
+	if ip := contextx.GetClientIP(ctx); ip != "" {
+		keys = append(keys, ipKey("login-lock", ip))
+	}
+
+	for _, key := range keys {
+		wait, err := s.Attempts.BlockedFor(ctx, key)
+		if err != nil {
+			// недоступность хранилища не должна блокировать вход всем пользователям
+			log.Printf("❌ attempts store error: %v", err)
+			return nil
+		}
+		if wait > 0 {
+			return tooManyAttempts(wait)
+		}
+	}
+	return nil
+}
+
+// registerLoginFailure — user == nil, если аккаунта с таким email нет
+func (s *AuthService) registerLoginFailure(ctx context.Context, email string, user *model.User) {
+	failures, err := s.Attempts.Incr(ctx, accountKey("login-fail", email), loginFailureWindow)
+	if err != nil {
+		log.Printf("❌ attempts store error: %v", err)
+		return
+	}
+
+	switch {
+	case failures >= accountLockThreshold:
+		if err := s.Attempts.Block(ctx, accountKey("login-lock", email), accountLockDuration); err != nil {
+			log.Printf("❌ attempts store error: %v", err)
+		}
+		if user != nil && failures == accountLockThreshold {
+			logger.Log.Warnw("🔒 account locked after failed logins",
+				"account_id", user.ID,
+				"failures", failures,
+				"ip", contextx.GetClientIP(ctx),
+				"until", s.Clock().Add(accountLockDuration).Format(time.RFC3339),
+			)
+		}
+	case failures >= loginDelayAfter:
+		if err := s.Attempts.Block(ctx, accountKey("login-delay", email), loginDelay(failures)); err != nil {
+			log.Printf("❌ attempts store error: %v", err)
+		}
+	}
+
+	ip := contextx.GetClientIP(ctx)
+	if ip == "" {
+		return
+	}
+	ipFailures, err := s.Attempts.Incr(ctx, ipKey("login-fail", ip), loginFailureWindow)
+	if err != nil {
+		log.Printf("❌ attempts store error: %v", err)
+		return
+	}
+	if ipFailures >= ipLockThreshold {
+		if err := s.Attempts.Block(ctx, ipKey("login-lock", ip), ipLockDuration); err != nil {
+			log.Printf("❌ attempts store error: %v", err)
+		}
+		if ipFailures == ipLockThreshold {
+			logger.Log.Warnw("🔒 ip blocked after failed logins", "ip", ip, "failures", ipFailures)
+		}
+	}
+}
+
+// resetLoginFailures — успешный вход (или сброс пароля) обнуляет счётчики аккаунта, но не IP
+func (s *AuthService) resetLoginFailures(ctx context.Context, email string) {
+	if err := s.Attempts.Delete(ctx,
+		accountKey("login-fail", email),
+		accountKey("login-delay", email),
+		accountKey("login-lock", email),
+	); err != nil {
+		log.Printf("❌ attempts store error: %v", err)
+	}
+}
+
+// allowMailRequest — лимит писем по запросу без входа (kind: reset, magic).
+// Превышение по IP возвращает ошибку, превышение по аккаунту молча пропускает отправку,
+// чтобы по ответу нельзя было понять, зарегистрирован ли email.
+func (s *AuthService) allowMailRequest(ctx context.Context, kind, email string) (bool, error) {
+	if ip := contextx.GetClientIP(ctx); ip != "" {
+		n, err := s.Attempts.Incr(ctx, ipKey(kind, ip), mailRequestWindow)
+		if err != nil {
+			log.Printf("❌ attempts store error: %v", err)
+		} else if n > mailRequestsPerIP {
+			return false, tooManyAttempts(mailRequestWindow)
+		}
+	}
+
+	n, err := s.Attempts.Incr(ctx, accountKey(kind, email), mailRequestWindow)
+	if err != nil {
+		log.Printf("❌ attempts store error: %v", err)
+		return true, nil
+	}
+	return n <= mailRequestsPerAccount, nil
+}
+
+// UnlockAccount — снятие блокировки администратором
+func (s *AuthService) UnlockAccount(ctx context.Context, adminID uint, userID string) error {
+	if err := s.requireAdmin(adminID); err != nil {
+		return err
+	}
+	uid, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	user, err := s.Repo.GetUserById(uint(uid))
+	if err != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+
+	if err := s.Attempts.Delete(ctx,
+		accountKey("login-fail", user.Email),
+		accountKey("login-delay", user.Email),
+		accountKey("login-lock", user.Email),
+	); err != nil {
+		return status.Error(codes.Internal, "failed to unlock account")
+	}
+
+	logger.Log.Infow("🔓 account unlocked", "account_id", user.ID, "admin_id", adminID)
+	s.auditByAdmin(ctx, model.EventAccountUnlocked, adminID, user, "")
+	return nil
+}
+
+// loginDelay — 1s, 2s, 4s ... но не больше loginMaxDelay
+func loginDelay(failures int64) time.Duration {
+	exp := float64(failures - loginDelayAfter)
+	delay := time.Duration(math.Pow(2, exp)) * time.Second
+	if delay > loginMaxDelay || delay <= 0 {
+		return loginMaxDelay
+	}
+	return delay
+}
+
+func tooManyAttempts(wait time.Duration) error {
+	seconds := int(math.Ceil(wait.Seconds()))
+	return status.Error(codes.ResourceExhausted, fmt.Sprintf("too many attempts, retry in %ds", seconds))
+}
+
+// dummyPasswordHash — сравнение с ним для несуществующего email,
+// чтобы время ответа не выдавало, зарегистрирован ли адрес
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := utils.PasswordHashing("dummy-password-for-timing")
+	return hash
+})
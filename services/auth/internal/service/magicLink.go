@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	utils2 "socialnet/pkg/utils"
+	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/mailer"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+	"strings"
+	"time"
+)
+
+const magicLinkTTL = 15 * time.Minute
+
+// RequestMagicLink — письмо с одноразовой ссылкой входа.
+// Как и ForgotPassword, ответ не зависит от того, есть ли аккаунт и ушло ли письмо.
+// Аккаунтам с 2FA ссылка не отправляется: она обходила бы второй фактор.
+func (s *AuthService) RequestMagicLink(ctx context.Context, req *pb.RequestMagicLinkRequest) error {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if !emailRe.MatchString(email) {
+		return status.Error(codes.InvalidArgument, "invalid mail")
+	}
+	allowed, err := s.allowMailRequest(ctx, "magic", email)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return nil
+	}
+
+	user, err := s.Repo.GetUserByEmail(email)
+	if err != nil || user.Bot {
+		return nil
+	}
+	if user.TwoFactorEnabled {
+		log.Printf("⚠️ magic link skipped for user %d: two-factor enabled", user.ID)
+		return nil
+	}
+
+	token, err := utils2.GenerateRefreshToken()
+	if err != nil {
+		return status.Error(codes.Internal, "failed to generate token")
+	}
+	now := s.Clock()
+	if err := s.Repo.SaveMagicLink(&model.MagicLink{
+		UserID:    user.ID,
+		Token:     utils.HashToken(token),
+		ExpiresAt: now.Add(magicLinkTTL),
+		CreatedAt: now,
+	}); err != nil {
+		return status.Error(codes.Internal, "failed to save magic link")
+	}
+
+	if err := s.enqueueMail(user, user.Email, mailer.TemplateMagicLink, mailData{
+		Link:    s.appLink("/magic-link", token),
+		Minutes: int(magicLinkTTL.Minutes()),
+	}); err != nil {
+		log.Printf("❌ magic link for user %d not queued: %v", user.ID, err)
+	}
+	s.audit(ctx, model.EventMagicLinkRequested, user, "")
+	return nil
+}
+
+// ConsumeMagicLink — обмен ссылки из письма на сессию, как после Login
+func (s *AuthService) ConsumeMagicLink(ctx context.Context, req *pb.ConsumeMagicLinkRequest) (*pb.LoginResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token required")
+	}
+	link, err := s.Repo.FindMagicLink(utils.HashToken(req.Token))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired link")
+	}
+	if !s.Clock().Before(link.ExpiresAt) {
+		_, _ = s.Repo.UseMagicLink(link.ID)
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired link")
+	}
+
+	used, err := s.Repo.UseMagicLink(link.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if used == 0 {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired link")
+	}
+
+	user, err := s.Repo.GetUserById(link.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired link")
+	}
+	// 2FA могли включить уже после отправки письма
+	if user.TwoFactorEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "magic link login is disabled with two-factor authentication")
+	}
+	if s.deletionInProgress(user.ID) {
+		return nil, status.Error(codes.FailedPrecondition, "account is being deleted")
+	}
+
+	if err := s.Repo.DeleteMagicLinks(user.ID); err != nil {
+		log.Printf("❌ failed to delete magic links of user %d: %v", user.ID, err)
+	}
+	// переход по ссылке подтверждает владение почтой
+	if !user.EmailVerified {
+		if err := s.Repo.MarkEmailVerified(user.ID); err != nil {
+			return nil, status.Error(codes.Internal, "db error")
+		}
+		user.EmailVerified = true
+	}
+	s.resetLoginFailures(ctx, user.Email)
+
+	access, refresh, err := s.startSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, model.EventLoginSucceeded, user, "magic_link")
+	return &pb.LoginResponse{AccessToken: access, RefreshToken: refresh}, nil
+}
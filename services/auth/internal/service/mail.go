@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"socialnet/services/auth/internal/mailer"
+	"socialnet/services/auth/internal/model"
+	"time"
+)
+
+const (
+	mailBatchSize   = 50
+	mailMaxAttempts = 8
+	mailRetryBase   = 30 * time.Second
+	mailMaxBackoff  = time.Hour
+	mailStaleAfter  = 5 * time.Minute    // sending дольше — отправка прервалась
+	mailRetention   = 7 * 24 * time.Hour // столько отправленные и брошенные письма хранятся для разбора жалоб
+)
+
+// mailData — общие поля шаблонов
+type mailData struct {
+	Username string
+	Link     string
+	NewEmail string
+	Hours    int
+	Minutes  int
+}
+
+// enqueueMail — письмо рендерится сразу (на языке пользователя) и ставится в outbox.
+// to отличается от user.Email только для писем на новый адрес при его смене.
+func (s *AuthService) enqueueMail(user *model.User, to, template string, data mailData) error {
+	if data.Username == "" {
+		data.Username = user.Username
+	}
+	msg, err := s.Templates.Render(user.Locale, template, data)
+	if err != nil {
+		return err
+	}
+	now := s.Clock()
+	return s.Repo.EnqueueEmail(&model.OutboxEmail{
+		UserID:        user.ID,
+		Template:      template,
+		To:            to,
+		Subject:       msg.Subject,
+		TextBody:      msg.Text,
+		HTMLBody:      msg.HTML,
+		Status:        model.MailPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+// appLink — ссылка на страницу фронтенда (APP_BASE_URL) с токеном
+func (s *AuthService) appLink(path, token string) string {
+	return s.AppBaseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// RunPendingMail — один проход обработчика outbox, возвращает число отправленных писем
+func (s *AuthService) RunPendingMail(ctx context.Context) (int, error) {
+	now := s.Clock()
+	emails, err := s.Repo.ClaimDueEmails(now, now.Add(-mailStaleAfter), mailBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, email := range emails {
+		err := s.Mailer.Send(ctx, mailer.Message{
+			To:      email.To,
+			Subject: email.Subject,
+			Text:    email.TextBody,
+			HTML:    email.HTMLBody,
+		})
+		now := s.Clock()
+		if err == nil {
+			if err := s.Repo.MarkEmailSent(email.ID, now); err != nil {
+				log.Printf("❌ failed to mark email %d sent: %v", email.ID, err)
+			}
+			sent++
+			continue
+		}
+
+		attempts := email.Attempts + 1
+		if attempts >= mailMaxAttempts {
+			log.Printf("❌ email %d (%s) dropped after %d attempts: %v", email.ID, email.Template, attempts, err)
+			if ferr := s.Repo.FailEmail(email.ID, attempts, err.Error(), now); ferr != nil {
+				log.Printf("❌ failed to record email error: %v", ferr)
+			}
+			continue
+		}
+		log.Printf("⚠️ email %d (%s) not sent, attempt %d: %v", email.ID, email.Template, attempts, err)
+		if ferr := s.Repo.RetryEmail(email.ID, attempts, err.Error(), now.Add(mailBackoff(attempts)), now); ferr != nil {
+			log.Printf("❌ failed to record email error: %v", ferr)
+		}
+	}
+	return sent, nil
+}
+
+// StartMailWorker — фоновая отправка писем из outbox до отмены ctx
+func (s *AuthService) StartMailWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RunPendingMail(ctx); err != nil {
+				log.Printf("❌ mail worker: %v", err)
+			}
+			if _, err := s.Repo.PruneEmails(s.Clock().Add(-mailRetention)); err != nil {
+				log.Printf("❌ mail worker: prune outbox: %v", err)
+			}
+		}
+	}
+}
+
+// mailBackoff — 30s, 1m, 2m ... но не больше mailMaxBackoff
+func mailBackoff(attempts int) time.Duration {
+	delay := mailRetryBase << (attempts - 1)
+	if delay > mailMaxBackoff || delay <= 0 {
+		return mailMaxBackoff
+	}
+	return delay
+}
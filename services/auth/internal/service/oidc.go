@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"log"
+	"regexp"
+	utils2 "socialnet/pkg/utils"
+	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/oidc"
+	"socialnet/services/auth/internal/utils"
+	"sort"
+	"strings"
+	"time"
+)
+
+// oidcStateTTL — сколько пользователь может провести на странице провайдера
+const oidcStateTTL = 10 * time.Minute
+
+var usernameUnsafeRe = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// OIDCResult — итог callback: сессия (или 2FA challenge) либо привязка к уже вошедшему пользователю
+type OIDCResult struct {
+	Login      *pb.LoginResponse
+	Linked     bool
+	NewAccount bool
+}
+
+func (s *AuthService) OIDCProviderNames() []string {
+	names := make([]string, 0, len(s.OIDCProviders))
+	for name := range s.OIDCProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StartOIDC — ссылка на вход у провайдера. linkUserID != 0 — привязка провайдера к своему аккаунту.
+// В state хранятся code_verifier (PKCE) и nonce: callback примет только свой ответ провайдера.
+func (s *AuthService) StartOIDC(ctx context.Context, providerName string, linkUserID uint) (string, error) {
+	provider, err := s.oidcProvider(providerName)
+	if err != nil {
+		return "", err
+	}
+	if linkUserID != 0 {
+		user, err := s.Repo.GetUserById(linkUserID)
+		if err != nil {
+			return "", status.Error(codes.NotFound, "user not found")
+		}
+		if user.Bot {
+			return "", status.Error(codes.PermissionDenied, "bots cannot link external accounts")
+		}
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return "", status.Error(codes.Internal, "internal error")
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", status.Error(codes.Internal, "internal error")
+	}
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		return "", status.Error(codes.Internal, "internal error")
+	}
+
+	now := s.Clock()
+	if err := s.Repo.DeleteExpiredOIDCStates(now); err != nil {
+		log.Printf("⚠️ failed to delete expired oidc states: %v", err)
+	}
+	record := &model.OIDCState{
+		State:        utils.HashToken(state),
+		Provider:     provider.Name,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    now.Add(oidcStateTTL),
+		CreatedAt:    now,
+	}
+	if linkUserID != 0 {
+		record.UserID = &linkUserID
+	}
+	if err := s.Repo.SaveOIDCState(record); err != nil {
+		return "", status.Error(codes.Internal, "db error")
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		log.Printf("❌ oidc provider %s unavailable: %v", provider.Name, err)
+		return "", status.Error(codes.Unavailable, "identity provider unavailable")
+	}
+	return authURL, nil
+}
+
+// CompleteOIDC — callback провайдера: обмен code на ID токен, проверка подписи и nonce,
+// затем вход по привязке, привязка по подтверждённому email или новый аккаунт
+func (s *AuthService) CompleteOIDC(ctx context.Context, providerName, state, code string) (*OIDCResult, error) {
+	provider, err := s.oidcProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	if state == "" || code == "" {
+		return nil, status.Error(codes.InvalidArgument, "state and code required")
+	}
+
+	pending, err := s.Repo.TakeOIDCState(utils.HashToken(state))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired state")
+		}
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if pending.Provider != provider.Name || !s.Clock().Before(pending.ExpiresAt) {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired state")
+	}
+
+	rawIDToken, err := provider.Exchange(ctx, code, pending.CodeVerifier)
+	if err != nil {
+		log.Printf("❌ oidc %s code exchange failed: %v", provider.Name, err)
+		return nil, status.Error(codes.Unauthenticated, "authorization code rejected by identity provider")
+	}
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, pending.Nonce, s.Clock())
+	if err != nil {
+		log.Printf("❌ oidc %s id token rejected: %v", provider.Name, err)
+		return nil, status.Error(codes.Unauthenticated, "invalid id token")
+	}
+
+	if pending.UserID != nil {
+		if err := s.linkIdentity(ctx, provider, *pending.UserID, claims); err != nil {
+			return nil, err
+		}
+		return &OIDCResult{Linked: true}, nil
+	}
+
+	user, created, err := s.resolveOIDCUser(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+	if user.Bot {
+		return nil, status.Error(codes.PermissionDenied, "bots cannot sign in")
+	}
+	if s.deletionInProgress(user.ID) {
+		return nil, status.Error(codes.FailedPrecondition, "account is being deleted")
+	}
+	result := &OIDCResult{NewAccount: created}
+
+	// внешний провайдер заменяет только пароль, второй фактор остаётся обязательным
+	if user.TwoFactorEnabled {
+		challenge, err := s.startTwoFactorChallenge(user)
+		if err != nil {
+			return nil, err
+		}
+		result.Login = &pb.LoginResponse{TwoFactorRequired: true, ChallengeToken: challenge}
+		return result, nil
+	}
+
+	access, refresh, err := s.startSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, model.EventLoginSucceeded, user, "oidc:"+provider.Name)
+	result.Login = &pb.LoginResponse{AccessToken: access, RefreshToken: refresh}
+	return result, nil
+}
+
+// resolveOIDCUser — пользователь по привязке (provider, sub). Без привязки существующий аккаунт
+// с тем же email привязывается автоматически, только если провайдеру доверяем (TrustEmail),
+// он подтвердил адрес и адрес подтверждён у нас — иначе так можно было бы захватить чужой аккаунт.
+func (s *AuthService) resolveOIDCUser(ctx context.Context, provider *oidc.Provider, claims *utils2.IDTokenClaims) (*model.User, bool, error) {
+	now := s.Clock()
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	identity, err := s.Repo.FindExternalIdentity(provider.Name, claims.Subject)
+	if err == nil {
+		user, err := s.Repo.GetUserById(identity.UserID)
+		if err != nil {
+			return nil, false, status.Error(codes.NotFound, "user not found")
+		}
+		if err := s.Repo.TouchExternalIdentity(identity.ID, email, now); err != nil {
+			log.Printf("⚠️ failed to update external identity %d: %v", identity.ID, err)
+		}
+		return user, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, status.Error(codes.Internal, "db error")
+	}
+
+	if !emailRe.MatchString(email) {
+		return nil, false, status.Error(codes.FailedPrecondition, "identity provider did not share a valid email")
+	}
+	trusted := provider.TrustEmail && bool(claims.EmailVerified)
+
+	existing, err := s.Repo.GetUserByEmail(email)
+	if err == nil {
+		if !trusted || !existing.EmailVerified || existing.Bot {
+			return nil, false, status.Error(codes.FailedPrecondition,
+				"an account with this email already exists, sign in and link the provider in settings")
+		}
+		if err := s.saveIdentity(ctx, provider, existing, claims.Subject, email); err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, status.Error(codes.Internal, "db error")
+	}
+
+	username, err := s.oidcUsername(claims, email)
+	if err != nil {
+		return nil, false, err
+	}
+	// пароля нет: войти можно через провайдера, magic link или задать пароль через сброс
+	user := &model.User{
+		Email:         email,
+		Username:      username,
+		EmailVerified: trusted,
+		Role:          utils2.RoleUser,
+	}
+	identity = &model.ExternalIdentity{
+		Provider:    provider.Name,
+		Subject:     claims.Subject,
+		Email:       email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+	if err := s.Repo.RegisterExternalUser(user, identity); err != nil {
+		return nil, false, status.Error(codes.Internal, "db error")
+	}
+	if !user.EmailVerified {
+		if err := s.sendVerification(user); err != nil {
+			log.Printf("⚠️ verification email for user %d not queued: %v", user.ID, err)
+		}
+	}
+	s.audit(ctx, model.EventRegistered, user, "oidc:"+provider.Name)
+	s.syncHandle(ctx, user)
+	return user, true, nil
+}
+
+// linkIdentity — привязка из настроек уже вошедшего пользователя
+func (s *AuthService) linkIdentity(ctx context.Context, provider *oidc.Provider, userID uint, claims *utils2.IDTokenClaims) error {
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	identity, err := s.Repo.FindExternalIdentity(provider.Name, claims.Subject)
+	if err == nil {
+		if identity.UserID != user.ID {
+			return status.Error(codes.AlreadyExists, "this external account is linked to another user")
+		}
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.Internal, "db error")
+	}
+	return s.saveIdentity(ctx, provider, user, claims.Subject, strings.ToLower(strings.TrimSpace(claims.Email)))
+}
+
+func (s *AuthService) saveIdentity(ctx context.Context, provider *oidc.Provider, user *model.User, subject, email string) error {
+	linked, err := s.Repo.ListExternalIdentities(user.ID)
+	if err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	for _, identity := range linked {
+		if identity.Provider == provider.Name {
+			return status.Error(codes.AlreadyExists, "another account of this provider is already linked")
+		}
+	}
+
+	now := s.Clock()
+	if err := s.Repo.SaveExternalIdentity(&model.ExternalIdentity{
+		UserID:      user.ID,
+		Provider:    provider.Name,
+		Subject:     subject,
+		Email:       email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}); err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	s.audit(ctx, model.EventIdentityLinked, user, provider.Name)
+	return nil
+}
+
+func (s *AuthService) ListExternalIdentities(userID uint) ([]model.ExternalIdentity, error) {
+	identities, err := s.Repo.ListExternalIdentities(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	return identities, nil
+}
+
+// UnlinkExternalIdentity — последний способ входа у аккаунта без пароля отвязать нельзя
+func (s *AuthService) UnlinkExternalIdentity(ctx context.Context, userID uint, providerName string) error {
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	identities, err := s.Repo.ListExternalIdentities(userID)
+	if err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	if user.Password == "" && len(identities) <= 1 {
+		return status.Error(codes.FailedPrecondition, "set a password before unlinking the last sign-in method")
+	}
+
+	deleted, err := s.Repo.DeleteExternalIdentity(userID, providerName)
+	if err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	if deleted == 0 {
+		return status.Error(codes.NotFound, "provider is not linked")
+	}
+	s.audit(ctx, model.EventIdentityUnlinked, user, providerName)
+	return nil
+}
+
+func (s *AuthService) oidcProvider(name string) (*oidc.Provider, error) {
+	provider, ok := s.OIDCProviders[strings.ToLower(name)]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown identity provider")
+	}
+	return provider, nil
+}
+
+// oidcUsername — preferred_username, имя или начало email, приведённые к правилам username;
+// занятое имя получает случайный суффикс
+func (s *AuthService) oidcUsername(claims *utils2.IDTokenClaims, email string) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base = claims.Name
+	}
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+	base = strings.Trim(usernameUnsafeRe.ReplaceAllString(base, "_"), "_")
+	if len(base) > 24 {
+		base = base[:24]
+	}
+	for len(base) < 3 {
+		base += "_user"
+	}
+
+	candidate := base
+	for attempt := 0; attempt < 5; attempt++ {
+		err := s.checkUsernameAvailable(candidate, 0)
+		if err == nil {
+			return candidate, nil
+		}
+		if status.Code(err) == codes.Internal {
+			return "", err
+		}
+		suffix, err := utils.GenerateUUID()
+		if err != nil {
+			return "", status.Error(codes.Internal, "internal error")
+		}
+		candidate = base + "_" + strings.ReplaceAll(suffix, "-", "")[:6]
+	}
+	return "", status.Error(codes.Internal, "failed to pick a username")
+}
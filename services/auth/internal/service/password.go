@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+)
+
+// checkNewPassword — политика паролей; user == nil при регистрации (истории ещё нет)
+func (s *AuthService) checkNewPassword(user *model.User, password string) error {
+	if err := s.PasswordPolicy.Check(password); err != nil {
+		switch {
+		case errors.Is(err, utils.ErrPasswordTooShort):
+			return status.Error(codes.InvalidArgument,
+				fmt.Sprintf("password must be at least %d characters", s.PasswordPolicy.MinLength))
+		case errors.Is(err, utils.ErrPasswordTooLong):
+			return status.Error(codes.InvalidArgument,
+				fmt.Sprintf("password must be at most %d characters", s.PasswordPolicy.MaxLength))
+		}
+		return status.Error(codes.InvalidArgument, "password is too common")
+	}
+	if user == nil || s.PasswordPolicy.HistorySize <= 0 {
+		return nil
+	}
+
+	// текущий пароль + HistorySize-1 прежних; каждая проверка — полный argon2
+	previous := []string{user.Password}
+	if s.PasswordPolicy.HistorySize > 1 {
+		hashes, err := s.Repo.RecentPasswordHashes(user.ID, s.PasswordPolicy.HistorySize-1)
+		if err != nil {
+			return status.Error(codes.Internal, "db error")
+		}
+		previous = append(previous, hashes...)
+	}
+	for _, hash := range previous {
+		if utils.VerifyPassword(hash, password) == nil {
+			return status.Error(codes.InvalidArgument,
+				fmt.Sprintf("password must differ from the last %d passwords", s.PasswordPolicy.HistorySize))
+		}
+	}
+	return nil
+}
+
+// setPassword — смена пароля с записью прежнего в историю
+func (s *AuthService) setPassword(user *model.User, password string) error {
+	hashed, err := utils.PasswordHashing(password)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to hash password")
+	}
+	if err := s.Repo.ChangePassword(user.ID, hashed, user.Password, s.PasswordPolicy.HistorySize-1); err != nil {
+		return status.Error(codes.Internal, "failed to update password")
+	}
+	user.Password = hashed
+	return nil
+}
+
+// upgradePasswordHash — после успешного входа пересчитываем хэш с актуальными параметрами argon2.
+// Это не смена пароля: история не меняется, ошибка вход не ломает.
+func (s *AuthService) upgradePasswordHash(user *model.User, password string) {
+	if !utils.NeedsRehash(user.Password) {
+		return
+	}
+	hashed, err := utils.PasswordHashing(password)
+	if err != nil {
+		log.Printf("❌ password rehash for user %d failed: %v", user.ID, err)
+		return
+	}
+	if err := s.Repo.UpdatePassword(user.ID, hashed); err != nil {
+		log.Printf("❌ password rehash for user %d failed: %v", user.ID, err)
+		return
+	}
+	user.Password = hashed
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"socialnet/pkg/logger"
+	utils2 "socialnet/pkg/utils"
+	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	patDefaultTTL     = 90 * 24 * time.Hour
+	patMaxTTL         = 365 * 24 * time.Hour
+	patMaxPerAccount  = 20
+	patPrefixLen      = 8 // символов после PersonalTokenPrefix, видны в списке токенов
+	maxBotsPerOwner   = 10
+	botEmailDomain    = "bots.invalid" // у ботов нет почты, адрес только для уникальности
+	patNameMaxLength  = 64
+	patTouchThreshold = time.Minute
+)
+
+// CreatePersonalAccessToken — токен для себя или своего бота (bot_id).
+// Возвращается открытый токен: повторно получить его нельзя.
+func (s *AuthService) CreatePersonalAccessToken(ctx context.Context, userID uint, req *pb.CreatePersonalAccessTokenRequest) (string, *model.PersonalAccessToken, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > patNameMaxLength {
+		return "", nil, status.Error(codes.InvalidArgument, "token name required (up to 64 characters)")
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	ttl := patDefaultTTL
+	if req.ExpiresInDays < 0 {
+		return "", nil, status.Error(codes.InvalidArgument, "invalid expiration")
+	}
+	if req.ExpiresInDays > 0 {
+		ttl = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+		if ttl > patMaxTTL {
+			return "", nil, status.Error(codes.InvalidArgument, "expiration is limited to 365 days")
+		}
+	}
+
+	account, err := s.tokenAccount(userID, req.BotId)
+	if err != nil {
+		return "", nil, err
+	}
+	now := s.Clock()
+	count, err := s.Repo.CountPersonalAccessTokens(account.ID, now)
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, "db error")
+	}
+	if count >= patMaxPerAccount {
+		return "", nil, status.Error(codes.ResourceExhausted, "too many active tokens, revoke unused ones")
+	}
+
+	raw, err := generatePersonalToken()
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, "internal error")
+	}
+	token := &model.PersonalAccessToken{
+		UserID:    account.ID,
+		Name:      name,
+		TokenHash: utils.HashToken(raw),
+		Prefix:    raw[:len(utils2.PersonalTokenPrefix)+patPrefixLen],
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := s.Repo.CreatePersonalAccessToken(token); err != nil {
+		return "", nil, status.Error(codes.Internal, "db error")
+	}
+
+	logger.Log.Infow("🔑 personal access token created",
+		"account_id", account.ID,
+		"token_id", token.ID,
+		"created_by", userID,
+		"scopes", token.Scopes,
+	)
+	return raw, token, nil
+}
+
+func (s *AuthService) ListPersonalAccessTokens(userID uint, botID string) ([]model.PersonalAccessToken, error) {
+	account, err := s.tokenAccount(userID, botID)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.Repo.ListPersonalAccessTokens(account.ID, s.Clock())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	return tokens, nil
+}
+
+// RevokePersonalAccessToken — свои токены и токены своих ботов.
+// Gateway кэширует проверку токена, поэтому отзыв вступает в силу в пределах TTL кэша.
+func (s *AuthService) RevokePersonalAccessToken(userID uint, tokenID string) error {
+	id, err := strconv.ParseUint(tokenID, 10, 64)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid token id")
+	}
+	token, err := s.Repo.GetPersonalAccessToken(uint(id))
+	if err != nil {
+		return status.Error(codes.NotFound, "token not found")
+	}
+	if token.UserID != userID {
+		if _, err := s.tokenAccount(userID, fmt.Sprint(token.UserID)); err != nil {
+			return status.Error(codes.NotFound, "token not found")
+		}
+	}
+
+	if _, err := s.Repo.RevokePersonalAccessToken(token.ID, s.Clock()); err != nil {
+		return status.Error(codes.Internal, "failed to revoke token")
+	}
+	logger.Log.Infow("🔑 personal access token revoked", "account_id", token.UserID, "token_id", token.ID, "revoked_by", userID)
+	return nil
+}
+
+// ValidatePersonalAccessToken — проверка токена для gateway: аккаунт и выданные права
+func (s *AuthService) ValidatePersonalAccessToken(ctx context.Context, raw string) (*model.User, []string, error) {
+	if !strings.HasPrefix(raw, utils2.PersonalTokenPrefix) {
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	token, err := s.Repo.FindPersonalAccessToken(utils.HashToken(raw))
+	if err != nil {
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	now := s.Clock()
+	if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+		return nil, nil, status.Error(codes.Unauthenticated, "token expired or revoked")
+	}
+	user, err := s.Repo.GetUserById(token.UserID)
+	if err != nil || s.deletionInProgress(user.ID) {
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= patTouchThreshold {
+		if err := s.Repo.TouchPersonalAccessToken(token.ID, now); err != nil {
+			log.Printf("⚠️ failed to update token %d last use: %v", token.ID, err)
+		}
+	}
+	return user, strings.Fields(token.Scopes), nil
+}
+
+// CreateBot — бот не может войти по паролю, только по personal access токенам,
+// которые выпускает его владелец
+func (s *AuthService) CreateBot(ctx context.Context, ownerID uint, username string) (*model.User, error) {
+	owner, err := s.Repo.GetUserById(ownerID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if owner.Bot {
+		return nil, status.Error(codes.PermissionDenied, "bots cannot create bots")
+	}
+	if !owner.EmailVerified {
+		return nil, status.Error(codes.FailedPrecondition, "email is not verified")
+	}
+	if !usernameRe.MatchString(username) {
+		return nil, status.Error(codes.InvalidArgument, "username must be 3-32 letters, digits or underscores")
+	}
+
+	count, err := s.Repo.CountBots(ownerID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if count >= maxBotsPerOwner {
+		return nil, status.Error(codes.ResourceExhausted, "bot limit reached")
+	}
+	if err := s.checkUsernameAvailable(username, 0); err != nil {
+		return nil, err
+	}
+
+	uid, err := utils.GenerateUUID()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	bot := &model.User{
+		Email:         "bot+" + uid + "@" + botEmailDomain,
+		Username:      username,
+		EmailVerified: true, // иначе RequireVerifiedEmail закроет боту публикацию
+		Role:          utils2.RoleUser,
+		Bot:           true,
+		OwnerID:       &ownerID,
+	}
+	if _, err := s.Repo.RegisterDB(bot); err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	s.syncHandle(ctx, bot)
+	logger.Log.Infow("🤖 bot account created", "account_id", bot.ID, "owner_id", ownerID)
+	return bot, nil
+}
+
+func (s *AuthService) ListBots(ownerID uint) ([]model.User, error) {
+	bots, err := s.Repo.ListBots(ownerID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	return bots, nil
+}
+
+// tokenAccount — аккаунт, которому принадлежат токены: сам пользователь или его бот.
+// Чужой бот неотличим от несуществующего.
+func (s *AuthService) tokenAccount(userID uint, botID string) (*model.User, error) {
+	if botID == "" {
+		user, err := s.Repo.GetUserById(userID)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return user, nil
+	}
+	id, err := strconv.ParseUint(botID, 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid bot id")
+	}
+	bot, err := s.Repo.GetUserById(uint(id))
+	if err != nil || !bot.Bot || bot.OwnerID == nil || *bot.OwnerID != userID {
+		return nil, status.Error(codes.NotFound, "bot not found")
+	}
+	return bot, nil
+}
+
+func normalizeScopes(requested []string) ([]string, error) {
+	seen := map[string]bool{}
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !utils2.ValidScope(scope) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown scope %q", scope))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one scope required")
+	}
+	return scopes, nil
+}
+
+func generatePersonalToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return utils2.PersonalTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"os"
+	"socialnet/pkg/logger"
+	"socialnet/pkg/utils"
+	"socialnet/services/auth/internal/model"
+	"strconv"
+	"strings"
+)
+
+// SetUserRole — назначение роли администратором.
+// В токене роль обновится при следующем RefreshToken (не позже JWT_EXPIRES_IN).
+func (s *AuthService) SetUserRole(ctx context.Context, adminID uint, userID, role string) error {
+	if err := s.requireAdmin(adminID); err != nil {
+		return err
+	}
+	if !utils.ValidRole(role) {
+		return status.Error(codes.InvalidArgument, "unknown role")
+	}
+	uid, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	// иначе последний администратор может случайно остаться без доступа
+	if uint(uid) == adminID && role != utils.RoleAdmin {
+		return status.Error(codes.FailedPrecondition, "cannot change your own role")
+	}
+
+	user, err := s.Repo.GetUserById(uint(uid))
+	if err != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	if user.Role == role {
+		return nil
+	}
+	if err := s.Repo.SetUserRole(user.ID, role); err != nil {
+		return status.Error(codes.Internal, "failed to update role")
+	}
+
+	logger.Log.Infow("🛡️ user role changed",
+		"account_id", user.ID,
+		"from", user.Role,
+		"to", role,
+		"admin_id", adminID,
+	)
+	s.auditByAdmin(ctx, model.EventRoleChanged, adminID, user, user.Role+" -> "+role)
+	return nil
+}
+
+// requireAdmin — роль проверяется по БД, а не только по токену:
+// снятый администратор теряет доступ сразу, не дожидаясь истечения access токена
+func (s *AuthService) requireAdmin(adminID uint) error {
+	admin, err := s.Repo.GetUserById(adminID)
+	if err != nil || admin.Role != utils.RoleAdmin {
+		return status.Error(codes.PermissionDenied, "admin only")
+	}
+	return nil
+}
+
+// BootstrapAdmins — id из AUTH_ADMIN_IDS (через запятую) получают роль admin при старте,
+// дальше роли раздаются через SetUserRole
+func (s *AuthService) BootstrapAdmins() error {
+	var ids []uint
+	for _, raw := range strings.Split(os.Getenv("AUTH_ADMIN_IDS"), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			log.Printf("⚠️ AUTH_ADMIN_IDS: invalid id %q", raw)
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+
+	granted, err := s.Repo.GrantRole(ids, utils.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if granted > 0 {
+		logger.Log.Infow("🛡️ admin role granted from AUTH_ADMIN_IDS", "accounts", granted)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	pb "socialnet/services/auth/gen"
+	"socialnet/services/auth/internal/model"
+	"socialnet/services/auth/internal/utils"
+	"time"
+)
+
+const (
+	totpIssuer            = "Socialnet"
+	recoveryCodesCount    = 10
+	twoFactorChallengeTTL = 5 * time.Minute
+	twoFactorMaxAttempts  = 5
+)
+
+// EnrollTwoFactor — первый шаг включения 2FA: генерируем секрет и otpauth ссылку.
+// 2FA начнёт действовать только после ConfirmTwoFactor с кодом из приложения.
+func (s *AuthService) EnrollTwoFactor(userID uint) (string, string, error) {
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return "", "", status.Error(codes.NotFound, "user not found")
+	}
+	if user.TwoFactorEnabled {
+		return "", "", status.Error(codes.FailedPrecondition, "two-factor already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "internal error")
+	}
+	if err := s.Repo.SaveTwoFactorSecret(user.ID, secret); err != nil {
+		return "", "", status.Error(codes.Internal, "db error")
+	}
+
+	return secret, utils.TOTPURI(totpIssuer, user.Email, secret), nil
+}
+
+// ConfirmTwoFactor — включает 2FA и возвращает коды восстановления (показываются один раз)
+func (s *AuthService) ConfirmTwoFactor(ctx context.Context, userID uint, code string) ([]string, error) {
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if user.TwoFactorEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor already enabled")
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor enrolment not started")
+	}
+
+	step, ok := utils.ValidateTOTP(user.TwoFactorSecret, code, s.Clock(), 0)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if err := s.Repo.EnableTwoFactor(user.ID, step, hashes); err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	s.audit(ctx, model.EventTwoFactorEnabled, user, "")
+	return recoveryCodes, nil
+}
+
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID uint, password, code string) error {
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	if !user.TwoFactorEnabled {
+		return status.Error(codes.FailedPrecondition, "two-factor not enabled")
+	}
+	if err := utils.VerifyPassword(user.Password, password); err != nil {
+		return status.Error(codes.InvalidArgument, "password incorrect")
+	}
+
+	ok, err := s.checkSecondFactor(user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	if err := s.Repo.DisableTwoFactor(user.ID); err != nil {
+		return status.Error(codes.Internal, "db error")
+	}
+	s.audit(ctx, model.EventTwoFactorDisabled, user, "")
+	return nil
+}
+
+// RegenerateRecoveryCodes — старые коды перестают действовать
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	user, err := s.Repo.GetUserById(userID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if !user.TwoFactorEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor not enabled")
+	}
+
+	ok, err := s.checkSecondFactor(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if err := s.Repo.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	s.audit(ctx, model.EventRecoveryCodesRenewed, user, "")
+	return recoveryCodes, nil
+}
+
+// VerifyTwoFactor — второй шаг входа: обмен challenge токена и кода (TOTP или recovery) на сессию
+func (s *AuthService) VerifyTwoFactor(ctx context.Context, req *pb.VerifyTwoFactorRequest) (string, string, error) {
+	if req.ChallengeToken == "" || req.Code == "" {
+		return "", "", status.Error(codes.InvalidArgument, "challenge token and code required")
+	}
+	challenge, err := s.Repo.FindTwoFactorChallenge(utils.HashToken(req.ChallengeToken))
+	if err != nil {
+		return "", "", status.Error(codes.Unauthenticated, "invalid challenge token")
+	}
+
+	if s.Clock().After(challenge.ExpiresAt) {
+		s.dropChallenge(challenge)
+		return "", "", status.Error(codes.Unauthenticated, "challenge token expired")
+	}
+	consumed, err := s.Repo.ConsumeChallengeAttempt(challenge.ID, twoFactorMaxAttempts)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "db error")
+	}
+	if !consumed {
+		s.dropChallenge(challenge)
+		return "", "", status.Error(codes.Unauthenticated, "challenge token expired")
+	}
+
+	user, err := s.Repo.GetUserById(challenge.UserID)
+	if err != nil {
+		return "", "", status.Error(codes.NotFound, "user not found")
+	}
+	if !user.TwoFactorEnabled {
+		s.dropChallenge(challenge)
+		return "", "", status.Error(codes.FailedPrecondition, "two-factor not enabled")
+	}
+
+	ok, err := s.checkSecondFactor(user, req.Code)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok {
+		s.audit(ctx, model.EventTwoFactorFailed, user, "")
+		return "", "", status.Error(codes.Unauthenticated, "invalid code")
+	}
+
+	// challenge одноразовый — параллельный запрос с тем же токеном не пройдёт
+	deleted, err := s.Repo.DeleteTwoFactorChallenge(challenge.ID)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "db error")
+	}
+	if deleted == 0 {
+		return "", "", status.Error(codes.Unauthenticated, "invalid challenge token")
+	}
+
+	access, refresh, err := s.startSession(ctx, user)
+	if err != nil {
+		return "", "", err
+	}
+	s.audit(ctx, model.EventLoginSucceeded, user, "two_factor")
+	return access, refresh, nil
+}
+
+func (s *AuthService) startTwoFactorChallenge(user *model.User) (string, error) {
+	token, err := utils.GenerateUUID()
+	if err != nil {
+		return "", status.Error(codes.Internal, "internal error")
+	}
+	if err := s.Repo.SaveTwoFactorChallenge(&model.TwoFactorChallenge{
+		UserID:    user.ID,
+		Token:     utils.HashToken(token),
+		ExpiresAt: s.Clock().Add(twoFactorChallengeTTL),
+	}); err != nil {
+		return "", status.Error(codes.Internal, "db error")
+	}
+	return token, nil
+}
+
+// checkSecondFactor — 6 цифр проверяем как TOTP, остальное как код восстановления
+func (s *AuthService) checkSecondFactor(user *model.User, code string) (bool, error) {
+	if utils.IsTOTPCode(code) {
+		step, ok := utils.ValidateTOTP(user.TwoFactorSecret, code, s.Clock(), user.TwoFactorLastStep)
+		if !ok {
+			return false, nil
+		}
+		advanced, err := s.Repo.AdvanceTwoFactorStep(user.ID, step)
+		if err != nil {
+			return false, status.Error(codes.Internal, "db error")
+		}
+		return advanced, nil
+	}
+
+	used, err := s.Repo.UseRecoveryCode(user.ID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		return false, status.Error(codes.Internal, "db error")
+	}
+	return used, nil
+}
+
+func (s *AuthService) dropChallenge(challenge *model.TwoFactorChallenge) {
+	if _, err := s.Repo.DeleteTwoFactorChallenge(challenge.ID); err != nil {
+		log.Printf("❌ failed to delete 2fa challenge %d: %v", challenge.ID, err)
+	}
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	recoveryCodes, err := utils.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, c := range recoveryCodes {
+		hashes = append(hashes, utils.HashToken(c))
+	}
+	return recoveryCodes, hashes, nil
+}
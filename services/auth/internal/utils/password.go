@@ -7,51 +7,138 @@ import (
 	"errors"
 	"fmt"
 	"golang.org/x/crypto/argon2"
+	"os"
 	utils2 "socialnet/pkg/utils"
+	"strconv"
 	"strings"
+	"sync"
 )
 
-func VerifyPassword(existPass, checkPass string) error {
-	parts := strings.Split(existPass, ".")
-	if len(parts) != 2 {
-		err := errors.New("password format error")
-		return utils2.ErrorHandler(err, "Invalid hash format")
-	}
-	saltBase64 := parts[0]
-	hashBase64 := parts[1]
+// Argon2Params — параметры argon2id; записываются в сам хэш (формат PHC),
+// поэтому их можно менять без потери старых паролей
+type Argon2Params struct {
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
 
-	salt, err := base64.StdEncoding.DecodeString(saltBase64)
-	if err != nil {
-		return utils2.ErrorHandler(err, "Invalid salt format")
+// legacyArgon2Params — хэши старого формата "salt.hash" считались с этими параметрами
+var legacyArgon2Params = Argon2Params{Time: 1, Memory: 64 * 1024, Threads: 4, KeyLen: 32, SaltLen: 16}
+
+// CurrentArgon2Params — параметры новых хэшей: RFC 9106 (t=3, 64 MiB, p=4),
+// переопределяются ARGON2_TIME, ARGON2_MEMORY_KIB и ARGON2_THREADS
+var CurrentArgon2Params = sync.OnceValue(func() Argon2Params {
+	p := Argon2Params{Time: 3, Memory: 64 * 1024, Threads: 4, KeyLen: 32, SaltLen: 16}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_TIME"), 10, 32); err == nil && v > 0 {
+		p.Time = uint32(v)
 	}
-	hashPass, err := base64.StdEncoding.DecodeString(hashBase64)
-	if err != nil {
-		return utils2.ErrorHandler(err, "Invalid password format")
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_MEMORY_KIB"), 10, 32); err == nil && v >= 8*1024 {
+		p.Memory = uint32(v)
 	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_THREADS"), 10, 8); err == nil && v > 0 {
+		p.Threads = uint8(v)
+	}
+	return p
+})
 
-	hash := argon2.IDKey([]byte(checkPass), salt, 1, 64*1024, 4, 32)
-
-	if len(hash) != len(hashPass) {
-		return utils2.ErrorHandler(err, "Invalid hash length")
+func VerifyPassword(existPass, checkPass string) error {
+	params, salt, hashPass, err := decodePasswordHash(existPass)
+	if err != nil {
+		return utils2.ErrorHandler(err, "Invalid hash format")
 	}
 
-	if subtle.ConstantTimeCompare(hash, []byte(hashPass)) != 1 {
-		return utils2.ErrorHandler(err, "Invalid password")
+	hash := argon2.IDKey([]byte(checkPass), salt, params.Time, params.Memory, params.Threads, uint32(len(hashPass)))
+
+	if subtle.ConstantTimeCompare(hash, hashPass) != 1 {
+		return utils2.ErrorHandler(errors.New("password mismatch"), "Invalid password")
 	}
 	return nil
 }
 
 func PasswordHashing(password string) (string, error) {
-	salt := make([]byte, 16)
+	return hashPasswordWith(password, CurrentArgon2Params())
+}
+
+// NeedsRehash — хэш старого формата или с устаревшими параметрами.
+// Пересчитать его можно только при входе, когда известен сам пароль.
+func NeedsRehash(encoded string) bool {
+	params, _, _, err := decodePasswordHash(encoded)
+	if err != nil || !strings.HasPrefix(encoded, "$argon2id$") {
+		return true
+	}
+	current := CurrentArgon2Params()
+	return params.Time != current.Time || params.Memory != current.Memory ||
+		params.Threads != current.Threads || params.KeyLen != current.KeyLen
+}
+
+// hashPasswordWith — $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+func hashPasswordWith(password string, p Argon2Params) (string, error) {
+	salt := make([]byte, p.SaltLen)
 	_, err := rand.Read(salt)
 	if err != nil {
 		return "", utils2.ErrorHandler(err, "Error generating random salt")
 	}
 
-	hash := argon2.IDKey([]byte(password), salt, 1, 64*1024, 4, 32)
+	hash := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	), nil
+}
+
+func decodePasswordHash(encoded string) (Argon2Params, []byte, []byte, error) {
+	if !strings.HasPrefix(encoded, "$") {
+		return decodeLegacyHash(encoded)
+	}
+
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Argon2Params{}, nil, nil, errors.New("unsupported hash algorithm")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, errors.New("unsupported argon2 version")
+	}
+	var p Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("invalid argon2 parameters: %v", err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	if p.Time == 0 || p.Threads == 0 || len(hash) == 0 {
+		return Argon2Params{}, nil, nil, errors.New("invalid argon2 parameters")
+	}
+	p.SaltLen, p.KeyLen = uint32(len(salt)), uint32(len(hash))
+	return p, salt, hash, nil
+}
 
-	saltBase64 := base64.StdEncoding.EncodeToString(salt)
-	hashBase64 := base64.StdEncoding.EncodeToString(hash)
-	encodedPass := fmt.Sprintf("%s.%s", saltBase64, hashBase64)
-	return encodedPass, err
+// decodeLegacyHash — base64(salt).base64(hash) с фиксированными параметрами
+func decodeLegacyHash(encoded string) (Argon2Params, []byte, []byte, error) {
+	parts := strings.Split(encoded, ".")
+	if len(parts) != 2 {
+		return Argon2Params{}, nil, nil, errors.New("password format error")
+	}
+	salt, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	hash, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	if len(hash) != int(legacyArgon2Params.KeyLen) {
+		return Argon2Params{}, nil, nil, errors.New("hash length mismatch")
+	}
+	return legacyArgon2Params, salt, hash, nil
 }
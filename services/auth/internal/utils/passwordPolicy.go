@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed common-passwords.txt
+var defaultBlocklist string
+
+// PasswordPolicy — требования к новому паролю.
+// HistorySize — сколько последних паролей (включая текущий) нельзя использовать снова, 0 — не проверять.
+type PasswordPolicy struct {
+	MinLength   int
+	MaxLength   int
+	HistorySize int
+	blocklist   map[string]bool
+}
+
+var (
+	ErrPasswordTooShort  = errors.New("password is too short")
+	ErrPasswordTooLong   = errors.New("password is too long")
+	ErrPasswordTooCommon = errors.New("password is too common")
+)
+
+// DefaultPasswordPolicy — 8..128 символов, встроенный список частых паролей, 5 последних паролей
+func DefaultPasswordPolicy() *PasswordPolicy {
+	p := &PasswordPolicy{MinLength: 8, MaxLength: 128, HistorySize: 5}
+	p.blocklist, _ = parseBlocklist(strings.NewReader(defaultBlocklist))
+	return p
+}
+
+// PasswordPolicyFromEnv — PASSWORD_MIN_LENGTH, PASSWORD_HISTORY и PASSWORD_BLOCKLIST_FILE
+// (файл заменяет встроенный список)
+func PasswordPolicyFromEnv() (*PasswordPolicy, error) {
+	p := DefaultPasswordPolicy()
+	if v := os.Getenv("PASSWORD_MIN_LENGTH"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > p.MaxLength {
+			return nil, fmt.Errorf("invalid PASSWORD_MIN_LENGTH %q", v)
+		}
+		p.MinLength = n
+	}
+	if v := os.Getenv("PASSWORD_HISTORY"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid PASSWORD_HISTORY %q", v)
+		}
+		p.HistorySize = n
+	}
+	if file := os.Getenv("PASSWORD_BLOCKLIST_FILE"); file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if p.blocklist, err = parseBlocklist(f); err != nil {
+			return nil, fmt.Errorf("PASSWORD_BLOCKLIST_FILE: %v", err)
+		}
+	}
+	return p, nil
+}
+
+// Check — длина считается в символах, сравнение со списком без учёта регистра
+func (p *PasswordPolicy) Check(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < p.MinLength {
+		return ErrPasswordTooShort
+	}
+	if p.MaxLength > 0 && n > p.MaxLength {
+		return ErrPasswordTooLong
+	}
+	if p.blocklist[strings.ToLower(password)] {
+		return ErrPasswordTooCommon
+	}
+	return nil
+}
+
+// parseBlocklist — по паролю в строке, # — комментарий
+func parseBlocklist(r io.Reader) (map[string]bool, error) {
+	list := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list[strings.ToLower(line)] = true
+	}
+	return list, scanner.Err()
+}
@@ -67,6 +67,14 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.ExtractUserInterceptor(),
+			interceptor.RequireVerifiedEmail(
+				pb.ChatService_CreateChat_FullMethodName,
+				pb.ChatService_SendMessage_FullMethodName,
+			),
+			interceptor.InternalOnly(
+				pb.ChatService_DeleteUserData_FullMethodName,
+				pb.ChatService_ExportUserData_FullMethodName,
+			),
 			interceptor.LoggingInterceptor(),
 		),
 	)
@@ -11,6 +11,8 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -18,6 +20,7 @@ import (
 	"socialnet/pkg/contextx"
 	authpb "socialnet/services/auth/gen"
 	notificationpb "socialnet/services/notification/gen"
+	userpb "socialnet/services/user/gen"
 
 	pb "socialnet/services/chat/gen"
 	"socialnet/services/chat/internal/model"
@@ -36,6 +39,32 @@ var (
 	testCtx   = context.WithValue(context.Background(), contextx.UserIDKey, "1")
 )
 
+// ---------------------------------------------------------
+// MOCK User Client — нужны только CheckRelations и BatchGetUsers
+// ---------------------------------------------------------
+type mockUsers struct {
+	userpb.UserServiceClient
+	blocked map[string]bool // "user:other"
+}
+
+func (m *mockUsers) CheckRelations(ctx context.Context, in *userpb.CheckRelationsRequest, opts ...grpc.CallOption) (*userpb.CheckRelationsResponse, error) {
+	resp := &userpb.CheckRelationsResponse{}
+	for _, other := range in.OtherIds {
+		if m.blocked[in.UserId+":"+other] {
+			resp.BlockedIds = append(resp.BlockedIds, other)
+		}
+	}
+	return resp, nil
+}
+
+func (m *mockUsers) BatchGetUsers(ctx context.Context, in *userpb.BatchGetUsersRequest, opts ...grpc.CallOption) (*userpb.BatchGetUsersResponse, error) {
+	resp := &userpb.BatchGetUsersResponse{}
+	for _, id := range in.Ids {
+		resp.Users = append(resp.Users, &userpb.UserSummary{Id: id, FirstName: "User " + id})
+	}
+	return resp, nil
+}
+
 // ---------------------------------------------------------
 // MOCK Notification Client
 // ---------------------------------------------------------
@@ -66,6 +95,12 @@ func (m *mockNotif) ClearAll(context.Context, *notificationpb.EmptyRequest, ...g
 func (m *mockNotif) StreamNotifications(context.Context, *notificationpb.StreamRequest, ...grpc.CallOption) (notificationpb.NotificationService_StreamNotificationsClient, error) {
 	return nil, nil
 }
+func (m *mockNotif) DeleteUserData(context.Context, *notificationpb.DeleteUserDataRequest, ...grpc.CallOption) (*notificationpb.DeleteUserDataResponse, error) {
+	return &notificationpb.DeleteUserDataResponse{}, nil
+}
+func (m *mockNotif) ExportUserData(context.Context, *notificationpb.ExportUserDataRequest, ...grpc.CallOption) (*notificationpb.ExportUserDataResponse, error) {
+	return &notificationpb.ExportUserDataResponse{}, nil
+}
 
 // =========================================================
 // TEST MAIN
@@ -98,6 +133,7 @@ func TestMain(m *testing.M) {
 
 	clients := &config.GRPCClients{
 		NotifClient: mockNotifClient,
+		UserClient:  &mockUsers{blocked: map[string]bool{"1:9": true}},
 	}
 
 	testSvc = service.NewChatService(testRepo, testRedis, clients)
@@ -121,6 +157,19 @@ func TestCreateChat(t *testing.T) {
 	assert.Len(t, resp.Participants, 3)
 }
 
+func TestCreateChat_BlockedUser(t *testing.T) {
+	_, err := testSvc.CreateChat(testCtx, &pb.CreateChatRequest{Participants: []string{"9"}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// личный чат, созданный до блокировки, писать тоже не даёт
+	chat := &model.Chat{Name: "old", CreatedAt: time.Now()}
+	_ = testDB.Create(chat)
+	_ = testRepo.AddParticipant(chat.ID, "1")
+	_ = testRepo.AddParticipant(chat.ID, "9")
+	_, err = testSvc.SendMessage(testCtx, &pb.SendMessageRequest{ChatId: fmt.Sprint(chat.ID), Content: "hi"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
 func TestSendMessage(t *testing.T) {
 	// создаём чат
 	chat := &model.Chat{Name: "X", CreatedAt: time.Now()}
@@ -153,8 +202,8 @@ func TestListMessages(t *testing.T) {
 	})
 
 	resp, err := testSvc.ListMessages(testCtx, &pb.ListMessagesRequest{
-		ChatId: fmt.Sprint(chat.ID),
-		Limit:  10,
+		ChatId:   fmt.Sprint(chat.ID),
+		PageSize: 10,
 	})
 	assert.NoError(t, err)
 	assert.Len(t, resp.Messages, 1)
@@ -168,9 +217,67 @@ func TestListChats(t *testing.T) {
 		UserID: "1",
 	})
 
-	resp, err := testSvc.ListChats(testCtx, &pb.EmptyRequest{})
+	resp, err := testSvc.ListChats(testCtx, &pb.ListChatsRequest{PageSize: 100})
 	assert.NoError(t, err)
 	assert.True(t, len(resp.Chats) >= 1)
+	for _, c := range resp.Chats {
+		if c.Id == fmt.Sprint(chat.ID) {
+			assert.Len(t, c.Members, 1)
+			assert.Equal(t, "User 1", c.Members[0].FirstName)
+		}
+	}
+}
+
+func TestDeleteUserData(t *testing.T) {
+	shared := &model.Chat{Name: "shared", CreatedAt: time.Now()}
+	_ = testDB.Create(shared)
+	solo := &model.Chat{Name: "solo", CreatedAt: time.Now()}
+	_ = testDB.Create(solo)
+
+	_ = testDB.Create(&model.Participant{ChatID: shared.ID, UserID: "gone"})
+	_ = testDB.Create(&model.Participant{ChatID: shared.ID, UserID: "friend"})
+	_ = testDB.Create(&model.Participant{ChatID: solo.ID, UserID: "gone"})
+	_ = testDB.Create(&model.Message{ChatID: shared.ID, SenderID: "gone", Content: "secret", CreatedAt: time.Now()})
+	_ = testDB.Create(&model.Message{ChatID: shared.ID, SenderID: "friend", Content: "hi", CreatedAt: time.Now()})
+	_ = testDB.Create(&model.Message{ChatID: solo.ID, SenderID: "gone", Content: "note", CreatedAt: time.Now()})
+
+	_, err := testSvc.DeleteUserData(testCtx, "gone")
+	assert.NoError(t, err)
+
+	// у собеседника переписка осталась, но без содержимого удалённого аккаунта
+	var msgs []model.Message
+	testDB.Where("chat_id = ?", shared.ID).Order("id").Find(&msgs)
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, repos.DeletedSenderID, msgs[0].SenderID)
+	assert.Empty(t, msgs[0].Content)
+	assert.Equal(t, "hi", msgs[1].Content)
+
+	// чат без участников удалён вместе с сообщениями
+	var cnt int64
+	testDB.Model(&model.Chat{}).Where("id = ?", solo.ID).Count(&cnt)
+	assert.Equal(t, int64(0), cnt)
+	testDB.Model(&model.Message{}).Where("chat_id = ?", solo.ID).Count(&cnt)
+	assert.Equal(t, int64(0), cnt)
+
+	testDB.Model(&model.Participant{}).Where("user_id = ?", "gone").Count(&cnt)
+	assert.Equal(t, int64(0), cnt)
+}
+
+func TestExportUserData_OnlyOwnMessages(t *testing.T) {
+	chat := &model.Chat{Name: "export", CreatedAt: time.Now()}
+	_ = testDB.Create(chat)
+	_ = testDB.Create(&model.Participant{ChatID: chat.ID, UserID: "exp1"})
+	_ = testDB.Create(&model.Participant{ChatID: chat.ID, UserID: "exp2"})
+	_ = testDB.Create(&model.Message{ChatID: chat.ID, SenderID: "exp1", Content: "mine", MediaURL: "m.jpg", CreatedAt: time.Now()})
+	_ = testDB.Create(&model.Message{ChatID: chat.ID, SenderID: "exp2", Content: "theirs", CreatedAt: time.Now()})
+
+	resp, err := testSvc.ExportUserData(testCtx, "exp1")
+	assert.NoError(t, err)
+	assert.Len(t, resp.Chats, 1)
+	assert.ElementsMatch(t, []string{"exp1", "exp2"}, resp.Chats[0].Participants)
+	assert.Len(t, resp.Messages, 1)
+	assert.Equal(t, "mine", resp.Messages[0].Content)
+	assert.Equal(t, "m.jpg", resp.Messages[0].MediaUrl)
 }
 
 // ---- fake stream ----
@@ -11,7 +11,8 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
-	gen "socialnet/services/auth/gen"
+	gen1 "socialnet/services/auth/gen"
+	gen "socialnet/services/user/gen"
 	sync "sync"
 	unsafe "unsafe"
 )
@@ -34,8 +35,10 @@ type Chat struct {
 	IsGroup         bool                   `protobuf:"varint,7,opt,name=is_group,proto3" json:"is_group,omitempty"`
 	CreatedAt       string                 `protobuf:"bytes,8,opt,name=created_at,proto3" json:"created_at,omitempty"`
 	UpdatedAt       string                 `protobuf:"bytes,9,opt,name=updated_at,proto3" json:"updated_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// краткие профили участников, заполняются в ListChats
+	Members       []*gen.UserSummary `protobuf:"bytes,10,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Chat) Reset() {
@@ -131,9 +134,17 @@ func (x *Chat) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Chat) GetMembers() []*gen.UserSummary {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
 type Chats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — последняя страница
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -175,6 +186,13 @@ func (x *Chats) GetChats() []*Chat {
 	return nil
 }
 
+func (x *Chats) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type Message struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -278,6 +296,7 @@ func (x *Message) GetRead() bool {
 type Messages struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — последняя страница
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -319,6 +338,13 @@ func (x *Messages) GetMessages() []*Message {
 	return nil
 }
 
+func (x *Messages) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type CreateChatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Participants  []string               `protobuf:"bytes,1,rep,name=participants,proto3" json:"participants,omitempty"`
@@ -527,11 +553,12 @@ func (x *SendMessageRequest) GetMediaUrl() string {
 	return ""
 }
 
+// page_size по умолчанию 20 (максимум 100), page_token — next_page_token из предыдущего ответа
 type ListMessagesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,proto3" json:"chat_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -573,20 +600,73 @@ func (x *ListMessagesRequest) GetChatId() string {
 	return ""
 }
 
-func (x *ListMessagesRequest) GetLimit() int32 {
+func (x *ListMessagesRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Limit
+		return x.PageSize
 	}
 	return 0
 }
 
-func (x *ListMessagesRequest) GetOffset() int32 {
+func (x *ListMessagesRequest) GetPageToken() string {
 	if x != nil {
-		return x.Offset
+		return x.PageToken
+	}
+	return ""
+}
+
+// новые чаты первыми
+type ListChatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChatsRequest) Reset() {
+	*x = ListChatsRequest{}
+	mi := &file_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChatsRequest) ProtoMessage() {}
+
+func (x *ListChatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChatsRequest.ProtoReflect.Descriptor instead.
+func (*ListChatsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListChatsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
 	return 0
 }
 
+func (x *ListChatsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type EmptyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -595,7 +675,7 @@ type EmptyRequest struct {
 
 func (x *EmptyRequest) Reset() {
 	*x = EmptyRequest{}
-	mi := &file_chat_proto_msgTypes[9]
+	mi := &file_chat_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -607,7 +687,7 @@ func (x *EmptyRequest) String() string {
 func (*EmptyRequest) ProtoMessage() {}
 
 func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[9]
+	mi := &file_chat_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +700,7 @@ func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
 func (*EmptyRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{9}
+	return file_chat_proto_rawDescGZIP(), []int{10}
 }
 
 type SubscribeRequest struct {
@@ -632,7 +712,7 @@ type SubscribeRequest struct {
 
 func (x *SubscribeRequest) Reset() {
 	*x = SubscribeRequest{}
-	mi := &file_chat_proto_msgTypes[10]
+	mi := &file_chat_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -644,7 +724,7 @@ func (x *SubscribeRequest) String() string {
 func (*SubscribeRequest) ProtoMessage() {}
 
 func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[10]
+	mi := &file_chat_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,7 +737,7 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{10}
+	return file_chat_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SubscribeRequest) GetChatIds() []string {
@@ -677,7 +757,7 @@ type MarkAsReadRequest struct {
 
 func (x *MarkAsReadRequest) Reset() {
 	*x = MarkAsReadRequest{}
-	mi := &file_chat_proto_msgTypes[11]
+	mi := &file_chat_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -689,7 +769,7 @@ func (x *MarkAsReadRequest) String() string {
 func (*MarkAsReadRequest) ProtoMessage() {}
 
 func (x *MarkAsReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[11]
+	mi := &file_chat_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -702,7 +782,7 @@ func (x *MarkAsReadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MarkAsReadRequest.ProtoReflect.Descriptor instead.
 func (*MarkAsReadRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{11}
+	return file_chat_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MarkAsReadRequest) GetChatId() string {
@@ -719,13 +799,200 @@ func (x *MarkAsReadRequest) GetMessageId() string {
 	return ""
 }
 
+// ----- Account deletion -----
+type DeleteUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataRequest) Reset() {
+	*x = DeleteUserDataRequest{}
+	mi := &file_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataRequest) ProtoMessage() {}
+
+func (x *DeleteUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataResponse) Reset() {
+	*x = DeleteUserDataResponse{}
+	mi := &file_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataResponse) ProtoMessage() {}
+
+func (x *DeleteUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteUserDataResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// ----- Data export -----
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	Messages      []*Message             `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"` // только отправленные самим пользователем
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExportUserDataResponse) GetChats() []*Chat {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 var File_chat_proto protoreflect.FileDescriptor
 
 const file_chat_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
 	"chat.proto\x12\x04chat\x1a\x1cgoogle/api/annotations.proto\x1a\n" +
-	"auth.proto\"\xa4\x02\n" +
+	"auth.proto\x1a\n" +
+	"user.proto\"\xd1\x02\n" +
 	"\x04Chat\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
@@ -739,10 +1006,13 @@ const file_chat_proto_rawDesc = "" +
 	"created_at\x12\x1e\n" +
 	"\n" +
 	"updated_at\x18\t \x01(\tR\n" +
-	"updated_at\")\n" +
+	"updated_at\x12+\n" +
+	"\amembers\x18\n" +
+	" \x03(\v2\x11.user.UserSummaryR\amembers\"Q\n" +
 	"\x05Chats\x12 \n" +
 	"\x05chats\x18\x01 \x03(\v2\n" +
-	".chat.ChatR\x05chats\"\xe1\x01\n" +
+	".chat.ChatR\x05chats\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe1\x01\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\achat_id\x18\x02 \x01(\tR\achat_id\x12\x1c\n" +
@@ -753,9 +1023,10 @@ const file_chat_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\n" +
 	"created_at\x12\x12\n" +
-	"\x04read\x18\b \x01(\bR\x04read\"5\n" +
+	"\x04read\x18\b \x01(\bR\x04read\"]\n" +
 	"\bMessages\x12)\n" +
-	"\bmessages\x18\x01 \x03(\v2\r.chat.MessageR\bmessages\"K\n" +
+	"\bmessages\x18\x01 \x03(\v2\r.chat.MessageR\bmessages\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"K\n" +
 	"\x11CreateChatRequest\x12\"\n" +
 	"\fparticipants\x18\x01 \x03(\tR\fparticipants\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\" \n" +
@@ -767,11 +1038,16 @@ const file_chat_proto_rawDesc = "" +
 	"\achat_id\x18\x01 \x01(\tR\achat_id\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\"\n" +
 	"\fcontent_type\x18\x03 \x01(\tR\fcontent_type\x12\x1c\n" +
-	"\tmedia_url\x18\x04 \x01(\tR\tmedia_url\"]\n" +
+	"\tmedia_url\x18\x04 \x01(\tR\tmedia_url\"\x86\x01\n" +
 	"\x13ListMessagesRequest\x12\x18\n" +
-	"\achat_id\x18\x01 \x01(\tR\achat_id\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x0e\n" +
+	"\achat_id\x18\x01 \x01(\tR\achat_id\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageTokenJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04R\x05limitR\x06offset\"N\n" +
+	"\x10ListChatsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x0e\n" +
 	"\fEmptyRequest\"-\n" +
 	"\x10SubscribeRequest\x12\x19\n" +
 	"\bchat_ids\x18\x01 \x03(\tR\achatIds\"M\n" +
@@ -779,21 +1055,33 @@ const file_chat_proto_rawDesc = "" +
 	"\achat_id\x18\x01 \x01(\tR\achat_id\x12\x1e\n" +
 	"\n" +
 	"message_id\x18\x02 \x01(\tR\n" +
-	"message_id2\xc1\x05\n" +
+	"message_id\"0\n" +
+	"\x15DeleteUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x16DeleteUserDataResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"e\n" +
+	"\x16ExportUserDataResponse\x12 \n" +
+	"\x05chats\x18\x01 \x03(\v2\n" +
+	".chat.ChatR\x05chats\x12)\n" +
+	"\bmessages\x18\x02 \x03(\v2\r.chat.MessageR\bmessages2\xdf\x06\n" +
 	"\vChatService\x12K\n" +
 	"\n" +
 	"CreateChat\x12\x17.chat.CreateChatRequest\x1a\n" +
 	".chat.Chat\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/chats\x12G\n" +
 	"\aGetChat\x12\x14.chat.GetChatRequest\x1a\n" +
-	".chat.Chat\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/chats/{id}\x12C\n" +
-	"\tListChats\x12\x12.chat.EmptyRequest\x1a\v.chat.Chats\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/chats\x12c\n" +
+	".chat.Chat\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/chats/{id}\x12G\n" +
+	"\tListChats\x12\x16.chat.ListChatsRequest\x1a\v.chat.Chats\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/chats\x12c\n" +
 	"\vSendMessage\x12\x18.chat.SendMessageRequest\x1a\r.chat.Message\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/chats/{chat_id}/messages\x12c\n" +
 	"\fListMessages\x12\x19.chat.ListMessagesRequest\x1a\x0e.chat.Messages\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/chats/{chat_id}/messages\x12U\n" +
 	"\n" +
 	"DeleteChat\x12\x17.chat.DeleteChatRequest\x1a\x12.auth.Confirmation\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/chats/{id}\x12x\n" +
 	"\n" +
 	"MarkAsRead\x12\x17.chat.MarkAsReadRequest\x1a\x12.auth.Confirmation\"=\x82\xd3\xe4\x93\x027:\x01*\"2/api/v1/chats/{chat_id}/messages/{message_id}/read\x12<\n" +
-	"\x11SubscribeMessages\x12\x16.chat.SubscribeRequest\x1a\r.chat.Message0\x01B$Z\"socialnet/services/chat/gen;chatpbb\x06proto3"
+	"\x11SubscribeMessages\x12\x16.chat.SubscribeRequest\x1a\r.chat.Message0\x01\x12K\n" +
+	"\x0eDeleteUserData\x12\x1b.chat.DeleteUserDataRequest\x1a\x1c.chat.DeleteUserDataResponse\x12K\n" +
+	"\x0eExportUserData\x12\x1b.chat.ExportUserDataRequest\x1a\x1c.chat.ExportUserDataResponseB$Z\"socialnet/services/chat/gen;chatpbb\x06proto3"
 
 var (
 	file_chat_proto_rawDescOnce sync.Once
@@ -807,46 +1095,59 @@ func file_chat_proto_rawDescGZIP() []byte {
 	return file_chat_proto_rawDescData
 }
 
-var file_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_chat_proto_goTypes = []any{
-	(*Chat)(nil),                // 0: chat.Chat
-	(*Chats)(nil),               // 1: chat.Chats
-	(*Message)(nil),             // 2: chat.Message
-	(*Messages)(nil),            // 3: chat.Messages
-	(*CreateChatRequest)(nil),   // 4: chat.CreateChatRequest
-	(*GetChatRequest)(nil),      // 5: chat.GetChatRequest
-	(*DeleteChatRequest)(nil),   // 6: chat.DeleteChatRequest
-	(*SendMessageRequest)(nil),  // 7: chat.SendMessageRequest
-	(*ListMessagesRequest)(nil), // 8: chat.ListMessagesRequest
-	(*EmptyRequest)(nil),        // 9: chat.EmptyRequest
-	(*SubscribeRequest)(nil),    // 10: chat.SubscribeRequest
-	(*MarkAsReadRequest)(nil),   // 11: chat.MarkAsReadRequest
-	(*gen.Confirmation)(nil),    // 12: auth.Confirmation
+	(*Chat)(nil),                   // 0: chat.Chat
+	(*Chats)(nil),                  // 1: chat.Chats
+	(*Message)(nil),                // 2: chat.Message
+	(*Messages)(nil),               // 3: chat.Messages
+	(*CreateChatRequest)(nil),      // 4: chat.CreateChatRequest
+	(*GetChatRequest)(nil),         // 5: chat.GetChatRequest
+	(*DeleteChatRequest)(nil),      // 6: chat.DeleteChatRequest
+	(*SendMessageRequest)(nil),     // 7: chat.SendMessageRequest
+	(*ListMessagesRequest)(nil),    // 8: chat.ListMessagesRequest
+	(*ListChatsRequest)(nil),       // 9: chat.ListChatsRequest
+	(*EmptyRequest)(nil),           // 10: chat.EmptyRequest
+	(*SubscribeRequest)(nil),       // 11: chat.SubscribeRequest
+	(*MarkAsReadRequest)(nil),      // 12: chat.MarkAsReadRequest
+	(*DeleteUserDataRequest)(nil),  // 13: chat.DeleteUserDataRequest
+	(*DeleteUserDataResponse)(nil), // 14: chat.DeleteUserDataResponse
+	(*ExportUserDataRequest)(nil),  // 15: chat.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil), // 16: chat.ExportUserDataResponse
+	(*gen.UserSummary)(nil),        // 17: user.UserSummary
+	(*gen1.Confirmation)(nil),      // 18: auth.Confirmation
 }
 var file_chat_proto_depIdxs = []int32{
-	0,  // 0: chat.Chats.chats:type_name -> chat.Chat
-	2,  // 1: chat.Messages.messages:type_name -> chat.Message
-	4,  // 2: chat.ChatService.CreateChat:input_type -> chat.CreateChatRequest
-	5,  // 3: chat.ChatService.GetChat:input_type -> chat.GetChatRequest
-	9,  // 4: chat.ChatService.ListChats:input_type -> chat.EmptyRequest
-	7,  // 5: chat.ChatService.SendMessage:input_type -> chat.SendMessageRequest
-	8,  // 6: chat.ChatService.ListMessages:input_type -> chat.ListMessagesRequest
-	6,  // 7: chat.ChatService.DeleteChat:input_type -> chat.DeleteChatRequest
-	11, // 8: chat.ChatService.MarkAsRead:input_type -> chat.MarkAsReadRequest
-	10, // 9: chat.ChatService.SubscribeMessages:input_type -> chat.SubscribeRequest
-	0,  // 10: chat.ChatService.CreateChat:output_type -> chat.Chat
-	0,  // 11: chat.ChatService.GetChat:output_type -> chat.Chat
-	1,  // 12: chat.ChatService.ListChats:output_type -> chat.Chats
-	2,  // 13: chat.ChatService.SendMessage:output_type -> chat.Message
-	3,  // 14: chat.ChatService.ListMessages:output_type -> chat.Messages
-	12, // 15: chat.ChatService.DeleteChat:output_type -> auth.Confirmation
-	12, // 16: chat.ChatService.MarkAsRead:output_type -> auth.Confirmation
-	2,  // 17: chat.ChatService.SubscribeMessages:output_type -> chat.Message
-	10, // [10:18] is the sub-list for method output_type
-	2,  // [2:10] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	17, // 0: chat.Chat.members:type_name -> user.UserSummary
+	0,  // 1: chat.Chats.chats:type_name -> chat.Chat
+	2,  // 2: chat.Messages.messages:type_name -> chat.Message
+	0,  // 3: chat.ExportUserDataResponse.chats:type_name -> chat.Chat
+	2,  // 4: chat.ExportUserDataResponse.messages:type_name -> chat.Message
+	4,  // 5: chat.ChatService.CreateChat:input_type -> chat.CreateChatRequest
+	5,  // 6: chat.ChatService.GetChat:input_type -> chat.GetChatRequest
+	9,  // 7: chat.ChatService.ListChats:input_type -> chat.ListChatsRequest
+	7,  // 8: chat.ChatService.SendMessage:input_type -> chat.SendMessageRequest
+	8,  // 9: chat.ChatService.ListMessages:input_type -> chat.ListMessagesRequest
+	6,  // 10: chat.ChatService.DeleteChat:input_type -> chat.DeleteChatRequest
+	12, // 11: chat.ChatService.MarkAsRead:input_type -> chat.MarkAsReadRequest
+	11, // 12: chat.ChatService.SubscribeMessages:input_type -> chat.SubscribeRequest
+	13, // 13: chat.ChatService.DeleteUserData:input_type -> chat.DeleteUserDataRequest
+	15, // 14: chat.ChatService.ExportUserData:input_type -> chat.ExportUserDataRequest
+	0,  // 15: chat.ChatService.CreateChat:output_type -> chat.Chat
+	0,  // 16: chat.ChatService.GetChat:output_type -> chat.Chat
+	1,  // 17: chat.ChatService.ListChats:output_type -> chat.Chats
+	2,  // 18: chat.ChatService.SendMessage:output_type -> chat.Message
+	3,  // 19: chat.ChatService.ListMessages:output_type -> chat.Messages
+	18, // 20: chat.ChatService.DeleteChat:output_type -> auth.Confirmation
+	18, // 21: chat.ChatService.MarkAsRead:output_type -> auth.Confirmation
+	2,  // 22: chat.ChatService.SubscribeMessages:output_type -> chat.Message
+	14, // 23: chat.ChatService.DeleteUserData:output_type -> chat.DeleteUserDataResponse
+	16, // 24: chat.ChatService.ExportUserData:output_type -> chat.ExportUserDataResponse
+	15, // [15:25] is the sub-list for method output_type
+	5,  // [5:15] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_chat_proto_init() }
@@ -860,7 +1161,7 @@ func file_chat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_proto_rawDesc), len(file_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -28,6 +28,8 @@ const (
 	ChatService_DeleteChat_FullMethodName        = "/chat.ChatService/DeleteChat"
 	ChatService_MarkAsRead_FullMethodName        = "/chat.ChatService/MarkAsRead"
 	ChatService_SubscribeMessages_FullMethodName = "/chat.ChatService/SubscribeMessages"
+	ChatService_DeleteUserData_FullMethodName    = "/chat.ChatService/DeleteUserData"
+	ChatService_ExportUserData_FullMethodName    = "/chat.ChatService/ExportUserData"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -36,13 +38,17 @@ const (
 type ChatServiceClient interface {
 	CreateChat(ctx context.Context, in *CreateChatRequest, opts ...grpc.CallOption) (*Chat, error)
 	GetChat(ctx context.Context, in *GetChatRequest, opts ...grpc.CallOption) (*Chat, error)
-	ListChats(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Chats, error)
+	ListChats(ctx context.Context, in *ListChatsRequest, opts ...grpc.CallOption) (*Chats, error)
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*Message, error)
 	ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*Messages, error)
 	DeleteChat(ctx context.Context, in *DeleteChatRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
 	MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
 	// ONLY SERVER STREAMING (grpc-web compatible)
 	SubscribeMessages(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
+	// Внутренний вызов саги удаления аккаунта: выход из чатов, сообщения обезличиваются
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: чаты пользователя и его сообщения
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
 }
 
 type chatServiceClient struct {
@@ -73,7 +79,7 @@ func (c *chatServiceClient) GetChat(ctx context.Context, in *GetChatRequest, opt
 	return out, nil
 }
 
-func (c *chatServiceClient) ListChats(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Chats, error) {
+func (c *chatServiceClient) ListChats(ctx context.Context, in *ListChatsRequest, opts ...grpc.CallOption) (*Chats, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Chats)
 	err := c.cc.Invoke(ctx, ChatService_ListChats_FullMethodName, in, out, cOpts...)
@@ -142,19 +148,43 @@ func (c *chatServiceClient) SubscribeMessages(ctx context.Context, in *Subscribe
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChatService_SubscribeMessagesClient = grpc.ServerStreamingClient[Message]
 
+func (c *chatServiceClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserDataResponse)
+	err := c.cc.Invoke(ctx, ChatService_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, ChatService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
 type ChatServiceServer interface {
 	CreateChat(context.Context, *CreateChatRequest) (*Chat, error)
 	GetChat(context.Context, *GetChatRequest) (*Chat, error)
-	ListChats(context.Context, *EmptyRequest) (*Chats, error)
+	ListChats(context.Context, *ListChatsRequest) (*Chats, error)
 	SendMessage(context.Context, *SendMessageRequest) (*Message, error)
 	ListMessages(context.Context, *ListMessagesRequest) (*Messages, error)
 	DeleteChat(context.Context, *DeleteChatRequest) (*gen.Confirmation, error)
 	MarkAsRead(context.Context, *MarkAsReadRequest) (*gen.Confirmation, error)
 	// ONLY SERVER STREAMING (grpc-web compatible)
 	SubscribeMessages(*SubscribeRequest, grpc.ServerStreamingServer[Message]) error
+	// Внутренний вызов саги удаления аккаунта: выход из чатов, сообщения обезличиваются
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: чаты пользователя и его сообщения
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -171,7 +201,7 @@ func (UnimplementedChatServiceServer) CreateChat(context.Context, *CreateChatReq
 func (UnimplementedChatServiceServer) GetChat(context.Context, *GetChatRequest) (*Chat, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetChat not implemented")
 }
-func (UnimplementedChatServiceServer) ListChats(context.Context, *EmptyRequest) (*Chats, error) {
+func (UnimplementedChatServiceServer) ListChats(context.Context, *ListChatsRequest) (*Chats, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListChats not implemented")
 }
 func (UnimplementedChatServiceServer) SendMessage(context.Context, *SendMessageRequest) (*Message, error) {
@@ -189,6 +219,12 @@ func (UnimplementedChatServiceServer) MarkAsRead(context.Context, *MarkAsReadReq
 func (UnimplementedChatServiceServer) SubscribeMessages(*SubscribeRequest, grpc.ServerStreamingServer[Message]) error {
 	return status.Error(codes.Unimplemented, "method SubscribeMessages not implemented")
 }
+func (UnimplementedChatServiceServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedChatServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
 func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
@@ -247,7 +283,7 @@ func _ChatService_GetChat_Handler(srv interface{}, ctx context.Context, dec func
 }
 
 func _ChatService_ListChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+	in := new(ListChatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -259,7 +295,7 @@ func _ChatService_ListChats_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: ChatService_ListChats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListChats(ctx, req.(*EmptyRequest))
+		return srv.(ChatServiceServer).ListChats(ctx, req.(*ListChatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -347,6 +383,42 @@ func _ChatService_SubscribeMessages_Handler(srv interface{}, stream grpc.ServerS
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChatService_SubscribeMessagesServer = grpc.ServerStreamingServer[Message]
 
+func _ChatService_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -382,6 +454,14 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MarkAsRead",
 			Handler:    _ChatService_MarkAsRead_Handler,
 		},
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _ChatService_DeleteUserData_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _ChatService_ExportUserData_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
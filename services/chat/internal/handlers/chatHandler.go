@@ -32,7 +32,7 @@ func (h *ChatHandler) SubscribeMessages(req *pb.SubscribeRequest, stream pb.Chat
 	return h.s.SubscribeMessages(req, stream)
 }
 
-func (h *ChatHandler) ListChats(ctx context.Context, req *pb.EmptyRequest) (*pb.Chats, error) {
+func (h *ChatHandler) ListChats(ctx context.Context, req *pb.ListChatsRequest) (*pb.Chats, error) {
 	return h.s.ListChats(ctx, req)
 }
 
@@ -47,3 +47,15 @@ func (h *ChatHandler) DeleteChat(ctx context.Context, req *pb.DeleteChatRequest)
 func (h *ChatHandler) MarkAsRead(ctx context.Context, req *pb.MarkAsReadRequest) (*authpb.Confirmation, error) {
 	return h.s.MarkAsRead(ctx, req)
 }
+
+func (h *ChatHandler) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	affected, err := h.s.DeleteUserData(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteUserDataResponse{Deleted: affected}, nil
+}
+
+func (h *ChatHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	return h.s.ExportUserData(ctx, req.UserId)
+}
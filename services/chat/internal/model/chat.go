@@ -3,10 +3,10 @@ package model
 import "time"
 
 type Chat struct {
-	ID           uint   `gorm:"primaryKey"`
+	ID           uint   `gorm:"primaryKey;index:idx_chats_page,priority:2"`
 	Name         string `gorm:"size:100"`
 	IsGroup      bool
-	CreatedAt    time.Time
+	CreatedAt    time.Time `gorm:"index:idx_chats_page,priority:1"`
 	UpdatedAt    time.Time
 	Participants []Participant `gorm:"foreignKey:ChatID"`
 }
@@ -18,12 +18,12 @@ type Participant struct {
 }
 
 type Message struct {
-	ID          uint   `gorm:"primaryKey"`
-	ChatID      uint   `gorm:"index"`
+	ID          uint   `gorm:"primaryKey;index:idx_messages_page,priority:3"`
+	ChatID      uint   `gorm:"index;index:idx_messages_page,priority:1"`
 	SenderID    string `gorm:"index"`
 	Content     string
 	ContentType string
 	MediaURL    string
 	Read        bool
-	CreatedAt   time.Time
+	CreatedAt   time.Time `gorm:"index:idx_messages_page,priority:2"`
 }
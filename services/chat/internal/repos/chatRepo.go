@@ -3,6 +3,7 @@ package repos
 import (
 	"errors"
 	"gorm.io/gorm"
+	"socialnet/pkg/utils"
 	"socialnet/services/chat/internal/model"
 )
 
@@ -38,15 +39,16 @@ func (r *ChatRepo) SaveMessage(m *model.Message) error {
 // ─── LIST MESSAGES ─────────────────────────────────────────────────────────
 //
 
-func (r *ChatRepo) GetMessages(chatID uint, limit, offset int) ([]model.Message, error) {
+// GetMessages — старые первыми, keyset по (created_at, id) от курсора.
+// limit+1 строка: лишняя означает, что есть следующая страница.
+func (r *ChatRepo) GetMessages(chatID uint, after *utils.PageCursor, limit int) ([]model.Message, error) {
 	var msgs []model.Message
 
-	err := r.db.
-		Where("chat_id = ?", chatID).
-		Order("created_at ASC").
-		Offset(offset).
-		Limit(limit).
-		Find(&msgs).Error
+	q := r.db.Where("chat_id = ?", chatID).Order("created_at, id").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at > ? OR (created_at = ? AND id > ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	err := q.Find(&msgs).Error
 
 	return msgs, err
 }
@@ -83,6 +85,24 @@ func (r *ChatRepo) GetChatsByUser(userID string) ([]model.Chat, error) {
 	return chats, err
 }
 
+// ListChatsByUser — страница чатов пользователя, новые первыми; limit+1 строка, как в GetMessages
+func (r *ChatRepo) ListChatsByUser(userID string, after *utils.PageCursor, limit int) ([]model.Chat, error) {
+	var chats []model.Chat
+
+	q := r.db.
+		Joins("JOIN participants ON participants.chat_id = chats.id").
+		Where("participants.user_id = ?", userID).
+		Preload("Participants").
+		Order("chats.created_at DESC, chats.id DESC").
+		Limit(limit + 1)
+	if after != nil {
+		q = q.Where("chats.created_at < ? OR (chats.created_at = ? AND chats.id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	err := q.Find(&chats).Error
+
+	return chats, err
+}
+
 //
 // ─── GET PARTICIPANTS ─────────────────────────────────────────────────────
 //
@@ -155,3 +175,69 @@ func (r *ChatRepo) MarkMessageAsRead(messageID uint, userID string) error {
 		Where("id = ?", messageID).
 		Update("read", true).Error
 }
+
+//
+// ─── ACCOUNT DELETION ──────────────────────────────────────────────────────
+//
+
+// DeletedSenderID — отправитель обезличенных сообщений удалённого аккаунта
+const DeletedSenderID = "deleted"
+
+// DeleteUserData — пользователь выходит из всех чатов, его сообщения остаются у собеседников
+// без текста и вложений; чаты, где не осталось участников, удаляются целиком.
+// Повторный вызов ничего не меняет.
+func (r *ChatRepo) DeleteUserData(userID string) (int64, error) {
+	var affected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var chatIDs []uint
+		if err := tx.Model(&model.Participant{}).
+			Where("user_id = ?", userID).
+			Pluck("chat_id", &chatIDs).Error; err != nil {
+			return err
+		}
+
+		res := tx.Where("user_id = ?", userID).Delete(&model.Participant{})
+		if res.Error != nil {
+			return res.Error
+		}
+		affected += res.RowsAffected
+
+		res = tx.Model(&model.Message{}).
+			Where("sender_id = ?", userID).
+			Updates(map[string]interface{}{
+				"sender_id": DeletedSenderID,
+				"content":   "",
+				"media_url": "",
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		affected += res.RowsAffected
+
+		if len(chatIDs) == 0 {
+			return nil
+		}
+		var emptyChats []uint
+		if err := tx.Model(&model.Chat{}).
+			Where("id IN ?", chatIDs).
+			Where("NOT EXISTS (SELECT 1 FROM participants p WHERE p.chat_id = chats.id)").
+			Pluck("id", &emptyChats).Error; err != nil {
+			return err
+		}
+		if len(emptyChats) == 0 {
+			return nil
+		}
+		if err := tx.Where("chat_id IN ?", emptyChats).Delete(&model.Message{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", emptyChats).Delete(&model.Chat{}).Error
+	})
+	return affected, err
+}
+
+// GetMessagesBySender — все сообщения пользователя во всех чатах (выгрузка данных)
+func (r *ChatRepo) GetMessagesBySender(userID string) ([]model.Message, error) {
+	var msgs []model.Message
+	err := r.db.Where("sender_id = ?", userID).Order("created_at").Find(&msgs).Error
+	return msgs, err
+}
@@ -11,22 +11,56 @@ import (
 	"log"
 	"socialnet/pkg/config"
 	"socialnet/pkg/contextx"
+	"socialnet/pkg/relations"
+	"socialnet/pkg/users"
+	"socialnet/pkg/utils"
 	authpb "socialnet/services/auth/gen"
 	pb "socialnet/services/chat/gen"
 	"socialnet/services/chat/internal/model"
 	"socialnet/services/chat/internal/repos"
 	notificationpb "socialnet/services/notification/gen"
+	userpb "socialnet/services/user/gen"
 	"time"
 )
 
 type ChatService struct {
-	repo    *repos.ChatRepo
-	redis   *redis.Client
-	clients *config.GRPCClients
+	repo      *repos.ChatRepo
+	redis     *redis.Client
+	clients   *config.GRPCClients
+	relations *relations.Checker
 }
 
 func NewChatService(repo *repos.ChatRepo, redis *redis.Client, clients *config.GRPCClients) *ChatService {
-	return &ChatService{repo: repo, redis: redis, clients: clients}
+	return &ChatService{repo: repo, redis: redis, clients: clients, relations: relations.FromClients(clients)}
+}
+
+// memberSummaries — список чатов без имён участников лучше, чем ошибка, поэтому сбой только логируем
+func (s *ChatService) memberSummaries(ctx context.Context, ids []string) map[string]*userpb.UserSummary {
+	userClient, err := s.clients.GetUserClient(users.Addr())
+	if err != nil {
+		log.Printf("⚠️ user client unavailable: %v", err)
+		return nil
+	}
+	members, err := users.Summaries(ctx, userClient, ids)
+	if err != nil {
+		log.Printf("⚠️ failed to load chat members: %v", err)
+		return nil
+	}
+	return members
+}
+
+// checkNotBlocked — нельзя начать чат или писать в личку тому, с кем есть блокировка
+func (s *ChatService) checkNotBlocked(ctx context.Context, userID string, others []string) error {
+	rel, err := s.relations.Check(ctx, userID, others)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to check block: %v", err)
+	}
+	for _, other := range others {
+		if rel.Blocked[other] {
+			return status.Error(codes.PermissionDenied, "you cannot message this user")
+		}
+	}
+	return nil
 }
 
 func (s *ChatService) CreateChat(ctx context.Context, req *pb.CreateChatRequest) (*pb.Chat, error) {
@@ -38,6 +72,9 @@ func (s *ChatService) CreateChat(ctx context.Context, req *pb.CreateChatRequest)
 	if len(req.Participants) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "participants required")
 	}
+	if err := s.checkNotBlocked(ctx, userID, req.Participants); err != nil {
+		return nil, err
+	}
 
 	// =============== PRIVATE CHAT (1-on-1) ===============
 	if len(req.Participants) == 1 {
@@ -106,6 +143,19 @@ func (s *ChatService) CreateChat(ctx context.Context, req *pb.CreateChatRequest)
 func (s *ChatService) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.Message, error) {
 	userID := contextx.GetUserID(ctx)
 
+	// в группе блокировка только глушит уведомления, в личном чате запрещает писать
+	if chat, err := s.repo.GetChatByID(parseUint(req.ChatId)); err == nil && !chat.IsGroup {
+		var others []string
+		for _, p := range chat.Participants {
+			if p.UserID != userID {
+				others = append(others, p.UserID)
+			}
+		}
+		if err := s.checkNotBlocked(ctx, userID, others); err != nil {
+			return nil, err
+		}
+	}
+
 	msg := &model.Message{
 		ChatID:      parseUint(req.ChatId),
 		SenderID:    userID,
@@ -168,10 +218,21 @@ func (s *ChatService) SendMessage(ctx context.Context, req *pb.SendMessageReques
 }
 
 func (s *ChatService) ListMessages(ctx context.Context, req *pb.ListMessagesRequest) (*pb.Messages, error) {
-	msgs, err := s.repo.GetMessages(parseUint(req.ChatId), int(req.Limit), int(req.Offset))
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+	msgs, err := s.repo.GetMessages(parseUint(req.ChatId), after, size)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to load messages: %v", err)
 	}
+	next := ""
+	if len(msgs) > size {
+		msgs = msgs[:size]
+		last := msgs[size-1]
+		next = utils.EncodePageToken(last.CreatedAt, last.ID)
+	}
 
 	var pbMsgs []*pb.Message
 	for _, m := range msgs {
@@ -187,7 +248,7 @@ func (s *ChatService) ListMessages(ctx context.Context, req *pb.ListMessagesRequ
 		})
 	}
 
-	return &pb.Messages{Messages: pbMsgs}, nil
+	return &pb.Messages{Messages: pbMsgs, NextPageToken: next}, nil
 }
 
 func (s *ChatService) SubscribeMessages(
@@ -256,16 +317,36 @@ func parseUint(s string) uint {
 	return id
 }
 
-func (s *ChatService) ListChats(ctx context.Context, req *pb.EmptyRequest) (*pb.Chats, error) {
+func (s *ChatService) ListChats(ctx context.Context, req *pb.ListChatsRequest) (*pb.Chats, error) {
 	userID := contextx.GetUserID(ctx)
 	if userID == "" {
 		return nil, status.Error(codes.Unauthenticated, "missing user id")
 	}
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
 
-	chats, err := s.repo.GetChatsByUser(userID)
+	chats, err := s.repo.ListChatsByUser(userID, after, size)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to load chats: %v", err)
 	}
+	next := ""
+	if len(chats) > size {
+		chats = chats[:size]
+		last := chats[size-1]
+		next = utils.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	// участники всех чатов одним запросом к user сервису
+	var memberIDs []string
+	for _, c := range chats {
+		for _, p := range c.Participants {
+			memberIDs = append(memberIDs, p.UserID)
+		}
+	}
+	members := s.memberSummaries(ctx, memberIDs)
 
 	var pbChats []*pb.Chat
 
@@ -273,8 +354,12 @@ func (s *ChatService) ListChats(ctx context.Context, req *pb.EmptyRequest) (*pb.
 
 		// собрать участников
 		participants := make([]string, 0, len(c.Participants))
+		var summaries []*userpb.UserSummary
 		for _, p := range c.Participants {
 			participants = append(participants, p.UserID)
+			if m, ok := members[p.UserID]; ok {
+				summaries = append(summaries, m)
+			}
 		}
 
 		// получить последнее сообщение
@@ -295,10 +380,11 @@ func (s *ChatService) ListChats(ctx context.Context, req *pb.EmptyRequest) (*pb.
 			LastMessage:  lastText,
 			CreatedAt:    c.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+			Members:      summaries,
 		})
 	}
 
-	return &pb.Chats{Chats: pbChats}, nil
+	return &pb.Chats{Chats: pbChats, NextPageToken: next}, nil
 }
 
 func (s *ChatService) GetChat(ctx context.Context, req *pb.GetChatRequest) (*pb.Chat, error) {
@@ -386,3 +472,61 @@ func (s *ChatService) MarkAsRead(ctx context.Context, req *pb.MarkAsReadRequest)
 
 	return &authpb.Confirmation{Status: "Message marked as read"}, nil
 }
+
+// DeleteUserData — шаг саги удаления аккаунта
+func (s *ChatService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	if userID == "" {
+		return 0, status.Error(codes.InvalidArgument, "user id required")
+	}
+	affected, err := s.repo.DeleteUserData(userID)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to delete chat data: %v", err)
+	}
+	return affected, nil
+}
+
+// ExportUserData — чаты пользователя и его собственные сообщения.
+// Чужие сообщения в архив не попадают: это персональные данные собеседников.
+func (s *ChatService) ExportUserData(ctx context.Context, userID string) (*pb.ExportUserDataResponse, error) {
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id required")
+	}
+
+	chats, err := s.repo.GetChatsByUser(userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load chats: %v", err)
+	}
+	resp := &pb.ExportUserDataResponse{}
+	for _, c := range chats {
+		participants := make([]string, 0, len(c.Participants))
+		for _, p := range c.Participants {
+			participants = append(participants, p.UserID)
+		}
+		resp.Chats = append(resp.Chats, &pb.Chat{
+			Id:           fmt.Sprint(c.ID),
+			Name:         c.Name,
+			IsGroup:      c.IsGroup,
+			Participants: participants,
+			CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	msgs, err := s.repo.GetMessagesBySender(userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load messages: %v", err)
+	}
+	for _, m := range msgs {
+		resp.Messages = append(resp.Messages, &pb.Message{
+			Id:          fmt.Sprint(m.ID),
+			ChatId:      fmt.Sprint(m.ChatID),
+			SenderId:    m.SenderID,
+			Content:     m.Content,
+			ContentType: m.ContentType,
+			MediaUrl:    m.MediaURL,
+			Read:        m.Read,
+			CreatedAt:   m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
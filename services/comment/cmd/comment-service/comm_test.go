@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"os"
+	"socialnet/pkg/contextx"
+	"socialnet/pkg/utils"
 	"socialnet/services/comment/internal/service"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
@@ -18,6 +23,8 @@ import (
 	"socialnet/services/comment/internal/model"
 	"socialnet/services/comment/internal/repos"
 	notificationpb "socialnet/services/notification/gen"
+	postpb "socialnet/services/post/gen"
+	userpb "socialnet/services/user/gen"
 )
 
 // ------------------- GLOBAL -------------------
@@ -55,6 +62,56 @@ func (m *mockNotif) ClearAll(context.Context, *notificationpb.EmptyRequest, ...g
 func (m *mockNotif) StreamNotifications(context.Context, *notificationpb.StreamRequest, ...grpc.CallOption) (notificationpb.NotificationService_StreamNotificationsClient, error) {
 	return nil, nil
 }
+func (m *mockNotif) DeleteUserData(context.Context, *notificationpb.DeleteUserDataRequest, ...grpc.CallOption) (*notificationpb.DeleteUserDataResponse, error) {
+	return &notificationpb.DeleteUserDataResponse{}, nil
+}
+func (m *mockNotif) ExportUserData(context.Context, *notificationpb.ExportUserDataRequest, ...grpc.CallOption) (*notificationpb.ExportUserDataResponse, error) {
+	return &notificationpb.ExportUserDataResponse{}, nil
+}
+
+// ------------------- MOCK POST -------------------
+
+// mockPost — посты из hidden для вызывающего «не существуют», как у закрытого аккаунта
+type mockPost struct {
+	postpb.PostServiceClient
+	hidden map[string]bool
+	down   map[string]bool
+}
+
+func (m *mockPost) GetPost(ctx context.Context, in *postpb.GetPostRequest, opts ...grpc.CallOption) (*postpb.Post, error) {
+	if m.hidden[in.Id] {
+		return nil, status.Error(codes.NotFound, "post not found")
+	}
+	if m.down[in.Id] {
+		return nil, status.Error(codes.Unavailable, "post db is down")
+	}
+	return &postpb.Post{Id: in.Id, UserId: "owner"}, nil
+}
+
+var testPosts = &mockPost{hidden: map[string]bool{}, down: map[string]bool{}}
+
+// ------------------- MOCK USERS -------------------
+
+type mockUsers struct {
+	userpb.UserServiceClient
+	blocked map[string]bool // "user:other"
+}
+
+func (m *mockUsers) CheckRelations(ctx context.Context, in *userpb.CheckRelationsRequest, opts ...grpc.CallOption) (*userpb.CheckRelationsResponse, error) {
+	resp := &userpb.CheckRelationsResponse{}
+	for _, other := range in.OtherIds {
+		if m.blocked[in.UserId+":"+other] {
+			resp.BlockedIds = append(resp.BlockedIds, other)
+		}
+	}
+	return resp, nil
+}
+
+func (m *mockUsers) BatchGetUsers(ctx context.Context, in *userpb.BatchGetUsersRequest, opts ...grpc.CallOption) (*userpb.BatchGetUsersResponse, error) {
+	return &userpb.BatchGetUsersResponse{}, nil
+}
+
+var testUsers = &mockUsers{blocked: map[string]bool{}}
 
 // ------------------- TEST MAIN -------------------
 
@@ -78,6 +135,8 @@ func TestMain(m *testing.M) {
 	// подменяем gRPC клиентов
 	clients := &config.GRPCClients{
 		NotifClient: &mockNotif{},
+		PostClient:  testPosts,
+		UserClient:  testUsers,
 	}
 
 	testSvc = service.NewCommentService(testRepo, clients)
@@ -99,6 +158,21 @@ func TestAddComment(t *testing.T) {
 	assert.Equal(t, "Hello comment", resp.Content)
 }
 
+func TestAddComment_BlockedOrPostUnavailable(t *testing.T) {
+	testUsers.blocked["owner:blocked1"] = true
+	_, err := testSvc.AddComment(ctx, "blocked1", &pb.AddCommentRequest{PostId: "11", Content: "hi"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// автора поста не узнать — блокировку не проверить, комментарий не создаётся
+	testPosts.down["12"] = true
+	_, err = testSvc.AddComment(ctx, "user1", &pb.AddCommentRequest{PostId: "12", Content: "hi"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	var count int64
+	testDB.Model(&model.Comment{}).Where("post_id IN ?", []string{"11", "12"}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
 func TestGetComment(t *testing.T) {
 	c := &model.Comment{
 		PostID:  "20",
@@ -129,11 +203,95 @@ func TestDeleteComment(t *testing.T) {
 	assert.Equal(t, int64(0), count)
 }
 
+func TestDeleteComment_OwnerOrModerator(t *testing.T) {
+	c := &model.Comment{PostID: "31", UserID: "u4", Content: "spam"}
+	_ = testDB.Create(c)
+
+	// чужой комментарий обычный пользователь удалить не может
+	userCtx := context.WithValue(ctx, contextx.RoleKey, utils.RoleUser)
+	err := testSvc.DeleteComment(userCtx, fmt.Sprint(c.ID), "u5")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	modCtx := context.WithValue(ctx, contextx.RoleKey, utils.RoleModerator)
+	assert.NoError(t, testSvc.DeleteComment(modCtx, fmt.Sprint(c.ID), "mod1"))
+
+	var count int64
+	testDB.Model(&model.Comment{}).Where("id = ?", c.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	err = testSvc.DeleteComment(modCtx, fmt.Sprint(c.ID), "mod1")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestListComments(t *testing.T) {
 	_ = testDB.Create(&model.Comment{PostID: "40", UserID: "u1", Content: "A"})
 	_ = testDB.Create(&model.Comment{PostID: "40", UserID: "u2", Content: "B"})
 
-	resp, err := testSvc.ListComments(ctx, "40")
+	resp, err := testSvc.ListComments(ctx, &pb.ListCommentsRequest{PostId: "40"})
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(resp.Comments))
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestListComments_PrivatePostHidden(t *testing.T) {
+	_ = testDB.Create(&model.Comment{PostID: "42", UserID: "u1", Content: "secret"})
+	testPosts.hidden["42"] = true
+	t.Cleanup(func() { delete(testPosts.hidden, "42") })
+
+	_, err := testSvc.ListComments(ctx, &pb.ListCommentsRequest{PostId: "42"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListComments_Pagination(t *testing.T) {
+	// одинаковое время создания: порядок внутри страницы держится на id
+	created := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		_ = testDB.Create(&model.Comment{PostID: "41", UserID: "u1", Content: fmt.Sprint(i), CreatedAt: created})
+	}
+
+	var contents []string
+	req := &pb.ListCommentsRequest{PostId: "41", PageSize: 2}
+	pages := 0
+	for {
+		resp, err := testSvc.ListComments(ctx, req)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(resp.Comments), 2)
+		for _, c := range resp.Comments {
+			contents = append(contents, c.Content)
+		}
+		pages++
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, contents)
+
+	_, err := testSvc.ListComments(ctx, &pb.ListCommentsRequest{PostId: "41", PageToken: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDeleteUserData(t *testing.T) {
+	_ = testDB.Create(&model.Comment{PostID: "50", UserID: "gone", Content: "A"})
+	_ = testDB.Create(&model.Comment{PostID: "51", UserID: "gone", Content: "B"})
+	_ = testDB.Create(&model.Comment{PostID: "50", UserID: "stays", Content: "C"})
+
+	deleted, err := testSvc.DeleteUserData(ctx, "gone")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	var count int64
+	testDB.Model(&model.Comment{}).Where("post_id = ?", "50").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestCountComments(t *testing.T) {
+	_ = testDB.Create(&model.Comment{PostID: "60", UserID: "u1", Content: "A"})
+	_ = testDB.Create(&model.Comment{PostID: "60", UserID: "u2", Content: "B"})
+	_ = testDB.Create(&model.Comment{PostID: "61", UserID: "u1", Content: "C"})
+
+	resp, err := testSvc.CountComments(&pb.CountCommentsRequest{PostIds: []string{"60", "61", "62"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int32{"60": 2, "61": 1}, resp.Counts)
 }
@@ -47,6 +47,12 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.ExtractUserInterceptor(),
+			interceptor.RequireVerifiedEmail(pb.CommentService_AddComment_FullMethodName),
+			interceptor.InternalOnly(
+				pb.CommentService_CountComments_FullMethodName,
+				pb.CommentService_DeleteUserData_FullMethodName,
+				pb.CommentService_ExportUserData_FullMethodName,
+			),
 			interceptor.LoggingInterceptor(),
 		),
 	)
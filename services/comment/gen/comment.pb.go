@@ -11,7 +11,8 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
-	gen "socialnet/services/auth/gen"
+	gen1 "socialnet/services/auth/gen"
+	gen "socialnet/services/user/gen"
 	sync "sync"
 	unsafe "unsafe"
 )
@@ -33,6 +34,7 @@ type Comment struct {
 	LikesCount    int32                  `protobuf:"varint,5,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
 	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Author        *gen.UserSummary       `protobuf:"bytes,8,opt,name=author,proto3" json:"author,omitempty"` // заполняется в ListComments
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -116,9 +118,17 @@ func (x *Comment) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Comment) GetAuthor() *gen.UserSummary {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
 type Comments struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — последняя страница
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -160,6 +170,13 @@ func (x *Comments) GetComments() []*Comment {
 	return nil
 }
 
+func (x *Comments) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // ---- Requests ----
 type AddCommentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -301,9 +318,12 @@ func (x *DeleteCommentRequest) GetId() string {
 	return ""
 }
 
+// page_size по умолчанию 20 (максимум 100), page_token — next_page_token из предыдущего ответа
 type ListCommentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -345,12 +365,293 @@ func (x *ListCommentsRequest) GetPostId() string {
 	return ""
 }
 
+func (x *ListCommentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type CountCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostIds       []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountCommentsRequest) Reset() {
+	*x = CountCommentsRequest{}
+	mi := &file_comment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountCommentsRequest) ProtoMessage() {}
+
+func (x *CountCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountCommentsRequest.ProtoReflect.Descriptor instead.
+func (*CountCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_comment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CountCommentsRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+type CountCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        map[string]int32       `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // посты без комментариев отсутствуют
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountCommentsResponse) Reset() {
+	*x = CountCommentsResponse{}
+	mi := &file_comment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountCommentsResponse) ProtoMessage() {}
+
+func (x *CountCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountCommentsResponse.ProtoReflect.Descriptor instead.
+func (*CountCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_comment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CountCommentsResponse) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+// ----- Account deletion -----
+type DeleteUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataRequest) Reset() {
+	*x = DeleteUserDataRequest{}
+	mi := &file_comment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataRequest) ProtoMessage() {}
+
+func (x *DeleteUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_comment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataResponse) Reset() {
+	*x = DeleteUserDataResponse{}
+	mi := &file_comment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataResponse) ProtoMessage() {}
+
+func (x *DeleteUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_comment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteUserDataResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// ----- Data export -----
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_comment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_comment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_comment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_comment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportUserDataResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
 var File_comment_proto protoreflect.FileDescriptor
 
 const file_comment_proto_rawDesc = "" +
 	"\n" +
 	"\rcomment.proto\x12\acomment\x1a\x1cgoogle/api/annotations.proto\x1a\n" +
-	"auth.proto\"\xc4\x01\n" +
+	"auth.proto\x1a\n" +
+	"user.proto\"\xef\x01\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\apost_id\x18\x02 \x01(\tR\x06postId\x12\x17\n" +
@@ -361,25 +662,48 @@ const file_comment_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"8\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12)\n" +
+	"\x06author\x18\b \x01(\v2\x11.user.UserSummaryR\x06author\"`\n" +
 	"\bComments\x12,\n" +
-	"\bcomments\x18\x01 \x03(\v2\x10.comment.CommentR\bcomments\"F\n" +
+	"\bcomments\x18\x01 \x03(\v2\x10.comment.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"F\n" +
 	"\x11AddCommentRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\"#\n" +
 	"\x11GetCommentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
 	"\x14DeleteCommentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"j\n" +
 	"\x13ListCommentsRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId2\xa2\x03\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"1\n" +
+	"\x14CountCommentsRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\"\x96\x01\n" +
+	"\x15CountCommentsResponse\x12B\n" +
+	"\x06counts\x18\x01 \x03(\v2*.comment.CountCommentsResponse.CountsEntryR\x06counts\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"0\n" +
+	"\x15DeleteUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x16DeleteUserDataResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"F\n" +
+	"\x16ExportUserDataResponse\x12,\n" +
+	"\bcomments\x18\x01 \x03(\v2\x10.comment.CommentR\bcomments2\x98\x05\n" +
 	"\x0eCommentService\x12g\n" +
 	"\n" +
 	"AddComment\x12\x1a.comment.AddCommentRequest\x1a\x10.comment.Comment\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/posts/{post_id}/comments\x12Y\n" +
 	"\n" +
 	"GetComment\x12\x1a.comment.GetCommentRequest\x1a\x10.comment.Comment\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/comments/{id}\x12a\n" +
 	"\rDeleteComment\x12\x1d.comment.DeleteCommentRequest\x1a\x12.auth.Confirmation\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/api/v1/comments/{id}\x12i\n" +
-	"\fListComments\x12\x1c.comment.ListCommentsRequest\x1a\x11.comment.Comments\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/posts/{post_id}/commentsB*Z(socialnet/services/comment/gen;commentpbb\x06proto3"
+	"\fListComments\x12\x1c.comment.ListCommentsRequest\x1a\x11.comment.Comments\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/posts/{post_id}/comments\x12N\n" +
+	"\rCountComments\x12\x1d.comment.CountCommentsRequest\x1a\x1e.comment.CountCommentsResponse\x12Q\n" +
+	"\x0eDeleteUserData\x12\x1e.comment.DeleteUserDataRequest\x1a\x1f.comment.DeleteUserDataResponse\x12Q\n" +
+	"\x0eExportUserData\x12\x1e.comment.ExportUserDataRequest\x1a\x1f.comment.ExportUserDataResponseB*Z(socialnet/services/comment/gen;commentpbb\x06proto3"
 
 var (
 	file_comment_proto_rawDescOnce sync.Once
@@ -393,31 +717,48 @@ func file_comment_proto_rawDescGZIP() []byte {
 	return file_comment_proto_rawDescData
 }
 
-var file_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_comment_proto_goTypes = []any{
-	(*Comment)(nil),              // 0: comment.Comment
-	(*Comments)(nil),             // 1: comment.Comments
-	(*AddCommentRequest)(nil),    // 2: comment.AddCommentRequest
-	(*GetCommentRequest)(nil),    // 3: comment.GetCommentRequest
-	(*DeleteCommentRequest)(nil), // 4: comment.DeleteCommentRequest
-	(*ListCommentsRequest)(nil),  // 5: comment.ListCommentsRequest
-	(*gen.Confirmation)(nil),     // 6: auth.Confirmation
+	(*Comment)(nil),                // 0: comment.Comment
+	(*Comments)(nil),               // 1: comment.Comments
+	(*AddCommentRequest)(nil),      // 2: comment.AddCommentRequest
+	(*GetCommentRequest)(nil),      // 3: comment.GetCommentRequest
+	(*DeleteCommentRequest)(nil),   // 4: comment.DeleteCommentRequest
+	(*ListCommentsRequest)(nil),    // 5: comment.ListCommentsRequest
+	(*CountCommentsRequest)(nil),   // 6: comment.CountCommentsRequest
+	(*CountCommentsResponse)(nil),  // 7: comment.CountCommentsResponse
+	(*DeleteUserDataRequest)(nil),  // 8: comment.DeleteUserDataRequest
+	(*DeleteUserDataResponse)(nil), // 9: comment.DeleteUserDataResponse
+	(*ExportUserDataRequest)(nil),  // 10: comment.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil), // 11: comment.ExportUserDataResponse
+	nil,                            // 12: comment.CountCommentsResponse.CountsEntry
+	(*gen.UserSummary)(nil),        // 13: user.UserSummary
+	(*gen1.Confirmation)(nil),      // 14: auth.Confirmation
 }
 var file_comment_proto_depIdxs = []int32{
-	0, // 0: comment.Comments.comments:type_name -> comment.Comment
-	2, // 1: comment.CommentService.AddComment:input_type -> comment.AddCommentRequest
-	3, // 2: comment.CommentService.GetComment:input_type -> comment.GetCommentRequest
-	4, // 3: comment.CommentService.DeleteComment:input_type -> comment.DeleteCommentRequest
-	5, // 4: comment.CommentService.ListComments:input_type -> comment.ListCommentsRequest
-	0, // 5: comment.CommentService.AddComment:output_type -> comment.Comment
-	0, // 6: comment.CommentService.GetComment:output_type -> comment.Comment
-	6, // 7: comment.CommentService.DeleteComment:output_type -> auth.Confirmation
-	1, // 8: comment.CommentService.ListComments:output_type -> comment.Comments
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	13, // 0: comment.Comment.author:type_name -> user.UserSummary
+	0,  // 1: comment.Comments.comments:type_name -> comment.Comment
+	12, // 2: comment.CountCommentsResponse.counts:type_name -> comment.CountCommentsResponse.CountsEntry
+	0,  // 3: comment.ExportUserDataResponse.comments:type_name -> comment.Comment
+	2,  // 4: comment.CommentService.AddComment:input_type -> comment.AddCommentRequest
+	3,  // 5: comment.CommentService.GetComment:input_type -> comment.GetCommentRequest
+	4,  // 6: comment.CommentService.DeleteComment:input_type -> comment.DeleteCommentRequest
+	5,  // 7: comment.CommentService.ListComments:input_type -> comment.ListCommentsRequest
+	6,  // 8: comment.CommentService.CountComments:input_type -> comment.CountCommentsRequest
+	8,  // 9: comment.CommentService.DeleteUserData:input_type -> comment.DeleteUserDataRequest
+	10, // 10: comment.CommentService.ExportUserData:input_type -> comment.ExportUserDataRequest
+	0,  // 11: comment.CommentService.AddComment:output_type -> comment.Comment
+	0,  // 12: comment.CommentService.GetComment:output_type -> comment.Comment
+	14, // 13: comment.CommentService.DeleteComment:output_type -> auth.Confirmation
+	1,  // 14: comment.CommentService.ListComments:output_type -> comment.Comments
+	7,  // 15: comment.CommentService.CountComments:output_type -> comment.CountCommentsResponse
+	9,  // 16: comment.CommentService.DeleteUserData:output_type -> comment.DeleteUserDataResponse
+	11, // 17: comment.CommentService.ExportUserData:output_type -> comment.ExportUserDataResponse
+	11, // [11:18] is the sub-list for method output_type
+	4,  // [4:11] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_comment_proto_init() }
@@ -431,7 +772,7 @@ func file_comment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_comment_proto_rawDesc), len(file_comment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
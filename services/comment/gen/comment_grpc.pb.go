@@ -20,10 +20,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CommentService_AddComment_FullMethodName    = "/comment.CommentService/AddComment"
-	CommentService_GetComment_FullMethodName    = "/comment.CommentService/GetComment"
-	CommentService_DeleteComment_FullMethodName = "/comment.CommentService/DeleteComment"
-	CommentService_ListComments_FullMethodName  = "/comment.CommentService/ListComments"
+	CommentService_AddComment_FullMethodName     = "/comment.CommentService/AddComment"
+	CommentService_GetComment_FullMethodName     = "/comment.CommentService/GetComment"
+	CommentService_DeleteComment_FullMethodName  = "/comment.CommentService/DeleteComment"
+	CommentService_ListComments_FullMethodName   = "/comment.CommentService/ListComments"
+	CommentService_CountComments_FullMethodName  = "/comment.CommentService/CountComments"
+	CommentService_DeleteUserData_FullMethodName = "/comment.CommentService/DeleteUserData"
+	CommentService_ExportUserData_FullMethodName = "/comment.CommentService/ExportUserData"
 )
 
 // CommentServiceClient is the client API for CommentService service.
@@ -38,6 +41,12 @@ type CommentServiceClient interface {
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
 	// ListComments → GET /api/v1/posts/{post_id}/comments
 	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*Comments, error)
+	// Внутренний вызов: число комментариев у постов одной страницы ленты
+	CountComments(ctx context.Context, in *CountCommentsRequest, opts ...grpc.CallOption) (*CountCommentsResponse, error)
+	// Внутренний вызов саги удаления аккаунта: все комментарии пользователя
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: комментарии пользователя
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
 }
 
 type commentServiceClient struct {
@@ -88,6 +97,36 @@ func (c *commentServiceClient) ListComments(ctx context.Context, in *ListComment
 	return out, nil
 }
 
+func (c *commentServiceClient) CountComments(ctx context.Context, in *CountCommentsRequest, opts ...grpc.CallOption) (*CountCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountCommentsResponse)
+	err := c.cc.Invoke(ctx, CommentService_CountComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserDataResponse)
+	err := c.cc.Invoke(ctx, CommentService_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, CommentService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentServiceServer is the server API for CommentService service.
 // All implementations must embed UnimplementedCommentServiceServer
 // for forward compatibility.
@@ -100,6 +139,12 @@ type CommentServiceServer interface {
 	DeleteComment(context.Context, *DeleteCommentRequest) (*gen.Confirmation, error)
 	// ListComments → GET /api/v1/posts/{post_id}/comments
 	ListComments(context.Context, *ListCommentsRequest) (*Comments, error)
+	// Внутренний вызов: число комментариев у постов одной страницы ленты
+	CountComments(context.Context, *CountCommentsRequest) (*CountCommentsResponse, error)
+	// Внутренний вызов саги удаления аккаунта: все комментарии пользователя
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: комментарии пользователя
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
 	mustEmbedUnimplementedCommentServiceServer()
 }
 
@@ -122,6 +167,15 @@ func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteC
 func (UnimplementedCommentServiceServer) ListComments(context.Context, *ListCommentsRequest) (*Comments, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
 }
+func (UnimplementedCommentServiceServer) CountComments(context.Context, *CountCommentsRequest) (*CountCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountComments not implemented")
+}
+func (UnimplementedCommentServiceServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedCommentServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
 func (UnimplementedCommentServiceServer) testEmbeddedByValue()                        {}
 
@@ -215,6 +269,60 @@ func _CommentService_ListComments_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_CountComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).CountComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_CountComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).CountComments(ctx, req.(*CountCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -238,6 +346,18 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListComments",
 			Handler:    _CommentService_ListComments_Handler,
 		},
+		{
+			MethodName: "CountComments",
+			Handler:    _CommentService_CountComments_Handler,
+		},
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _CommentService_DeleteUserData_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _CommentService_ExportUserData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "comment.proto",
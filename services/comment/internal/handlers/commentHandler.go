@@ -43,5 +43,21 @@ func (h *CommentHandler) DeleteComment(ctx context.Context, req *pb.DeleteCommen
 }
 
 func (h *CommentHandler) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.Comments, error) {
-	return h.service.ListComments(ctx, req.PostId)
+	return h.service.ListComments(ctx, req)
+}
+
+func (h *CommentHandler) CountComments(ctx context.Context, req *pb.CountCommentsRequest) (*pb.CountCommentsResponse, error) {
+	return h.service.CountComments(req)
+}
+
+func (h *CommentHandler) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	deleted, err := h.service.DeleteUserData(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteUserDataResponse{Deleted: deleted}, nil
+}
+
+func (h *CommentHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	return h.service.ExportUserData(ctx, req.UserId)
 }
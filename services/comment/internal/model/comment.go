@@ -5,11 +5,11 @@ import (
 )
 
 type Comment struct {
-	ID         uint      `gorm:"primaryKey"`
-	PostID     string    `gorm:"index;not null"`
+	ID         uint      `gorm:"primaryKey;index:idx_comments_page,priority:3"`
+	PostID     string    `gorm:"index;index:idx_comments_page,priority:1;not null"`
 	UserID     string    `gorm:"index;not null"`
 	Content    string    `gorm:"type:text;not null"`
 	LikesCount int       `gorm:"default:0"`
-	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index:idx_comments_page,priority:2"`
 	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 }
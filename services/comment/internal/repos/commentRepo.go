@@ -2,6 +2,7 @@ package repos
 
 import (
 	"gorm.io/gorm"
+	"socialnet/pkg/utils"
 	"socialnet/services/comment/internal/model"
 )
 
@@ -25,19 +26,53 @@ func (r *CommentRepo) GetComment(id string) (*model.Comment, error) {
 	return &comment, nil
 }
 
-func (r *CommentRepo) DeleteComment(id, userID string) error {
-	return r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Comment{}).Error
+func (r *CommentRepo) DeleteComment(id string) error {
+	return r.db.Where("id = ?", id).Delete(&model.Comment{}).Error
 }
 
-func (r *CommentRepo) ListComments(postID string) ([]model.Comment, error) {
+// ListComments — старые комментарии первыми, keyset по (created_at, id) от курсора.
+// limit+1 строка: лишняя означает, что есть следующая страница.
+func (r *CommentRepo) ListComments(postID string, after *utils.PageCursor, limit int) ([]model.Comment, error) {
 	var comments []model.Comment
-	err := r.db.Where("post_id = ?", postID).Order("created_at asc").Find(&comments).Error
+	q := r.db.Where("post_id = ?", postID).Order("created_at, id").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at > ? OR (created_at = ? AND id > ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	err := q.Find(&comments).Error
 	return comments, err
 }
 
+// CountByPosts — число комментариев по каждому посту одним GROUP BY
+func (r *CommentRepo) CountByPosts(postIDs []string) (map[string]int32, error) {
+	var rows []struct {
+		PostID string
+		Count  int32
+	}
+	if err := r.db.Model(&model.Comment{}).Select("post_id, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).Group("post_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int32, len(rows))
+	for _, row := range rows {
+		counts[row.PostID] = row.Count
+	}
+	return counts, nil
+}
+
 func (r *CommentRepo) UpdateLikesCount(commentID string, count int) error {
 	return r.db.Model(&model.Comment{}).
 		Where("id = ?", commentID).
 		Update("likes_count", count).
 		Error
 }
+
+func (r *CommentRepo) DeleteUserComments(userID string) (int64, error) {
+	res := r.db.Where("user_id = ?", userID).Delete(&model.Comment{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *CommentRepo) ListUserComments(userID string) ([]model.Comment, error) {
+	var comments []model.Comment
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&comments).Error
+	return comments, err
+}
@@ -3,13 +3,19 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
 	"socialnet/pkg/config"
+	"socialnet/pkg/contextx"
+	"socialnet/pkg/relations"
+	"socialnet/pkg/users"
 	"socialnet/pkg/utils"
 	notificationpb "socialnet/services/notification/gen"
 	postpb "socialnet/services/post/gen"
+	userpb "socialnet/services/user/gen"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	pb "socialnet/services/comment/gen"
 	"socialnet/services/comment/internal/model"
@@ -17,16 +23,32 @@ import (
 )
 
 type CommentService struct {
-	repo    *repos.CommentRepo
-	clients *config.GRPCClients
+	repo      *repos.CommentRepo
+	clients   *config.GRPCClients
+	relations *relations.Checker
 }
 
 func NewCommentService(repo *repos.CommentRepo, cl *config.GRPCClients) *CommentService {
-	return &CommentService{repo: repo, clients: cl}
+	return &CommentService{repo: repo, clients: cl, relations: relations.FromClients(cl)}
 }
 
-// Добавление комментария
+// Добавление комментария; заблокированный автором поста комментировать не может
 func (s *CommentService) AddComment(ctx context.Context, userID string, req *pb.AddCommentRequest) (*pb.Comment, error) {
+	// без автора поста проверить блокировку нельзя — отказываем
+	postOwnerID, err := s.postOwner(ctx, userID, req.PostId)
+	if err != nil {
+		return nil, err
+	}
+	if postOwnerID != userID {
+		blocked, err := s.relations.Blocked(ctx, postOwnerID, userID)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to check block: %v", err)
+		}
+		if blocked {
+			return nil, status.Error(codes.PermissionDenied, "you cannot comment on this post")
+		}
+	}
+
 	comment := &model.Comment{
 		PostID:  req.PostId,
 		UserID:  userID,
@@ -39,22 +61,17 @@ func (s *CommentService) AddComment(ctx context.Context, userID string, req *pb.
 
 	id := utils.UintToString(comment.ID)
 
-	postClient, err := s.clients.GetPostClient("localhost:50053")
+	notif, err := s.clients.GetNotifClient("localhost:50057")
 	if err == nil {
-		postResp, err := postClient.GetPost(ctx, &postpb.GetPostRequest{Id: req.PostId})
-		if err == nil {
-			postOwnerID := postResp.UserId
-
-			notif, err := s.clients.GetNotifClient("localhost:50057")
-			if err == nil {
-				_, _ = notif.CreateNotification(ctx, &notificationpb.CreateNotificationRequest{
-					UserId:      postOwnerID,
-					Type:        "comment_created",
-					ReferenceId: id,
-					Content:     fmt.Sprintf("User %s commented on your post", userID),
-				})
-			}
-		}
+		// автор уведомления — для фильтра блокировок и заглушек в notification сервисе
+		ctxWithUser := metadata.AppendToOutgoingContext(ctx, "user-id", userID)
+		_, _ = notif.CreateNotification(ctxWithUser, &notificationpb.CreateNotificationRequest{
+			UserId:      postOwnerID,
+			Type:        "comment_created",
+			ReferenceId: id,
+			Content:     fmt.Sprintf("User %s commented on your post", userID),
+		})
+		s.notifyMentions(ctxWithUser, notif, userID, postOwnerID, comment)
 	}
 
 	return &pb.Comment{
@@ -68,6 +85,30 @@ func (s *CommentService) AddComment(ctx context.Context, userID string, req *pb.
 	}, nil
 }
 
+// notifyMentions — упомянутым через @handle; автор поста уже получил comment_created
+func (s *CommentService) notifyMentions(ctx context.Context, notif notificationpb.NotificationServiceClient, userID, postOwnerID string, comment *model.Comment) {
+	userClient, err := s.clients.GetUserClient(users.Addr())
+	if err != nil {
+		return
+	}
+	mentioned, err := users.Mentioned(ctx, userClient, comment.Content)
+	if err != nil {
+		log.Printf("⚠️ failed to resolve mentions in comment %d: %v", comment.ID, err)
+		return
+	}
+	for _, u := range mentioned {
+		if u.Id == userID || u.Id == postOwnerID {
+			continue
+		}
+		_, _ = notif.CreateNotification(ctx, &notificationpb.CreateNotificationRequest{
+			UserId:      u.Id,
+			Type:        "mention",
+			ReferenceId: utils.UintToString(comment.ID),
+			Content:     fmt.Sprintf("User %s mentioned you in a comment", userID),
+		})
+	}
+}
+
 // Получение комментария
 func (s *CommentService) GetComment(ctx context.Context, id string) (*pb.Comment, error) {
 	c, err := s.repo.GetComment(id)
@@ -87,21 +128,52 @@ func (s *CommentService) GetComment(ctx context.Context, id string) (*pb.Comment
 	}, nil
 }
 
-// Удаление комментария
+// Удаление комментария: автор или модератор / администратор
 func (s *CommentService) DeleteComment(ctx context.Context, id, userID string) error {
-	if err := s.repo.DeleteComment(id, userID); err != nil {
+	c, err := s.repo.GetComment(id)
+	if err != nil {
+		return status.Error(codes.NotFound, "comment not found")
+	}
+	moderated := false
+	if c.UserID != userID {
+		if ok, _ := utils.AuthorizeUser(contextx.GetRole(ctx), utils.RoleModerator, utils.RoleAdmin); !ok {
+			return status.Error(codes.PermissionDenied, "not your comment")
+		}
+		moderated = true
+	}
+
+	if err := s.repo.DeleteComment(id); err != nil {
 		return status.Error(codes.Internal, "failed to delete comment")
 	}
+	if moderated {
+		log.Printf("🛡️ comment %s of user %s removed by %s %s", id, c.UserID, contextx.GetRole(ctx), userID)
+	}
 	return nil
 }
 
-// Список комментариев к посту
-func (s *CommentService) ListComments(ctx context.Context, postID string) (*pb.Comments, error) {
-	comments, err := s.repo.ListComments(postID)
+// Список комментариев к посту, постранично; к посту закрытого аккаунта — только подписчикам
+func (s *CommentService) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.Comments, error) {
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	if _, err := s.postOwner(ctx, contextx.GetUserID(ctx), req.PostId); err != nil {
+		return nil, err
+	}
+	size := utils.PageSize(req.PageSize)
+	comments, err := s.repo.ListComments(req.PostId, after, size)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to get comments")
 	}
 
+	next := ""
+	if len(comments) > size {
+		comments = comments[:size]
+		last := comments[size-1]
+		next = utils.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	authors := s.authors(ctx, comments)
 	resp := make([]*pb.Comment, 0, len(comments))
 	for _, c := range comments {
 		id := utils.UintToString(c.ID)
@@ -113,7 +185,99 @@ func (s *CommentService) ListComments(ctx context.Context, postID string) (*pb.C
 			LikesCount: int32(c.LikesCount),
 			CreatedAt:  c.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:  c.UpdatedAt.Format(time.RFC3339),
+			Author:     authors[c.UserID],
+		})
+	}
+	return &pb.Comments{Comments: resp, NextPageToken: next}, nil
+}
+
+// CountComments — не больше одной страницы постов за вызов
+func (s *CommentService) CountComments(req *pb.CountCommentsRequest) (*pb.CountCommentsResponse, error) {
+	if len(req.PostIds) > utils.MaxPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d post ids", utils.MaxPageSize)
+	}
+	if len(req.PostIds) == 0 {
+		return &pb.CountCommentsResponse{}, nil
+	}
+	counts, err := s.repo.CountByPosts(req.PostIds)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to count comments")
+	}
+	return &pb.CountCommentsResponse{Counts: counts}, nil
+}
+
+// postOwner — автор поста глазами userID. Post сервис сам скрывает посты закрытых
+// аккаунтов, поэтому NotFound — это и «поста нет», и «нет доступа».
+func (s *CommentService) postOwner(ctx context.Context, userID, postID string) (string, error) {
+	postClient, err := s.clients.GetPostClient("localhost:50053")
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "post service unavailable: %v", err)
+	}
+	if userID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "user-id", userID)
+	}
+	post, err := postClient.GetPost(ctx, &postpb.GetPostRequest{Id: postID})
+	if status.Code(err) == codes.NotFound {
+		return "", status.Error(codes.NotFound, "post not found")
+	}
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "failed to load post: %v", err)
+	}
+	return post.UserId, nil
+}
+
+// authors — имена и аватары авторов страницы; если user сервис недоступен, комментарии отдаются без них
+func (s *CommentService) authors(ctx context.Context, comments []model.Comment) map[string]*userpb.UserSummary {
+	ids := make([]string, 0, len(comments))
+	for _, c := range comments {
+		ids = append(ids, c.UserID)
+	}
+	userClient, err := s.clients.GetUserClient(users.Addr())
+	if err != nil {
+		log.Printf("⚠️ user client unavailable: %v", err)
+		return nil
+	}
+	authors, err := users.Summaries(ctx, userClient, ids)
+	if err != nil {
+		log.Printf("⚠️ failed to load comment authors: %v", err)
+		return nil
+	}
+	return authors
+}
+
+// Удаление всех комментариев пользователя (сага удаления аккаунта)
+func (s *CommentService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	if userID == "" {
+		return 0, status.Error(codes.InvalidArgument, "user id required")
+	}
+	deleted, err := s.repo.DeleteUserComments(userID)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "failed to delete comments")
+	}
+	return deleted, nil
+}
+
+// Все комментарии пользователя для архива персональных данных
+func (s *CommentService) ExportUserData(ctx context.Context, userID string) (*pb.ExportUserDataResponse, error) {
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id required")
+	}
+	comments, err := s.repo.ListUserComments(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get comments")
+	}
+
+	resp := make([]*pb.Comment, 0, len(comments))
+	for _, c := range comments {
+		resp = append(resp, &pb.Comment{
+			Id:         utils.UintToString(c.ID),
+			PostId:     c.PostID,
+			UserId:     c.UserID,
+			Content:    c.Content,
+			LikesCount: int32(c.LikesCount),
+			CreatedAt:  c.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:  c.UpdatedAt.Format(time.RFC3339),
 		})
 	}
-	return &pb.Comments{Comments: resp}, nil
+	return &pb.ExportUserDataResponse{Comments: resp}, nil
 }
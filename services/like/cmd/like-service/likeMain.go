@@ -48,6 +48,10 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.ExtractUserInterceptor(),
+			interceptor.InternalOnly(
+				pb.LikeService_DeleteUserData_FullMethodName,
+				pb.LikeService_ExportUserData_FullMethodName,
+			),
 			interceptor.LoggingInterceptor(),
 		),
 	)
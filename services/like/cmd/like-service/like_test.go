@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"os"
 	authpb "socialnet/services/auth/gen"
 	"socialnet/services/like/internal/service"
@@ -13,9 +15,12 @@ import (
 	"gorm.io/gorm"
 
 	"socialnet/pkg/config"
+	pb "socialnet/services/like/gen"
 	"socialnet/services/like/internal/model"
 	"socialnet/services/like/internal/repos"
 	notificationpb "socialnet/services/notification/gen"
+	postpb "socialnet/services/post/gen"
+	userpb "socialnet/services/user/gen"
 )
 
 // =========================================================
@@ -57,6 +62,49 @@ func (m *mockNotif) ClearAll(context.Context, *notificationpb.EmptyRequest, ...g
 func (m *mockNotif) StreamNotifications(context.Context, *notificationpb.StreamRequest, ...grpc.CallOption) (notificationpb.NotificationService_StreamNotificationsClient, error) {
 	return nil, nil
 }
+func (m *mockNotif) DeleteUserData(context.Context, *notificationpb.DeleteUserDataRequest, ...grpc.CallOption) (*notificationpb.DeleteUserDataResponse, error) {
+	return &notificationpb.DeleteUserDataResponse{}, nil
+}
+func (m *mockNotif) ExportUserData(context.Context, *notificationpb.ExportUserDataRequest, ...grpc.CallOption) (*notificationpb.ExportUserDataResponse, error) {
+	return &notificationpb.ExportUserDataResponse{}, nil
+}
+
+// =========================================================
+// MOCK POST / USER CLIENTS
+// =========================================================
+
+// mockPost — у всех постов автор "owner"; posts из down отвечают ошибкой
+type mockPost struct {
+	postpb.PostServiceClient
+	down map[string]bool
+}
+
+func (m *mockPost) GetPost(ctx context.Context, in *postpb.GetPostRequest, opts ...grpc.CallOption) (*postpb.Post, error) {
+	if m.down[in.Id] {
+		return nil, status.Error(codes.Unavailable, "post db is down")
+	}
+	return &postpb.Post{Id: in.Id, UserId: "owner"}, nil
+}
+
+type mockUsers struct {
+	userpb.UserServiceClient
+	blocked map[string]bool // "user:other"
+}
+
+func (m *mockUsers) CheckRelations(ctx context.Context, in *userpb.CheckRelationsRequest, opts ...grpc.CallOption) (*userpb.CheckRelationsResponse, error) {
+	resp := &userpb.CheckRelationsResponse{}
+	for _, other := range in.OtherIds {
+		if m.blocked[in.UserId+":"+other] {
+			resp.BlockedIds = append(resp.BlockedIds, other)
+		}
+	}
+	return resp, nil
+}
+
+var (
+	testPosts = &mockPost{down: map[string]bool{}}
+	testUsers = &mockUsers{blocked: map[string]bool{}}
+)
 
 // =========================================================
 // TEST MAIN
@@ -81,6 +129,8 @@ func TestMain(m *testing.M) {
 
 	clients := &config.GRPCClients{
 		NotifClient: &mockNotif{},
+		PostClient:  testPosts,
+		UserClient:  testUsers,
 	}
 
 	testSvc = service.NewLikeService(testRepo, clients)
@@ -108,6 +158,23 @@ func TestLikePost(t *testing.T) {
 	assert.Equal(t, int32(0), resp2.LikesCount)
 }
 
+// ---------------- TEST LIKE POST: BLOCK CHECK FAILS CLOSED ----------------
+
+func TestLikePost_BlockedOrPostUnavailable(t *testing.T) {
+	testUsers.blocked["owner:blocked1"] = true
+	_, err := testSvc.LikePost(ctx, "blocked1", "post200")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// автора не узнать — лайк не ставится
+	testPosts.down["post201"] = true
+	_, err = testSvc.LikePost(ctx, "user1", "post201")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	var count int64
+	testDB.Model(&model.Like{}).Where("post_id IN ?", []string{"post200", "post201"}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
 // ---------------- TEST LIST POST LIKES ----------------
 
 func TestListPostLikes(t *testing.T) {
@@ -115,7 +182,7 @@ func TestListPostLikes(t *testing.T) {
 	_ = testDB.Create(&model.Like{UserID: "u1", PostID: strPtr("p1")})
 	_ = testDB.Create(&model.Like{UserID: "u2", PostID: strPtr("p1")})
 
-	resp, err := testSvc.ListPostLikes(ctx, "p1")
+	resp, err := testSvc.ListPostLikes(ctx, &pb.ListLikesRequest{Id: "p1"})
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(resp.Likes))
 }
@@ -138,7 +205,7 @@ func TestListCommentLikes(t *testing.T) {
 	_ = testDB.Create(&model.Like{UserID: "u1", CommentID: strPtr("cx")})
 	_ = testDB.Create(&model.Like{UserID: "u2", CommentID: strPtr("cx")})
 
-	resp, err := testSvc.ListCommentLikes(ctx, "cx")
+	resp, err := testSvc.ListCommentLikes(ctx, &pb.ListLikesRequest{Id: "cx"})
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(resp.Likes))
 }
@@ -150,3 +217,32 @@ func TestListCommentLikes(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+// ---------------- TEST DELETE USER DATA ----------------
+
+func TestDeleteUserData(t *testing.T) {
+	_ = testDB.Create(&model.Like{UserID: "gone", PostID: strPtr("p9")})
+	_ = testDB.Create(&model.Like{UserID: "gone", CommentID: strPtr("c9")})
+	_ = testDB.Create(&model.Like{UserID: "u9", PostID: strPtr("p9")})
+
+	deleted, err := testSvc.DeleteUserData(ctx, "gone")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	resp, err := testSvc.ListPostLikes(ctx, &pb.ListLikesRequest{Id: "p9"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Likes))
+}
+
+// ---------------- TEST EXPORT USER DATA ----------------
+
+func TestExportUserData(t *testing.T) {
+	_ = testDB.Create(&model.Like{UserID: "exp", PostID: strPtr("p10")})
+	_ = testDB.Create(&model.Like{UserID: "exp", CommentID: strPtr("c10")})
+
+	resp, err := testSvc.ExportUserData(ctx, "exp")
+	assert.NoError(t, err)
+	assert.Len(t, resp.Likes, 2)
+	assert.Equal(t, "p10", resp.Likes[0].PostId)
+	assert.Equal(t, "c10", resp.Likes[1].CommentId)
+}
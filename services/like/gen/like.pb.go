@@ -292,16 +292,79 @@ func (x *LikeCommentResponse) GetLikesCount() int32 {
 	return 0
 }
 
+// id — поста или комментария; новые лайки первыми,
+// page_size по умолчанию 20 (максимум 100), page_token — next_page_token из предыдущего ответа
+type ListLikesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikesRequest) Reset() {
+	*x = ListLikesRequest{}
+	mi := &file_like_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikesRequest) ProtoMessage() {}
+
+func (x *ListLikesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikesRequest.ProtoReflect.Descriptor instead.
+func (*ListLikesRequest) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListLikesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListLikesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLikesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type ListLikesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Likes         []*Like                `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — последняя страница
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListLikesResponse) Reset() {
 	*x = ListLikesResponse{}
-	mi := &file_like_proto_msgTypes[5]
+	mi := &file_like_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -313,7 +376,7 @@ func (x *ListLikesResponse) String() string {
 func (*ListLikesResponse) ProtoMessage() {}
 
 func (x *ListLikesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_like_proto_msgTypes[5]
+	mi := &file_like_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,7 +389,7 @@ func (x *ListLikesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLikesResponse.ProtoReflect.Descriptor instead.
 func (*ListLikesResponse) Descriptor() ([]byte, []int) {
-	return file_like_proto_rawDescGZIP(), []int{5}
+	return file_like_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListLikesResponse) GetLikes() []*Like {
@@ -336,6 +399,191 @@ func (x *ListLikesResponse) GetLikes() []*Like {
 	return nil
 }
 
+func (x *ListLikesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ----- Account deletion -----
+type DeleteUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataRequest) Reset() {
+	*x = DeleteUserDataRequest{}
+	mi := &file_like_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataRequest) ProtoMessage() {}
+
+func (x *DeleteUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataResponse) Reset() {
+	*x = DeleteUserDataResponse{}
+	mi := &file_like_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataResponse) ProtoMessage() {}
+
+func (x *DeleteUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteUserDataResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// ----- Data export -----
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_like_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         []*Like                `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_like_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportUserDataResponse) GetLikes() []*Like {
+	if x != nil {
+		return x.Likes
+	}
+	return nil
+}
+
 var File_like_proto protoreflect.FileDescriptor
 
 const file_like_proto_rawDesc = "" +
@@ -361,18 +609,35 @@ const file_like_proto_rawDesc = "" +
 	"\x13LikeCommentResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1f\n" +
 	"\vlikes_count\x18\x02 \x01(\x05R\n" +
-	"likesCount\"5\n" +
+	"likesCount\"^\n" +
+	"\x10ListLikesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"]\n" +
 	"\x11ListLikesResponse\x12 \n" +
 	"\x05likes\x18\x01 \x03(\v2\n" +
-	".like.LikeR\x05likes2\xe8\x04\n" +
+	".like.LikeR\x05likes\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"0\n" +
+	"\x15DeleteUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x16DeleteUserDataResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\":\n" +
+	"\x16ExportUserDataResponse\x12 \n" +
+	"\x05likes\x18\x01 \x03(\v2\n" +
+	".like.LikeR\x05likes2\x81\x06\n" +
 	"\vLikeService\x12Z\n" +
 	"\bLikePost\x12\x15.like.LikePostRequest\x1a\x16.like.LikePostResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\"\x17/api/v1/posts/{id}/like\x12\\\n" +
 	"\n" +
 	"UnlikePost\x12\x15.like.LikePostRequest\x1a\x16.like.LikePostResponse\"\x1f\x82\xd3\xe4\x93\x02\x19*\x17/api/v1/posts/{id}/like\x12f\n" +
 	"\vLikeComment\x12\x18.like.LikeCommentRequest\x1a\x19.like.LikeCommentResponse\"\"\x82\xd3\xe4\x93\x02\x1c\"\x1a/api/v1/comments/{id}/like\x12h\n" +
-	"\rUnlikeComment\x12\x18.like.LikeCommentRequest\x1a\x19.like.LikeCommentResponse\"\"\x82\xd3\xe4\x93\x02\x1c*\x1a/api/v1/comments/{id}/like\x12a\n" +
-	"\rListPostLikes\x12\x15.like.LikePostRequest\x1a\x17.like.ListLikesResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/posts/{id}/likes\x12j\n" +
-	"\x10ListCommentLikes\x12\x18.like.LikeCommentRequest\x1a\x17.like.ListLikesResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/comments/{id}/likesB$Z\"socialnet/services/like/gen;likepbb\x06proto3"
+	"\rUnlikeComment\x12\x18.like.LikeCommentRequest\x1a\x19.like.LikeCommentResponse\"\"\x82\xd3\xe4\x93\x02\x1c*\x1a/api/v1/comments/{id}/like\x12b\n" +
+	"\rListPostLikes\x12\x16.like.ListLikesRequest\x1a\x17.like.ListLikesResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/posts/{id}/likes\x12h\n" +
+	"\x10ListCommentLikes\x12\x16.like.ListLikesRequest\x1a\x17.like.ListLikesResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/comments/{id}/likes\x12K\n" +
+	"\x0eDeleteUserData\x12\x1b.like.DeleteUserDataRequest\x1a\x1c.like.DeleteUserDataResponse\x12K\n" +
+	"\x0eExportUserData\x12\x1b.like.ExportUserDataRequest\x1a\x1c.like.ExportUserDataResponseB$Z\"socialnet/services/like/gen;likepbb\x06proto3"
 
 var (
 	file_like_proto_rawDescOnce sync.Once
@@ -386,34 +651,44 @@ func file_like_proto_rawDescGZIP() []byte {
 	return file_like_proto_rawDescData
 }
 
-var file_like_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_like_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_like_proto_goTypes = []any{
-	(*Like)(nil),                // 0: like.Like
-	(*LikePostRequest)(nil),     // 1: like.LikePostRequest
-	(*LikePostResponse)(nil),    // 2: like.LikePostResponse
-	(*LikeCommentRequest)(nil),  // 3: like.LikeCommentRequest
-	(*LikeCommentResponse)(nil), // 4: like.LikeCommentResponse
-	(*ListLikesResponse)(nil),   // 5: like.ListLikesResponse
+	(*Like)(nil),                   // 0: like.Like
+	(*LikePostRequest)(nil),        // 1: like.LikePostRequest
+	(*LikePostResponse)(nil),       // 2: like.LikePostResponse
+	(*LikeCommentRequest)(nil),     // 3: like.LikeCommentRequest
+	(*LikeCommentResponse)(nil),    // 4: like.LikeCommentResponse
+	(*ListLikesRequest)(nil),       // 5: like.ListLikesRequest
+	(*ListLikesResponse)(nil),      // 6: like.ListLikesResponse
+	(*DeleteUserDataRequest)(nil),  // 7: like.DeleteUserDataRequest
+	(*DeleteUserDataResponse)(nil), // 8: like.DeleteUserDataResponse
+	(*ExportUserDataRequest)(nil),  // 9: like.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil), // 10: like.ExportUserDataResponse
 }
 var file_like_proto_depIdxs = []int32{
-	0, // 0: like.ListLikesResponse.likes:type_name -> like.Like
-	1, // 1: like.LikeService.LikePost:input_type -> like.LikePostRequest
-	1, // 2: like.LikeService.UnlikePost:input_type -> like.LikePostRequest
-	3, // 3: like.LikeService.LikeComment:input_type -> like.LikeCommentRequest
-	3, // 4: like.LikeService.UnlikeComment:input_type -> like.LikeCommentRequest
-	1, // 5: like.LikeService.ListPostLikes:input_type -> like.LikePostRequest
-	3, // 6: like.LikeService.ListCommentLikes:input_type -> like.LikeCommentRequest
-	2, // 7: like.LikeService.LikePost:output_type -> like.LikePostResponse
-	2, // 8: like.LikeService.UnlikePost:output_type -> like.LikePostResponse
-	4, // 9: like.LikeService.LikeComment:output_type -> like.LikeCommentResponse
-	4, // 10: like.LikeService.UnlikeComment:output_type -> like.LikeCommentResponse
-	5, // 11: like.LikeService.ListPostLikes:output_type -> like.ListLikesResponse
-	5, // 12: like.LikeService.ListCommentLikes:output_type -> like.ListLikesResponse
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: like.ListLikesResponse.likes:type_name -> like.Like
+	0,  // 1: like.ExportUserDataResponse.likes:type_name -> like.Like
+	1,  // 2: like.LikeService.LikePost:input_type -> like.LikePostRequest
+	1,  // 3: like.LikeService.UnlikePost:input_type -> like.LikePostRequest
+	3,  // 4: like.LikeService.LikeComment:input_type -> like.LikeCommentRequest
+	3,  // 5: like.LikeService.UnlikeComment:input_type -> like.LikeCommentRequest
+	5,  // 6: like.LikeService.ListPostLikes:input_type -> like.ListLikesRequest
+	5,  // 7: like.LikeService.ListCommentLikes:input_type -> like.ListLikesRequest
+	7,  // 8: like.LikeService.DeleteUserData:input_type -> like.DeleteUserDataRequest
+	9,  // 9: like.LikeService.ExportUserData:input_type -> like.ExportUserDataRequest
+	2,  // 10: like.LikeService.LikePost:output_type -> like.LikePostResponse
+	2,  // 11: like.LikeService.UnlikePost:output_type -> like.LikePostResponse
+	4,  // 12: like.LikeService.LikeComment:output_type -> like.LikeCommentResponse
+	4,  // 13: like.LikeService.UnlikeComment:output_type -> like.LikeCommentResponse
+	6,  // 14: like.LikeService.ListPostLikes:output_type -> like.ListLikesResponse
+	6,  // 15: like.LikeService.ListCommentLikes:output_type -> like.ListLikesResponse
+	8,  // 16: like.LikeService.DeleteUserData:output_type -> like.DeleteUserDataResponse
+	10, // 17: like.LikeService.ExportUserData:output_type -> like.ExportUserDataResponse
+	10, // [10:18] is the sub-list for method output_type
+	2,  // [2:10] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_like_proto_init() }
@@ -427,7 +702,7 @@ func file_like_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_like_proto_rawDesc), len(file_like_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
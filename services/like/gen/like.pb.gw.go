@@ -191,9 +191,11 @@ func local_request_LikeService_UnlikeComment_0(ctx context.Context, marshaler ru
 	return msg, metadata, err
 }
 
+var filter_LikeService_ListPostLikes_0 = &utilities.DoubleArray{Encoding: map[string]int{"id": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
+
 func request_LikeService_ListPostLikes_0(ctx context.Context, marshaler runtime.Marshaler, client LikeServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var (
-		protoReq LikePostRequest
+		protoReq ListLikesRequest
 		metadata runtime.ServerMetadata
 		err      error
 	)
@@ -208,13 +210,19 @@ func request_LikeService_ListPostLikes_0(ctx context.Context, marshaler runtime.
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "id", err)
 	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_LikeService_ListPostLikes_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 	msg, err := client.ListPostLikes(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
 
 func local_request_LikeService_ListPostLikes_0(ctx context.Context, marshaler runtime.Marshaler, server LikeServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var (
-		protoReq LikePostRequest
+		protoReq ListLikesRequest
 		metadata runtime.ServerMetadata
 		err      error
 	)
@@ -226,13 +234,21 @@ func local_request_LikeService_ListPostLikes_0(ctx context.Context, marshaler ru
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "id", err)
 	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_LikeService_ListPostLikes_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 	msg, err := server.ListPostLikes(ctx, &protoReq)
 	return msg, metadata, err
 }
 
+var filter_LikeService_ListCommentLikes_0 = &utilities.DoubleArray{Encoding: map[string]int{"id": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
+
 func request_LikeService_ListCommentLikes_0(ctx context.Context, marshaler runtime.Marshaler, client LikeServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var (
-		protoReq LikeCommentRequest
+		protoReq ListLikesRequest
 		metadata runtime.ServerMetadata
 		err      error
 	)
@@ -247,13 +263,19 @@ func request_LikeService_ListCommentLikes_0(ctx context.Context, marshaler runti
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "id", err)
 	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_LikeService_ListCommentLikes_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 	msg, err := client.ListCommentLikes(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
 
 func local_request_LikeService_ListCommentLikes_0(ctx context.Context, marshaler runtime.Marshaler, server LikeServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var (
-		protoReq LikeCommentRequest
+		protoReq ListLikesRequest
 		metadata runtime.ServerMetadata
 		err      error
 	)
@@ -265,6 +287,12 @@ func local_request_LikeService_ListCommentLikes_0(ctx context.Context, marshaler
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "id", err)
 	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_LikeService_ListCommentLikes_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 	msg, err := server.ListCommentLikes(ctx, &protoReq)
 	return msg, metadata, err
 }
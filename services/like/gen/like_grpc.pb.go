@@ -25,6 +25,8 @@ const (
 	LikeService_UnlikeComment_FullMethodName    = "/like.LikeService/UnlikeComment"
 	LikeService_ListPostLikes_FullMethodName    = "/like.LikeService/ListPostLikes"
 	LikeService_ListCommentLikes_FullMethodName = "/like.LikeService/ListCommentLikes"
+	LikeService_DeleteUserData_FullMethodName   = "/like.LikeService/DeleteUserData"
+	LikeService_ExportUserData_FullMethodName   = "/like.LikeService/ExportUserData"
 )
 
 // LikeServiceClient is the client API for LikeService service.
@@ -40,9 +42,13 @@ type LikeServiceClient interface {
 	// UnlikeComment → DELETE /api/v1/comments/{id}/like
 	UnlikeComment(ctx context.Context, in *LikeCommentRequest, opts ...grpc.CallOption) (*LikeCommentResponse, error)
 	// ListPostLikes → GET /api/v1/posts/{id}/likes
-	ListPostLikes(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*ListLikesResponse, error)
+	ListPostLikes(ctx context.Context, in *ListLikesRequest, opts ...grpc.CallOption) (*ListLikesResponse, error)
 	// ListCommentLikes → GET /api/v1/comments/{id}/likes
-	ListCommentLikes(ctx context.Context, in *LikeCommentRequest, opts ...grpc.CallOption) (*ListLikesResponse, error)
+	ListCommentLikes(ctx context.Context, in *ListLikesRequest, opts ...grpc.CallOption) (*ListLikesResponse, error)
+	// Внутренний вызов саги удаления аккаунта: снимает все лайки пользователя
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: лайки пользователя
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
 }
 
 type likeServiceClient struct {
@@ -93,7 +99,7 @@ func (c *likeServiceClient) UnlikeComment(ctx context.Context, in *LikeCommentRe
 	return out, nil
 }
 
-func (c *likeServiceClient) ListPostLikes(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*ListLikesResponse, error) {
+func (c *likeServiceClient) ListPostLikes(ctx context.Context, in *ListLikesRequest, opts ...grpc.CallOption) (*ListLikesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListLikesResponse)
 	err := c.cc.Invoke(ctx, LikeService_ListPostLikes_FullMethodName, in, out, cOpts...)
@@ -103,7 +109,7 @@ func (c *likeServiceClient) ListPostLikes(ctx context.Context, in *LikePostReque
 	return out, nil
 }
 
-func (c *likeServiceClient) ListCommentLikes(ctx context.Context, in *LikeCommentRequest, opts ...grpc.CallOption) (*ListLikesResponse, error) {
+func (c *likeServiceClient) ListCommentLikes(ctx context.Context, in *ListLikesRequest, opts ...grpc.CallOption) (*ListLikesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListLikesResponse)
 	err := c.cc.Invoke(ctx, LikeService_ListCommentLikes_FullMethodName, in, out, cOpts...)
@@ -113,6 +119,26 @@ func (c *likeServiceClient) ListCommentLikes(ctx context.Context, in *LikeCommen
 	return out, nil
 }
 
+func (c *likeServiceClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserDataResponse)
+	err := c.cc.Invoke(ctx, LikeService_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *likeServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, LikeService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LikeServiceServer is the server API for LikeService service.
 // All implementations must embed UnimplementedLikeServiceServer
 // for forward compatibility.
@@ -126,9 +152,13 @@ type LikeServiceServer interface {
 	// UnlikeComment → DELETE /api/v1/comments/{id}/like
 	UnlikeComment(context.Context, *LikeCommentRequest) (*LikeCommentResponse, error)
 	// ListPostLikes → GET /api/v1/posts/{id}/likes
-	ListPostLikes(context.Context, *LikePostRequest) (*ListLikesResponse, error)
+	ListPostLikes(context.Context, *ListLikesRequest) (*ListLikesResponse, error)
 	// ListCommentLikes → GET /api/v1/comments/{id}/likes
-	ListCommentLikes(context.Context, *LikeCommentRequest) (*ListLikesResponse, error)
+	ListCommentLikes(context.Context, *ListLikesRequest) (*ListLikesResponse, error)
+	// Внутренний вызов саги удаления аккаунта: снимает все лайки пользователя
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: лайки пользователя
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
 	mustEmbedUnimplementedLikeServiceServer()
 }
 
@@ -151,12 +181,18 @@ func (UnimplementedLikeServiceServer) LikeComment(context.Context, *LikeCommentR
 func (UnimplementedLikeServiceServer) UnlikeComment(context.Context, *LikeCommentRequest) (*LikeCommentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnlikeComment not implemented")
 }
-func (UnimplementedLikeServiceServer) ListPostLikes(context.Context, *LikePostRequest) (*ListLikesResponse, error) {
+func (UnimplementedLikeServiceServer) ListPostLikes(context.Context, *ListLikesRequest) (*ListLikesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPostLikes not implemented")
 }
-func (UnimplementedLikeServiceServer) ListCommentLikes(context.Context, *LikeCommentRequest) (*ListLikesResponse, error) {
+func (UnimplementedLikeServiceServer) ListCommentLikes(context.Context, *ListLikesRequest) (*ListLikesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCommentLikes not implemented")
 }
+func (UnimplementedLikeServiceServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedLikeServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedLikeServiceServer) mustEmbedUnimplementedLikeServiceServer() {}
 func (UnimplementedLikeServiceServer) testEmbeddedByValue()                     {}
 
@@ -251,7 +287,7 @@ func _LikeService_UnlikeComment_Handler(srv interface{}, ctx context.Context, de
 }
 
 func _LikeService_ListPostLikes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LikePostRequest)
+	in := new(ListLikesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -263,13 +299,13 @@ func _LikeService_ListPostLikes_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: LikeService_ListPostLikes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LikeServiceServer).ListPostLikes(ctx, req.(*LikePostRequest))
+		return srv.(LikeServiceServer).ListPostLikes(ctx, req.(*ListLikesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _LikeService_ListCommentLikes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LikeCommentRequest)
+	in := new(ListLikesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -281,7 +317,43 @@ func _LikeService_ListCommentLikes_Handler(srv interface{}, ctx context.Context,
 		FullMethod: LikeService_ListCommentLikes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LikeServiceServer).ListCommentLikes(ctx, req.(*LikeCommentRequest))
+		return srv.(LikeServiceServer).ListCommentLikes(ctx, req.(*ListLikesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LikeService_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LikeService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -317,6 +389,14 @@ var LikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCommentLikes",
 			Handler:    _LikeService_ListCommentLikes_Handler,
 		},
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _LikeService_DeleteUserData_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _LikeService_ExportUserData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "like.proto",
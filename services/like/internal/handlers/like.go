@@ -50,10 +50,22 @@ func (h *LikeHandler) UnlikeComment(ctx context.Context, req *pb.LikeCommentRequ
 	return h.service.UnlikeComment(ctx, userID, req.Id)
 }
 
-func (h *LikeHandler) ListPostLikes(ctx context.Context, req *pb.LikePostRequest) (*pb.ListLikesResponse, error) {
-	return h.service.ListPostLikes(ctx, req.Id)
+func (h *LikeHandler) ListPostLikes(ctx context.Context, req *pb.ListLikesRequest) (*pb.ListLikesResponse, error) {
+	return h.service.ListPostLikes(ctx, req)
 }
 
-func (h *LikeHandler) ListCommentLikes(ctx context.Context, req *pb.LikeCommentRequest) (*pb.ListLikesResponse, error) {
-	return h.service.ListCommentLikes(ctx, req.Id)
+func (h *LikeHandler) ListCommentLikes(ctx context.Context, req *pb.ListLikesRequest) (*pb.ListLikesResponse, error) {
+	return h.service.ListCommentLikes(ctx, req)
+}
+
+func (h *LikeHandler) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	deleted, err := h.service.DeleteUserData(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteUserDataResponse{Deleted: deleted}, nil
+}
+
+func (h *LikeHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	return h.service.ExportUserData(ctx, req.UserId)
 }
@@ -5,9 +5,9 @@ import (
 )
 
 type Like struct {
-	ID        uint      `gorm:"primaryKey"`
+	ID        uint      `gorm:"primaryKey;index:idx_likes_post_page,priority:3;index:idx_likes_comment_page,priority:3"`
 	UserID    string    `gorm:"index;not null"`
-	PostID    *string   `gorm:"index"`
-	CommentID *string   `gorm:"index"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	PostID    *string   `gorm:"index;index:idx_likes_post_page,priority:1"`
+	CommentID *string   `gorm:"index;index:idx_likes_comment_page,priority:1"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index:idx_likes_post_page,priority:2;index:idx_likes_comment_page,priority:2"`
 }
@@ -2,6 +2,7 @@ package repos
 
 import (
 	"gorm.io/gorm"
+	"socialnet/pkg/utils"
 	"socialnet/services/like/internal/model"
 )
 
@@ -29,9 +30,18 @@ func (r *LikeRepo) CountPostLikes(postID string) (int64, error) {
 	return count, err
 }
 
-func (r *LikeRepo) ListPostLikes(postID string) ([]model.Like, error) {
+func (r *LikeRepo) ListPostLikes(postID string, after *utils.PageCursor, limit int) ([]model.Like, error) {
+	return r.listLikes("post_id", postID, after, limit)
+}
+
+// listLikes — новые первыми, keyset по (created_at, id); limit+1 строка — признак следующей страницы
+func (r *LikeRepo) listLikes(column, id string, after *utils.PageCursor, limit int) ([]model.Like, error) {
 	var likes []model.Like
-	err := r.db.Where("post_id = ?", postID).Find(&likes).Error
+	q := r.db.Where(column+" = ?", id).Order("created_at DESC, id DESC").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at < ? OR (created_at = ? AND id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	err := q.Find(&likes).Error
 	return likes, err
 }
 func (r *LikeRepo) HasUserLikedPost(userID, postID string) (bool, error) {
@@ -64,8 +74,18 @@ func (r *LikeRepo) CountCommentLikes(commentID string) (int64, error) {
 	return count, err
 }
 
-func (r *LikeRepo) ListCommentLikes(commentID string) ([]model.Like, error) {
+func (r *LikeRepo) ListCommentLikes(commentID string, after *utils.PageCursor, limit int) ([]model.Like, error) {
+	return r.listLikes("comment_id", commentID, after, limit)
+}
+
+// DeleteUserLikes — лайки и постов, и комментариев
+func (r *LikeRepo) DeleteUserLikes(userID string) (int64, error) {
+	res := r.db.Where("user_id = ?", userID).Delete(&model.Like{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *LikeRepo) ListUserLikes(userID string) ([]model.Like, error) {
 	var likes []model.Like
-	err := r.db.Where("comment_id = ?", commentID).Find(&likes).Error
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&likes).Error
 	return likes, err
 }
@@ -7,20 +7,23 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"socialnet/pkg/config"
+	"socialnet/pkg/relations"
 	"socialnet/pkg/utils"
 	pb "socialnet/services/like/gen"
+	"socialnet/services/like/internal/model"
 	"socialnet/services/like/internal/repos"
 	notificationpb "socialnet/services/notification/gen"
 	postpb "socialnet/services/post/gen"
 )
 
 type LikeService struct {
-	repo    *repos.LikeRepo
-	clients *config.GRPCClients
+	repo      *repos.LikeRepo
+	clients   *config.GRPCClients
+	relations *relations.Checker
 }
 
 func NewLikeService(repo *repos.LikeRepo, cl *config.GRPCClients) *LikeService {
-	return &LikeService{repo: repo, clients: cl}
+	return &LikeService{repo: repo, clients: cl, relations: relations.FromClients(cl)}
 }
 
 // POST LIKES
@@ -37,29 +40,47 @@ func (s *LikeService) LikePost(ctx context.Context, userID, postID string) (*pb.
 		return &pb.LikePostResponse{Status: "unliked", LikesCount: int32(count)}, nil
 	}
 
+	// заблокированный автором поста лайкать не может, снять старый лайк — может.
+	// Без автора поста блокировку не проверить, поэтому ошибка загрузки — отказ.
+	postClient, err := s.clients.GetPostClient("localhost:50053")
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "post service unavailable: %v", err)
+	}
+	postResp, err := postClient.GetPost(metadata.AppendToOutgoingContext(ctx, "user-id", userID),
+		&postpb.GetPostRequest{Id: postID})
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Error(codes.NotFound, "post not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to load post: %v", err)
+	}
+	postOwnerID := postResp.UserId
+	if postOwnerID != userID {
+		blocked, err := s.relations.Blocked(ctx, postOwnerID, userID)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to check block: %v", err)
+		}
+		if blocked {
+			return nil, status.Error(codes.PermissionDenied, "you cannot like this post")
+		}
+	}
+
 	if err := s.repo.LikePost(userID, postID); err != nil {
 		return nil, status.Errorf(codes.AlreadyExists, "post already liked or invalid: %v", err)
 	}
-	postClient, err := s.clients.GetPostClient("localhost:50053")
+	notifClient, err := s.clients.GetNotifClient("localhost:50057")
 	if err == nil {
-		postResp, err := postClient.GetPost(ctx, &postpb.GetPostRequest{Id: postID})
-		if err == nil {
-			postOwnerID := postResp.UserId
-			notifClient, err := s.clients.GetNotifClient("localhost:50057")
-			if err == nil {
-
-				md := metadata.New(map[string]string{"user-id": userID})
-				ctxWithUser := metadata.NewOutgoingContext(ctx, md)
-
-				_, _ = notifClient.CreateNotification(ctxWithUser,
-					&notificationpb.CreateNotificationRequest{
-						UserId:      postOwnerID,
-						Type:        "post_liked",
-						ReferenceId: postID,
-						Content:     fmt.Sprintf("Your post was liked by %s", userID),
-					})
-			}
-		}
+
+		md := metadata.New(map[string]string{"user-id": userID})
+		ctxWithUser := metadata.NewOutgoingContext(ctx, md)
+
+		_, _ = notifClient.CreateNotification(ctxWithUser,
+			&notificationpb.CreateNotificationRequest{
+				UserId:      postOwnerID,
+				Type:        "post_liked",
+				ReferenceId: postID,
+				Content:     fmt.Sprintf("Your post was liked by %s", userID),
+			})
 	}
 
 	count, _ := s.repo.CountPostLikes(postID)
@@ -75,11 +96,17 @@ func (s *LikeService) UnlikePost(ctx context.Context, userID, postID string) (*p
 	return &pb.LikePostResponse{Status: "unliked", LikesCount: int32(count)}, nil
 }
 
-func (s *LikeService) ListPostLikes(ctx context.Context, postID string) (*pb.ListLikesResponse, error) {
-	likes, err := s.repo.ListPostLikes(postID)
+func (s *LikeService) ListPostLikes(ctx context.Context, req *pb.ListLikesRequest) (*pb.ListLikesResponse, error) {
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+	likes, err := s.repo.ListPostLikes(req.Id, after, size)
 	if err != nil {
 		return nil, err
 	}
+	likes, next := likesPage(likes, size)
 	res := make([]*pb.Like, 0, len(likes))
 	for _, l := range likes {
 		id := utils.UintToString(l.ID)
@@ -90,7 +117,17 @@ func (s *LikeService) ListPostLikes(ctx context.Context, postID string) (*pb.Lis
 			CreatedAt: l.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		})
 	}
-	return &pb.ListLikesResponse{Likes: res}, nil
+	return &pb.ListLikesResponse{Likes: res, NextPageToken: next}, nil
+}
+
+// likesPage — репозиторий отдаёт size+1 лайков; лишний означает, что есть следующая страница
+func likesPage(likes []model.Like, size int) ([]model.Like, string) {
+	if len(likes) <= size {
+		return likes, ""
+	}
+	likes = likes[:size]
+	last := likes[size-1]
+	return likes, utils.EncodePageToken(last.CreatedAt, last.ID)
 }
 
 // COMMENT LIKES
@@ -110,11 +147,17 @@ func (s *LikeService) UnlikeComment(ctx context.Context, userID, commentID strin
 	return &pb.LikeCommentResponse{Status: "unliked", LikesCount: int32(count)}, nil
 }
 
-func (s *LikeService) ListCommentLikes(ctx context.Context, commentID string) (*pb.ListLikesResponse, error) {
-	likes, err := s.repo.ListCommentLikes(commentID)
+func (s *LikeService) ListCommentLikes(ctx context.Context, req *pb.ListLikesRequest) (*pb.ListLikesResponse, error) {
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+	likes, err := s.repo.ListCommentLikes(req.Id, after, size)
 	if err != nil {
 		return nil, err
 	}
+	likes, next := likesPage(likes, size)
 	res := make([]*pb.Like, 0, len(likes))
 	for _, l := range likes {
 		id := utils.UintToString(l.ID)
@@ -125,5 +168,45 @@ func (s *LikeService) ListCommentLikes(ctx context.Context, commentID string) (*
 			CreatedAt: l.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		})
 	}
-	return &pb.ListLikesResponse{Likes: res}, nil
+	return &pb.ListLikesResponse{Likes: res, NextPageToken: next}, nil
+}
+
+// DeleteUserData — сага удаления аккаунта; счётчики считаются по таблице лайков, пересчитывать нечего
+func (s *LikeService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	if userID == "" {
+		return 0, status.Error(codes.InvalidArgument, "user id required")
+	}
+	deleted, err := s.repo.DeleteUserLikes(userID)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to delete likes: %v", err)
+	}
+	return deleted, nil
+}
+
+// ExportUserData — лайки постов и комментариев для архива персональных данных
+func (s *LikeService) ExportUserData(ctx context.Context, userID string) (*pb.ExportUserDataResponse, error) {
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id required")
+	}
+	likes, err := s.repo.ListUserLikes(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load likes")
+	}
+
+	res := make([]*pb.Like, 0, len(likes))
+	for _, l := range likes {
+		like := &pb.Like{
+			Id:        utils.UintToString(l.ID),
+			UserId:    l.UserID,
+			CreatedAt: l.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if l.PostID != nil {
+			like.PostId = *l.PostID
+		}
+		if l.CommentID != nil {
+			like.CommentId = *l.CommentID
+		}
+		res = append(res, like)
+	}
+	return &pb.ExportUserDataResponse{Likes: res}, nil
 }
@@ -10,8 +10,10 @@ import (
 	"log"
 	"net"
 	"os"
+	"socialnet/pkg/config"
 	"socialnet/pkg/interceptor"
 	"socialnet/pkg/logger"
+	"socialnet/pkg/relations"
 	pb "socialnet/services/notification/gen"
 	"socialnet/services/notification/internal/handlers"
 	"socialnet/services/notification/internal/model"
@@ -47,7 +49,9 @@ func main() {
 	}
 
 	repo := repos.NewNotificationRepo(db)
-	svc := service.NewNotificationService(repo, rdb)
+	clients := &config.GRPCClients{}
+	defer clients.CloseAll()
+	svc := service.NewNotificationService(repo, rdb, relations.FromClients(clients))
 	handler := handlers.NewNotificationHandler(svc)
 
 	lis, err := net.Listen("tcp", port)
@@ -58,6 +62,10 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.ExtractUserInterceptor(),
+			interceptor.InternalOnly(
+				pb.NotificationService_DeleteUserData_FullMethodName,
+				pb.NotificationService_ExportUserData_FullMethodName,
+			),
 			interceptor.LoggingInterceptor(),
 		),
 	)
@@ -5,18 +5,23 @@ import (
 	"fmt"
 	"os"
 	"socialnet/pkg/contextx"
+	"socialnet/pkg/relations"
 	"socialnet/services/notification/internal/service"
 	"testing"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	pb "socialnet/services/notification/gen"
 	"socialnet/services/notification/internal/model"
 	"socialnet/services/notification/internal/repos"
+	userpb "socialnet/services/user/gen"
 )
 
 var (
@@ -58,6 +63,29 @@ func (s *fakeStream) Send(n *pb.Notification) error {
 	return nil
 }
 
+//
+// ------------------------- FAKE USERS -------------------------
+//
+
+type fakeUsers struct {
+	userpb.UserServiceClient
+	blocked map[string]bool // "user:other"
+	muted   map[string]bool
+}
+
+func (f *fakeUsers) CheckRelations(ctx context.Context, in *userpb.CheckRelationsRequest, opts ...grpc.CallOption) (*userpb.CheckRelationsResponse, error) {
+	resp := &userpb.CheckRelationsResponse{}
+	for _, other := range in.OtherIds {
+		if f.blocked[in.UserId+":"+other] {
+			resp.BlockedIds = append(resp.BlockedIds, other)
+		}
+		if f.muted[in.UserId+":"+other] {
+			resp.MutedIds = append(resp.MutedIds, other)
+		}
+	}
+	return resp, nil
+}
+
 //
 // ------------------------- TEST MAIN -------------------------
 //
@@ -84,7 +112,9 @@ func TestMain(m *testing.M) {
 		Addr: "localhost:6379",
 	})
 
-	testSvc = service.NewNotificationService(testRepo, rdb)
+	users := &fakeUsers{blocked: map[string]bool{"2:7": true}, muted: map[string]bool{"2:8": true}}
+	testSvc = service.NewNotificationService(testRepo, rdb,
+		relations.NewChecker(func() (userpb.UserServiceClient, error) { return users, nil }))
 
 	os.Exit(m.Run())
 }
@@ -108,6 +138,21 @@ func TestCreateNotification(t *testing.T) {
 	assert.Equal(t, int64(1), count)
 }
 
+func TestCreateNotification_BlockedAndMutedActorsSuppressed(t *testing.T) {
+	for _, actor := range []string{"7", "8", "9"} {
+		actorCtx := context.WithValue(context.Background(), contextx.UserIDKey, actor)
+		_, err := testSvc.CreateNotification(actorCtx, &pb.CreateNotificationRequest{
+			UserId: "2", Type: "follow", ReferenceId: actor,
+		})
+		assert.NoError(t, err)
+	}
+
+	// дошло только от 9: 7 заблокирован, 8 заглушён
+	var refs []string
+	testDB.Model(&model.Notification{}).Where("user_id = ?", "2").Pluck("reference_id", &refs)
+	assert.Equal(t, []string{"9"}, refs)
+}
+
 func TestListNotifications(t *testing.T) {
 	_ = testDB.Create(&model.Notification{
 		UserID:    "1",
@@ -118,12 +163,38 @@ func TestListNotifications(t *testing.T) {
 	ctx = context.WithValue(context.Background(), contextx.UserIDKey, "1")
 
 	resp, err := testSvc.ListNotifications(ctx, &pb.ListNotificationsRequest{
-		Limit:  1,
-		Offset: 1,
-		Filter: "unread",
+		PageSize: 1,
+		Filter:   "unread",
 	})
 	assert.NoError(t, err)
-	assert.True(t, len(resp.Notifications) >= 1)
+	assert.Len(t, resp.Notifications, 1)
+}
+
+func TestListNotifications_Pagination(t *testing.T) {
+	// одинаковое время создания: порядок внутри страницы держится на id
+	created := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		_ = testDB.Create(&model.Notification{UserID: "77", Type: "msg", Content: fmt.Sprint(i), CreatedAt: created})
+	}
+	userCtx := context.WithValue(context.Background(), contextx.UserIDKey, "77")
+
+	var contents []string
+	req := &pb.ListNotificationsRequest{PageSize: 2}
+	for {
+		resp, err := testSvc.ListNotifications(userCtx, req)
+		assert.NoError(t, err)
+		for _, n := range resp.Notifications {
+			contents = append(contents, n.Content)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Equal(t, []string{"4", "3", "2", "1", "0"}, contents)
+
+	_, err := testSvc.ListNotifications(userCtx, &pb.ListNotificationsRequest{PageToken: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 func TestMarkAsRead(t *testing.T) {
@@ -182,3 +253,17 @@ func TestClearAll(t *testing.T) {
 	testDB.Model(&model.Notification{}).Where("user_id=?", "777").Count(&count)
 	assert.Equal(t, int64(0), count)
 }
+
+func TestDeleteUserData(t *testing.T) {
+	testDB.Create(&model.Notification{UserID: "888"})
+	testDB.Create(&model.Notification{UserID: "888"})
+	testDB.Create(&model.Notification{UserID: "889"})
+
+	deleted, err := testSvc.DeleteUserData(context.Background(), "888")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	var count int64
+	testDB.Model(&model.Notification{}).Where("user_id=?", "889").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
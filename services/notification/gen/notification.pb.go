@@ -118,6 +118,7 @@ func (x *Notification) GetCreatedAt() string {
 type Notifications struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — последняя страница
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,6 +160,13 @@ func (x *Notifications) GetNotifications() []*Notification {
 	return nil
 }
 
+func (x *Notifications) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type EmptyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -195,11 +203,13 @@ func (*EmptyRequest) Descriptor() ([]byte, []int) {
 	return file_notification_proto_rawDescGZIP(), []int{2}
 }
 
+// новые первыми; page_size по умолчанию 20 (максимум 100),
+// page_token — next_page_token из предыдущего ответа
 type ListNotificationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Filter        string                 `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"` // optional: "unread", "all", "system"
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -241,18 +251,18 @@ func (x *ListNotificationsRequest) GetFilter() string {
 	return ""
 }
 
-func (x *ListNotificationsRequest) GetLimit() int32 {
+func (x *ListNotificationsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Limit
+		return x.PageSize
 	}
 	return 0
 }
 
-func (x *ListNotificationsRequest) GetOffset() int32 {
+func (x *ListNotificationsRequest) GetPageToken() string {
 	if x != nil {
-		return x.Offset
+		return x.PageToken
 	}
-	return 0
+	return ""
 }
 
 type MarkAsReadRequest struct {
@@ -455,6 +465,184 @@ func (x *CreateNotificationRequest) GetContent() string {
 	return ""
 }
 
+// ----- Account deletion -----
+type DeleteUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataRequest) Reset() {
+	*x = DeleteUserDataRequest{}
+	mi := &file_notification_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataRequest) ProtoMessage() {}
+
+func (x *DeleteUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataResponse) Reset() {
+	*x = DeleteUserDataResponse{}
+	mi := &file_notification_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataResponse) ProtoMessage() {}
+
+func (x *DeleteUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteUserDataResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// ----- Data export -----
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_notification_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_notification_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportUserDataResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
 var File_notification_proto protoreflect.FileDescriptor
 
 const file_notification_proto_rawDesc = "" +
@@ -469,14 +657,16 @@ const file_notification_proto_rawDesc = "" +
 	"\acontent\x18\x05 \x01(\tR\acontent\x12\x12\n" +
 	"\x04read\x18\x06 \x01(\bR\x04read\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt\"Q\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"y\n" +
 	"\rNotifications\x12@\n" +
-	"\rnotifications\x18\x01 \x03(\v2\x1a.notification.NotificationR\rnotifications\"\x0e\n" +
-	"\fEmptyRequest\"`\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1a.notification.NotificationR\rnotifications\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x0e\n" +
+	"\fEmptyRequest\"\x89\x01\n" +
 	"\x18ListNotificationsRequest\x12\x16\n" +
-	"\x06filter\x18\x01 \x01(\tR\x06filter\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"#\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageTokenJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04R\x05limitR\x06offset\"#\n" +
 	"\x11MarkAsReadRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
 	"\x19DeleteNotificationRequest\x12\x0e\n" +
@@ -487,7 +677,15 @@ const file_notification_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
 	"\freference_id\x18\x03 \x01(\tR\vreferenceId\x12\x18\n" +
-	"\acontent\x18\x04 \x01(\tR\acontent2\x8c\x06\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\"0\n" +
+	"\x15DeleteUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x16DeleteUserDataResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"Z\n" +
+	"\x16ExportUserDataResponse\x12@\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1a.notification.NotificationR\rnotifications2\xc6\a\n" +
 	"\x13NotificationService\x12w\n" +
 	"\x11ListNotifications\x12&.notification.ListNotificationsRequest\x1a\x1b.notification.Notifications\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/notifications\x12m\n" +
 	"\n" +
@@ -496,7 +694,9 @@ const file_notification_proto_rawDesc = "" +
 	"\x12DeleteNotification\x12'.notification.DeleteNotificationRequest\x1a\x12.auth.Confirmation\"\"\x82\xd3\xe4\x93\x02\x1c*\x1a/api/v1/notifications/{id}\x12_\n" +
 	"\bClearAll\x12\x1a.notification.EmptyRequest\x1a\x12.auth.Confirmation\"#\x82\xd3\xe4\x93\x02\x1d*\x1b/api/v1/notifications/clear\x12z\n" +
 	"\x12CreateNotification\x12'.notification.CreateNotificationRequest\x1a\x12.auth.Confirmation\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/notifications/create\x12P\n" +
-	"\x13StreamNotifications\x12\x1b.notification.StreamRequest\x1a\x1a.notification.Notification0\x01B4Z2socialnet/services/notification/gen;notificationpbb\x06proto3"
+	"\x13StreamNotifications\x12\x1b.notification.StreamRequest\x1a\x1a.notification.Notification0\x01\x12[\n" +
+	"\x0eDeleteUserData\x12#.notification.DeleteUserDataRequest\x1a$.notification.DeleteUserDataResponse\x12[\n" +
+	"\x0eExportUserData\x12#.notification.ExportUserDataRequest\x1a$.notification.ExportUserDataResponseB4Z2socialnet/services/notification/gen;notificationpbb\x06proto3"
 
 var (
 	file_notification_proto_rawDescOnce sync.Once
@@ -510,7 +710,7 @@ func file_notification_proto_rawDescGZIP() []byte {
 	return file_notification_proto_rawDescData
 }
 
-var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_notification_proto_goTypes = []any{
 	(*Notification)(nil),              // 0: notification.Notification
 	(*Notifications)(nil),             // 1: notification.Notifications
@@ -520,29 +720,38 @@ var file_notification_proto_goTypes = []any{
 	(*DeleteNotificationRequest)(nil), // 5: notification.DeleteNotificationRequest
 	(*StreamRequest)(nil),             // 6: notification.StreamRequest
 	(*CreateNotificationRequest)(nil), // 7: notification.CreateNotificationRequest
-	(*gen.Confirmation)(nil),          // 8: auth.Confirmation
+	(*DeleteUserDataRequest)(nil),     // 8: notification.DeleteUserDataRequest
+	(*DeleteUserDataResponse)(nil),    // 9: notification.DeleteUserDataResponse
+	(*ExportUserDataRequest)(nil),     // 10: notification.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),    // 11: notification.ExportUserDataResponse
+	(*gen.Confirmation)(nil),          // 12: auth.Confirmation
 }
 var file_notification_proto_depIdxs = []int32{
-	0, // 0: notification.Notifications.notifications:type_name -> notification.Notification
-	3, // 1: notification.NotificationService.ListNotifications:input_type -> notification.ListNotificationsRequest
-	4, // 2: notification.NotificationService.MarkAsRead:input_type -> notification.MarkAsReadRequest
-	2, // 3: notification.NotificationService.MarkAllAsRead:input_type -> notification.EmptyRequest
-	5, // 4: notification.NotificationService.DeleteNotification:input_type -> notification.DeleteNotificationRequest
-	2, // 5: notification.NotificationService.ClearAll:input_type -> notification.EmptyRequest
-	7, // 6: notification.NotificationService.CreateNotification:input_type -> notification.CreateNotificationRequest
-	6, // 7: notification.NotificationService.StreamNotifications:input_type -> notification.StreamRequest
-	1, // 8: notification.NotificationService.ListNotifications:output_type -> notification.Notifications
-	8, // 9: notification.NotificationService.MarkAsRead:output_type -> auth.Confirmation
-	8, // 10: notification.NotificationService.MarkAllAsRead:output_type -> auth.Confirmation
-	8, // 11: notification.NotificationService.DeleteNotification:output_type -> auth.Confirmation
-	8, // 12: notification.NotificationService.ClearAll:output_type -> auth.Confirmation
-	8, // 13: notification.NotificationService.CreateNotification:output_type -> auth.Confirmation
-	0, // 14: notification.NotificationService.StreamNotifications:output_type -> notification.Notification
-	8, // [8:15] is the sub-list for method output_type
-	1, // [1:8] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: notification.Notifications.notifications:type_name -> notification.Notification
+	0,  // 1: notification.ExportUserDataResponse.notifications:type_name -> notification.Notification
+	3,  // 2: notification.NotificationService.ListNotifications:input_type -> notification.ListNotificationsRequest
+	4,  // 3: notification.NotificationService.MarkAsRead:input_type -> notification.MarkAsReadRequest
+	2,  // 4: notification.NotificationService.MarkAllAsRead:input_type -> notification.EmptyRequest
+	5,  // 5: notification.NotificationService.DeleteNotification:input_type -> notification.DeleteNotificationRequest
+	2,  // 6: notification.NotificationService.ClearAll:input_type -> notification.EmptyRequest
+	7,  // 7: notification.NotificationService.CreateNotification:input_type -> notification.CreateNotificationRequest
+	6,  // 8: notification.NotificationService.StreamNotifications:input_type -> notification.StreamRequest
+	8,  // 9: notification.NotificationService.DeleteUserData:input_type -> notification.DeleteUserDataRequest
+	10, // 10: notification.NotificationService.ExportUserData:input_type -> notification.ExportUserDataRequest
+	1,  // 11: notification.NotificationService.ListNotifications:output_type -> notification.Notifications
+	12, // 12: notification.NotificationService.MarkAsRead:output_type -> auth.Confirmation
+	12, // 13: notification.NotificationService.MarkAllAsRead:output_type -> auth.Confirmation
+	12, // 14: notification.NotificationService.DeleteNotification:output_type -> auth.Confirmation
+	12, // 15: notification.NotificationService.ClearAll:output_type -> auth.Confirmation
+	12, // 16: notification.NotificationService.CreateNotification:output_type -> auth.Confirmation
+	0,  // 17: notification.NotificationService.StreamNotifications:output_type -> notification.Notification
+	9,  // 18: notification.NotificationService.DeleteUserData:output_type -> notification.DeleteUserDataResponse
+	11, // 19: notification.NotificationService.ExportUserData:output_type -> notification.ExportUserDataResponse
+	11, // [11:20] is the sub-list for method output_type
+	2,  // [2:11] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_notification_proto_init() }
@@ -556,7 +765,7 @@ func file_notification_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_proto_rawDesc), len(file_notification_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,8 @@ const (
 	NotificationService_ClearAll_FullMethodName            = "/notification.NotificationService/ClearAll"
 	NotificationService_CreateNotification_FullMethodName  = "/notification.NotificationService/CreateNotification"
 	NotificationService_StreamNotifications_FullMethodName = "/notification.NotificationService/StreamNotifications"
+	NotificationService_DeleteUserData_FullMethodName      = "/notification.NotificationService/DeleteUserData"
+	NotificationService_ExportUserData_FullMethodName      = "/notification.NotificationService/ExportUserData"
 )
 
 // NotificationServiceClient is the client API for NotificationService service.
@@ -46,6 +48,10 @@ type NotificationServiceClient interface {
 	CreateNotification(ctx context.Context, in *CreateNotificationRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
 	// --- Поток уведомлений в реальном времени (через gRPC stream) ---
 	StreamNotifications(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error)
+	// Внутренний вызов саги удаления аккаунта: уведомления пользователя
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: уведомления пользователя
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
 }
 
 type notificationServiceClient struct {
@@ -135,6 +141,26 @@ func (c *notificationServiceClient) StreamNotifications(ctx context.Context, in
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NotificationService_StreamNotificationsClient = grpc.ServerStreamingClient[Notification]
 
+func (c *notificationServiceClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserDataResponse)
+	err := c.cc.Invoke(ctx, NotificationService_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, NotificationService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility.
@@ -152,6 +178,10 @@ type NotificationServiceServer interface {
 	CreateNotification(context.Context, *CreateNotificationRequest) (*gen.Confirmation, error)
 	// --- Поток уведомлений в реальном времени (через gRPC stream) ---
 	StreamNotifications(*StreamRequest, grpc.ServerStreamingServer[Notification]) error
+	// Внутренний вызов саги удаления аккаунта: уведомления пользователя
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: уведомления пользователя
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -183,6 +213,12 @@ func (UnimplementedNotificationServiceServer) CreateNotification(context.Context
 func (UnimplementedNotificationServiceServer) StreamNotifications(*StreamRequest, grpc.ServerStreamingServer[Notification]) error {
 	return status.Error(codes.Unimplemented, "method StreamNotifications not implemented")
 }
+func (UnimplementedNotificationServiceServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedNotificationServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 func (UnimplementedNotificationServiceServer) testEmbeddedByValue()                             {}
 
@@ -323,6 +359,42 @@ func _NotificationService_StreamNotifications_Handler(srv interface{}, stream gr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NotificationService_StreamNotificationsServer = grpc.ServerStreamingServer[Notification]
 
+func _NotificationService_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -354,6 +426,14 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateNotification",
 			Handler:    _NotificationService_CreateNotification_Handler,
 		},
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _NotificationService_DeleteUserData_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _NotificationService_ExportUserData_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
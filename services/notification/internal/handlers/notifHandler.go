@@ -43,3 +43,15 @@ func (h *NotificationHandler) StreamNotifications(req *pb.StreamRequest, stream
 func (h *NotificationHandler) CreateNotification(ctx context.Context, in *pb.CreateNotificationRequest) (*authpb.Confirmation, error) {
 	return h.svc.CreateNotification(ctx, in)
 }
+
+func (h *NotificationHandler) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	deleted, err := h.svc.DeleteUserData(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteUserDataResponse{Deleted: deleted}, nil
+}
+
+func (h *NotificationHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	return h.svc.ExportUserData(ctx, req.UserId)
+}
@@ -3,13 +3,13 @@ package model
 import "time"
 
 type Notification struct {
-	ID          uint   `gorm:"primaryKey"`
-	UserID      string `gorm:"index"`
+	ID          uint   `gorm:"primaryKey;index:idx_notifications_page,priority:3"`
+	UserID      string `gorm:"index;index:idx_notifications_page,priority:1"`
 	Type        string
 	ReferenceID string
 	Content     string
-	Read        bool `gorm:"default:false"`
-	CreatedAt   time.Time
+	Read        bool      `gorm:"default:false"`
+	CreatedAt   time.Time `gorm:"index:idx_notifications_page,priority:2"`
 }
 
 type RedisNotification struct {
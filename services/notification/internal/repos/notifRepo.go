@@ -2,6 +2,7 @@ package repos
 
 import (
 	"gorm.io/gorm"
+	"socialnet/pkg/utils"
 	"socialnet/services/notification/internal/model"
 )
 
@@ -17,21 +18,21 @@ func (r *NotificationRepo) Save(n *model.Notification) error {
 	return r.DB.Create(n).Error
 }
 
-func (r *NotificationRepo) List(userID, filter string, limit, offset int) ([]model.Notification, error) {
+// List — новые первыми, keyset по (created_at, id) от курсора.
+// limit+1 строка: лишняя означает, что есть следующая страница.
+func (r *NotificationRepo) List(userID, filter string, after *utils.PageCursor, limit int) ([]model.Notification, error) {
 	query := r.DB.Where("user_id = ?", userID)
 
 	if filter == "unread" {
 		query = query.Where("read = ?", false)
 	}
-
-	if limit == 0 {
-		limit = -1
+	if after != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
 	}
 
 	var notifications []model.Notification
-	err := query.Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit + 1).
 		Find(&notifications).Error
 
 	return notifications, err
@@ -52,3 +53,14 @@ func (r *NotificationRepo) Delete(id string) error {
 func (r *NotificationRepo) ClearAll(userID string) error {
 	return r.DB.Where("user_id = ?", userID).Delete(&model.Notification{}).Error
 }
+
+func (r *NotificationRepo) DeleteUserNotifications(userID string) (int64, error) {
+	res := r.DB.Where("user_id = ?", userID).Delete(&model.Notification{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *NotificationRepo) ListAll(userID string) ([]model.Notification, error) {
+	var notes []model.Notification
+	err := r.DB.Where("user_id = ?", userID).Order("created_at").Find(&notes).Error
+	return notes, err
+}
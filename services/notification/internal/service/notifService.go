@@ -9,6 +9,8 @@ import (
 	"google.golang.org/grpc/status"
 	"log"
 	"socialnet/pkg/contextx"
+	"socialnet/pkg/relations"
+	"socialnet/pkg/utils"
 	authpb "socialnet/services/auth/gen"
 	pb "socialnet/services/notification/gen"
 	"socialnet/services/notification/internal/model"
@@ -17,12 +19,13 @@ import (
 )
 
 type NotificationService struct {
-	repo  *repos.NotificationRepo
-	redis *redis.Client
+	repo      *repos.NotificationRepo
+	redis     *redis.Client
+	relations *relations.Checker
 }
 
-func NewNotificationService(repo *repos.NotificationRepo, redis *redis.Client) *NotificationService {
-	return &NotificationService{repo: repo, redis: redis}
+func NewNotificationService(repo *repos.NotificationRepo, redis *redis.Client, relations *relations.Checker) *NotificationService {
+	return &NotificationService{repo: repo, redis: redis, relations: relations}
 }
 
 // ListNotifications  Получить список уведомлений
@@ -32,10 +35,22 @@ func (s *NotificationService) ListNotifications(ctx context.Context, req *pb.Lis
 		return nil, status.Error(codes.Unauthenticated, "missing user id")
 	}
 
-	notes, err := s.repo.List(userID, req.Filter, int(req.Limit), int(req.Offset))
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+
+	notes, err := s.repo.List(userID, req.Filter, after, size)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get notifications: %v", err)
 	}
+	next := ""
+	if len(notes) > size {
+		notes = notes[:size]
+		last := notes[size-1]
+		next = utils.EncodePageToken(last.CreatedAt, last.ID)
+	}
 
 	var pbNotes []*pb.Notification
 	for _, n := range notes {
@@ -50,7 +65,7 @@ func (s *NotificationService) ListNotifications(ctx context.Context, req *pb.Lis
 		})
 	}
 
-	return &pb.Notifications{Notifications: pbNotes}, nil
+	return &pb.Notifications{Notifications: pbNotes, NextPageToken: next}, nil
 }
 
 // ✅ Отметить уведомление как прочитанное
@@ -92,6 +107,17 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *pb.Cr
 		return nil, status.Error(codes.InvalidArgument, "user_id required")
 	}
 
+	// от заблокированных и заглушённых уведомления не приходят;
+	// если user сервис недоступен, лучше доставить лишнее, чем потерять
+	if actor := contextx.GetUserID(ctx); actor != "" && actor != req.UserId {
+		rel, err := s.relations.Check(ctx, req.UserId, []string{actor})
+		if err != nil {
+			log.Printf("⚠️ relations check failed for %s -> %s: %v", actor, req.UserId, err)
+		} else if rel.Hidden(actor) {
+			return &authpb.Confirmation{Status: "Notification suppressed"}, nil
+		}
+	}
+
 	n := &model.Notification{
 		UserID:      req.UserId,
 		Type:        req.Type,
@@ -189,3 +215,40 @@ func (s *NotificationService) PublishNotification(ctx context.Context, n *model.
 	})
 	s.redis.Publish(ctx, fmt.Sprintf("notifications:%s", n.UserID), data)
 }
+
+// DeleteUserData — сага удаления аккаунта
+func (s *NotificationService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	if userID == "" {
+		return 0, status.Error(codes.InvalidArgument, "user id required")
+	}
+	deleted, err := s.repo.DeleteUserNotifications(userID)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "failed to delete notifications")
+	}
+	return deleted, nil
+}
+
+// ExportUserData — все уведомления пользователя без фильтров и пагинации
+func (s *NotificationService) ExportUserData(ctx context.Context, userID string) (*pb.ExportUserDataResponse, error) {
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id required")
+	}
+	notes, err := s.repo.ListAll(userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get notifications: %v", err)
+	}
+
+	pbNotes := make([]*pb.Notification, 0, len(notes))
+	for _, n := range notes {
+		pbNotes = append(pbNotes, &pb.Notification{
+			Id:          fmt.Sprint(n.ID),
+			UserId:      n.UserID,
+			Type:        n.Type,
+			ReferenceId: n.ReferenceID,
+			Content:     n.Content,
+			Read:        n.Read,
+			CreatedAt:   n.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return &pb.ExportUserDataResponse{Notifications: pbNotes}, nil
+}
@@ -56,6 +56,14 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.ExtractUserInterceptor(),
+			interceptor.RequireVerifiedEmail(
+				pb.PostService_CreatePost_FullMethodName,
+				pb.PostService_UpdatePost_FullMethodName,
+			),
+			interceptor.InternalOnly(
+				pb.PostService_DeleteUserData_FullMethodName,
+				pb.PostService_ExportUserData_FullMethodName,
+			),
 			interceptor.LoggingInterceptor(),
 		),
 	)
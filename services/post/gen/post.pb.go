@@ -35,6 +35,7 @@ type Post struct {
 	CommentsCount int32                  `protobuf:"varint,6,opt,name=comments_count,json=commentsCount,proto3" json:"comments_count,omitempty"`
 	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Author        *gen.UserSummary       `protobuf:"bytes,9,opt,name=author,proto3" json:"author,omitempty"` // заполняется в ленте
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,9 +126,17 @@ func (x *Post) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Post) GetAuthor() *gen.UserSummary {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
 type Posts struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Posts         []*Post                `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — последняя страница
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,6 +178,13 @@ func (x *Posts) GetPosts() []*Post {
 	return nil
 }
 
+func (x *Posts) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // ---Requests---
 type CreatePostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -230,15 +246,70 @@ func (x *CreatePostRequest) GetFileName() string {
 	return ""
 }
 
+// page_size по умолчанию 20 (максимум 100), page_token — next_page_token из предыдущего ответа
+type ListPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostsRequest) Reset() {
+	*x = ListPostsRequest{}
+	mi := &file_post_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsRequest) ProtoMessage() {}
+
+func (x *ListPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostsRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type GetFeedRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetFeedRequest) Reset() {
 	*x = GetFeedRequest{}
-	mi := &file_post_proto_msgTypes[3]
+	mi := &file_post_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -250,7 +321,7 @@ func (x *GetFeedRequest) String() string {
 func (*GetFeedRequest) ProtoMessage() {}
 
 func (x *GetFeedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[3]
+	mi := &file_post_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +334,21 @@ func (x *GetFeedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFeedRequest.ProtoReflect.Descriptor instead.
 func (*GetFeedRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{3}
+	return file_post_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetFeedRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetFeedRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
 }
 
 type GetPostRequest struct {
@@ -275,7 +360,7 @@ type GetPostRequest struct {
 
 func (x *GetPostRequest) Reset() {
 	*x = GetPostRequest{}
-	mi := &file_post_proto_msgTypes[4]
+	mi := &file_post_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -287,7 +372,7 @@ func (x *GetPostRequest) String() string {
 func (*GetPostRequest) ProtoMessage() {}
 
 func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[4]
+	mi := &file_post_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +385,7 @@ func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
 func (*GetPostRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{4}
+	return file_post_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetPostRequest) GetId() string {
@@ -322,7 +407,7 @@ type UpdatePostRequest struct {
 
 func (x *UpdatePostRequest) Reset() {
 	*x = UpdatePostRequest{}
-	mi := &file_post_proto_msgTypes[5]
+	mi := &file_post_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +419,7 @@ func (x *UpdatePostRequest) String() string {
 func (*UpdatePostRequest) ProtoMessage() {}
 
 func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[5]
+	mi := &file_post_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +432,7 @@ func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePostRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePostRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{5}
+	return file_post_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdatePostRequest) GetId() string {
@@ -387,7 +472,7 @@ type DeletePostRequest struct {
 
 func (x *DeletePostRequest) Reset() {
 	*x = DeletePostRequest{}
-	mi := &file_post_proto_msgTypes[6]
+	mi := &file_post_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -399,7 +484,7 @@ func (x *DeletePostRequest) String() string {
 func (*DeletePostRequest) ProtoMessage() {}
 
 func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[6]
+	mi := &file_post_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +497,7 @@ func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
 func (*DeletePostRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{6}
+	return file_post_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeletePostRequest) GetId() string {
@@ -425,13 +510,15 @@ func (x *DeletePostRequest) GetId() string {
 type UserPostsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UserPostsRequest) Reset() {
 	*x = UserPostsRequest{}
-	mi := &file_post_proto_msgTypes[7]
+	mi := &file_post_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -443,7 +530,7 @@ func (x *UserPostsRequest) String() string {
 func (*UserPostsRequest) ProtoMessage() {}
 
 func (x *UserPostsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[7]
+	mi := &file_post_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -456,7 +543,7 @@ func (x *UserPostsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserPostsRequest.ProtoReflect.Descriptor instead.
 func (*UserPostsRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{7}
+	return file_post_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UserPostsRequest) GetId() string {
@@ -466,6 +553,198 @@ func (x *UserPostsRequest) GetId() string {
 	return ""
 }
 
+func (x *UserPostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *UserPostsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ----- Account deletion -----
+type DeleteUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataRequest) Reset() {
+	*x = DeleteUserDataRequest{}
+	mi := &file_post_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataRequest) ProtoMessage() {}
+
+func (x *DeleteUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataResponse) Reset() {
+	*x = DeleteUserDataResponse{}
+	mi := &file_post_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataResponse) ProtoMessage() {}
+
+func (x *DeleteUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteUserDataResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// ----- Data export -----
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_post_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*Post                `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_post_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportUserDataResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
 var File_post_proto protoreflect.FileDescriptor
 
 const file_post_proto_rawDesc = "" +
@@ -473,7 +752,7 @@ const file_post_proto_rawDesc = "" +
 	"\n" +
 	"post.proto\x12\x04post\x1a\x1cgoogle/api/annotations.proto\x1a\n" +
 	"auth.proto\x1a\n" +
-	"user.proto\"\xec\x01\n" +
+	"user.proto\"\x97\x02\n" +
 	"\x04Post\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
@@ -485,15 +764,24 @@ const file_post_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\")\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12)\n" +
+	"\x06author\x18\t \x01(\v2\x11.user.UserSummaryR\x06author\"Q\n" +
 	"\x05Posts\x12 \n" +
 	"\x05posts\x18\x01 \x03(\v2\n" +
-	".post.PostR\x05posts\"_\n" +
+	".post.PostR\x05posts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"_\n" +
 	"\x11CreatePostRequest\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x14\n" +
 	"\x05image\x18\x02 \x01(\fR\x05image\x12\x1a\n" +
-	"\bfileName\x18\x03 \x01(\tR\bfileName\"\x10\n" +
-	"\x0eGetFeedRequest\" \n" +
+	"\bfileName\x18\x03 \x01(\tR\bfileName\"N\n" +
+	"\x10ListPostsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"L\n" +
+	"\x0eGetFeedRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\" \n" +
 	"\x0eGetPostRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
 	"\x11UpdatePostRequest\x12\x0e\n" +
@@ -502,9 +790,21 @@ const file_post_proto_rawDesc = "" +
 	"\x05image\x18\x03 \x01(\fR\x05image\x12\x1a\n" +
 	"\bfileName\x18\x04 \x01(\tR\bfileName\"#\n" +
 	"\x11DeletePostRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"^\n" +
 	"\x10UserPostsRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xb5\x04\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"0\n" +
+	"\x15DeleteUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x16DeleteUserDataResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\":\n" +
+	"\x16ExportUserDataResponse\x12 \n" +
+	"\x05posts\x18\x01 \x03(\v2\n" +
+	".post.PostR\x05posts2\xd3\x05\n" +
 	"\vPostService\x12K\n" +
 	"\n" +
 	"CreatePost\x12\x17.post.CreatePostRequest\x1a\n" +
@@ -514,10 +814,12 @@ const file_post_proto_rawDesc = "" +
 	"\n" +
 	"UpdatePost\x12\x17.post.UpdatePostRequest\x1a\x12.auth.Confirmation\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/api/v1/posts/{id}\x12U\n" +
 	"\n" +
-	"DeletePost\x12\x17.post.DeletePostRequest\x1a\x12.auth.Confirmation\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/posts/{id}\x12C\n" +
-	"\tListPosts\x12\x12.user.EmptyRequest\x1a\v.post.Posts\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/posts\x12V\n" +
+	"DeletePost\x12\x17.post.DeletePostRequest\x1a\x12.auth.Confirmation\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/posts/{id}\x12G\n" +
+	"\tListPosts\x12\x16.post.ListPostsRequest\x1a\v.post.Posts\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/posts\x12V\n" +
 	"\rListUserPosts\x12\x16.post.UserPostsRequest\x1a\v.post.Posts\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/users/{id}/posts\x12B\n" +
-	"\aGetFeed\x12\x14.post.GetFeedRequest\x1a\v.post.Posts\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/feedB$Z\"socialnet/services/post/gen;postpbb\x06proto3"
+	"\aGetFeed\x12\x14.post.GetFeedRequest\x1a\v.post.Posts\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/feed\x12K\n" +
+	"\x0eDeleteUserData\x12\x1b.post.DeleteUserDataRequest\x1a\x1c.post.DeleteUserDataResponse\x12K\n" +
+	"\x0eExportUserData\x12\x1b.post.ExportUserDataRequest\x1a\x1c.post.ExportUserDataResponseB$Z\"socialnet/services/post/gen;postpbb\x06proto3"
 
 var (
 	file_post_proto_rawDescOnce sync.Once
@@ -531,40 +833,51 @@ func file_post_proto_rawDescGZIP() []byte {
 	return file_post_proto_rawDescData
 }
 
-var file_post_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_post_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_post_proto_goTypes = []any{
-	(*Post)(nil),              // 0: post.Post
-	(*Posts)(nil),             // 1: post.Posts
-	(*CreatePostRequest)(nil), // 2: post.CreatePostRequest
-	(*GetFeedRequest)(nil),    // 3: post.GetFeedRequest
-	(*GetPostRequest)(nil),    // 4: post.GetPostRequest
-	(*UpdatePostRequest)(nil), // 5: post.UpdatePostRequest
-	(*DeletePostRequest)(nil), // 6: post.DeletePostRequest
-	(*UserPostsRequest)(nil),  // 7: post.UserPostsRequest
-	(*gen.EmptyRequest)(nil),  // 8: user.EmptyRequest
-	(*gen1.Confirmation)(nil), // 9: auth.Confirmation
+	(*Post)(nil),                   // 0: post.Post
+	(*Posts)(nil),                  // 1: post.Posts
+	(*CreatePostRequest)(nil),      // 2: post.CreatePostRequest
+	(*ListPostsRequest)(nil),       // 3: post.ListPostsRequest
+	(*GetFeedRequest)(nil),         // 4: post.GetFeedRequest
+	(*GetPostRequest)(nil),         // 5: post.GetPostRequest
+	(*UpdatePostRequest)(nil),      // 6: post.UpdatePostRequest
+	(*DeletePostRequest)(nil),      // 7: post.DeletePostRequest
+	(*UserPostsRequest)(nil),       // 8: post.UserPostsRequest
+	(*DeleteUserDataRequest)(nil),  // 9: post.DeleteUserDataRequest
+	(*DeleteUserDataResponse)(nil), // 10: post.DeleteUserDataResponse
+	(*ExportUserDataRequest)(nil),  // 11: post.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil), // 12: post.ExportUserDataResponse
+	(*gen.UserSummary)(nil),        // 13: user.UserSummary
+	(*gen1.Confirmation)(nil),      // 14: auth.Confirmation
 }
 var file_post_proto_depIdxs = []int32{
-	0, // 0: post.Posts.posts:type_name -> post.Post
-	2, // 1: post.PostService.CreatePost:input_type -> post.CreatePostRequest
-	4, // 2: post.PostService.GetPost:input_type -> post.GetPostRequest
-	5, // 3: post.PostService.UpdatePost:input_type -> post.UpdatePostRequest
-	6, // 4: post.PostService.DeletePost:input_type -> post.DeletePostRequest
-	8, // 5: post.PostService.ListPosts:input_type -> user.EmptyRequest
-	7, // 6: post.PostService.ListUserPosts:input_type -> post.UserPostsRequest
-	3, // 7: post.PostService.GetFeed:input_type -> post.GetFeedRequest
-	0, // 8: post.PostService.CreatePost:output_type -> post.Post
-	0, // 9: post.PostService.GetPost:output_type -> post.Post
-	9, // 10: post.PostService.UpdatePost:output_type -> auth.Confirmation
-	9, // 11: post.PostService.DeletePost:output_type -> auth.Confirmation
-	1, // 12: post.PostService.ListPosts:output_type -> post.Posts
-	1, // 13: post.PostService.ListUserPosts:output_type -> post.Posts
-	1, // 14: post.PostService.GetFeed:output_type -> post.Posts
-	8, // [8:15] is the sub-list for method output_type
-	1, // [1:8] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	13, // 0: post.Post.author:type_name -> user.UserSummary
+	0,  // 1: post.Posts.posts:type_name -> post.Post
+	0,  // 2: post.ExportUserDataResponse.posts:type_name -> post.Post
+	2,  // 3: post.PostService.CreatePost:input_type -> post.CreatePostRequest
+	5,  // 4: post.PostService.GetPost:input_type -> post.GetPostRequest
+	6,  // 5: post.PostService.UpdatePost:input_type -> post.UpdatePostRequest
+	7,  // 6: post.PostService.DeletePost:input_type -> post.DeletePostRequest
+	3,  // 7: post.PostService.ListPosts:input_type -> post.ListPostsRequest
+	8,  // 8: post.PostService.ListUserPosts:input_type -> post.UserPostsRequest
+	4,  // 9: post.PostService.GetFeed:input_type -> post.GetFeedRequest
+	9,  // 10: post.PostService.DeleteUserData:input_type -> post.DeleteUserDataRequest
+	11, // 11: post.PostService.ExportUserData:input_type -> post.ExportUserDataRequest
+	0,  // 12: post.PostService.CreatePost:output_type -> post.Post
+	0,  // 13: post.PostService.GetPost:output_type -> post.Post
+	14, // 14: post.PostService.UpdatePost:output_type -> auth.Confirmation
+	14, // 15: post.PostService.DeletePost:output_type -> auth.Confirmation
+	1,  // 16: post.PostService.ListPosts:output_type -> post.Posts
+	1,  // 17: post.PostService.ListUserPosts:output_type -> post.Posts
+	1,  // 18: post.PostService.GetFeed:output_type -> post.Posts
+	10, // 19: post.PostService.DeleteUserData:output_type -> post.DeleteUserDataResponse
+	12, // 20: post.PostService.ExportUserData:output_type -> post.ExportUserDataResponse
+	12, // [12:21] is the sub-list for method output_type
+	3,  // [3:12] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_post_proto_init() }
@@ -578,7 +891,7 @@ func file_post_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_post_proto_rawDesc), len(file_post_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -12,7 +12,6 @@ import (
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	gen "socialnet/services/auth/gen"
-	gen1 "socialnet/services/user/gen"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -21,13 +20,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PostService_CreatePost_FullMethodName    = "/post.PostService/CreatePost"
-	PostService_GetPost_FullMethodName       = "/post.PostService/GetPost"
-	PostService_UpdatePost_FullMethodName    = "/post.PostService/UpdatePost"
-	PostService_DeletePost_FullMethodName    = "/post.PostService/DeletePost"
-	PostService_ListPosts_FullMethodName     = "/post.PostService/ListPosts"
-	PostService_ListUserPosts_FullMethodName = "/post.PostService/ListUserPosts"
-	PostService_GetFeed_FullMethodName       = "/post.PostService/GetFeed"
+	PostService_CreatePost_FullMethodName     = "/post.PostService/CreatePost"
+	PostService_GetPost_FullMethodName        = "/post.PostService/GetPost"
+	PostService_UpdatePost_FullMethodName     = "/post.PostService/UpdatePost"
+	PostService_DeletePost_FullMethodName     = "/post.PostService/DeletePost"
+	PostService_ListPosts_FullMethodName      = "/post.PostService/ListPosts"
+	PostService_ListUserPosts_FullMethodName  = "/post.PostService/ListUserPosts"
+	PostService_GetFeed_FullMethodName        = "/post.PostService/GetFeed"
+	PostService_DeleteUserData_FullMethodName = "/post.PostService/DeleteUserData"
+	PostService_ExportUserData_FullMethodName = "/post.PostService/ExportUserData"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -42,12 +43,19 @@ type PostServiceClient interface {
 	UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
 	// DeletePost → DELETE /api/v1/posts/{id}
 	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
-	// ListPosts → GET /api/v1/posts
-	ListPosts(ctx context.Context, in *gen1.EmptyRequest, opts ...grpc.CallOption) (*Posts, error)
+	// ListPosts → GET /api/v1/posts?page_size=&page_token=
+	// Посты закрытых аккаунтов видны только одобренным подписчикам,
+	// поэтому страница может быть короче page_size.
+	ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*Posts, error)
 	// ListUserPosts → GET /api/v1/users/{id}/posts
+	// Закрытый аккаунт без одобренной подписки → PERMISSION_DENIED
 	ListUserPosts(ctx context.Context, in *UserPostsRequest, opts ...grpc.CallOption) (*Posts, error)
 	// Получить ленту (мои посты + посты друзей)
 	GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*Posts, error)
+	// Внутренний вызов саги удаления аккаунта: посты пользователя и их изображения
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: все посты пользователя
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
 }
 
 type postServiceClient struct {
@@ -98,7 +106,7 @@ func (c *postServiceClient) DeletePost(ctx context.Context, in *DeletePostReques
 	return out, nil
 }
 
-func (c *postServiceClient) ListPosts(ctx context.Context, in *gen1.EmptyRequest, opts ...grpc.CallOption) (*Posts, error) {
+func (c *postServiceClient) ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*Posts, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Posts)
 	err := c.cc.Invoke(ctx, PostService_ListPosts_FullMethodName, in, out, cOpts...)
@@ -128,6 +136,26 @@ func (c *postServiceClient) GetFeed(ctx context.Context, in *GetFeedRequest, opt
 	return out, nil
 }
 
+func (c *postServiceClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserDataResponse)
+	err := c.cc.Invoke(ctx, PostService_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, PostService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations must embed UnimplementedPostServiceServer
 // for forward compatibility.
@@ -140,12 +168,19 @@ type PostServiceServer interface {
 	UpdatePost(context.Context, *UpdatePostRequest) (*gen.Confirmation, error)
 	// DeletePost → DELETE /api/v1/posts/{id}
 	DeletePost(context.Context, *DeletePostRequest) (*gen.Confirmation, error)
-	// ListPosts → GET /api/v1/posts
-	ListPosts(context.Context, *gen1.EmptyRequest) (*Posts, error)
+	// ListPosts → GET /api/v1/posts?page_size=&page_token=
+	// Посты закрытых аккаунтов видны только одобренным подписчикам,
+	// поэтому страница может быть короче page_size.
+	ListPosts(context.Context, *ListPostsRequest) (*Posts, error)
 	// ListUserPosts → GET /api/v1/users/{id}/posts
+	// Закрытый аккаунт без одобренной подписки → PERMISSION_DENIED
 	ListUserPosts(context.Context, *UserPostsRequest) (*Posts, error)
 	// Получить ленту (мои посты + посты друзей)
 	GetFeed(context.Context, *GetFeedRequest) (*Posts, error)
+	// Внутренний вызов саги удаления аккаунта: посты пользователя и их изображения
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error)
+	// Внутренний вызов выгрузки персональных данных: все посты пользователя
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -168,7 +203,7 @@ func (UnimplementedPostServiceServer) UpdatePost(context.Context, *UpdatePostReq
 func (UnimplementedPostServiceServer) DeletePost(context.Context, *DeletePostRequest) (*gen.Confirmation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
 }
-func (UnimplementedPostServiceServer) ListPosts(context.Context, *gen1.EmptyRequest) (*Posts, error) {
+func (UnimplementedPostServiceServer) ListPosts(context.Context, *ListPostsRequest) (*Posts, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPosts not implemented")
 }
 func (UnimplementedPostServiceServer) ListUserPosts(context.Context, *UserPostsRequest) (*Posts, error) {
@@ -177,6 +212,12 @@ func (UnimplementedPostServiceServer) ListUserPosts(context.Context, *UserPostsR
 func (UnimplementedPostServiceServer) GetFeed(context.Context, *GetFeedRequest) (*Posts, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
 }
+func (UnimplementedPostServiceServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedPostServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 func (UnimplementedPostServiceServer) testEmbeddedByValue()                     {}
 
@@ -271,7 +312,7 @@ func _PostService_DeletePost_Handler(srv interface{}, ctx context.Context, dec f
 }
 
 func _PostService_ListPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(gen1.EmptyRequest)
+	in := new(ListPostsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -283,7 +324,7 @@ func _PostService_ListPosts_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: PostService_ListPosts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PostServiceServer).ListPosts(ctx, req.(*gen1.EmptyRequest))
+		return srv.(PostServiceServer).ListPosts(ctx, req.(*ListPostsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -324,6 +365,42 @@ func _PostService_GetFeed_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -359,6 +436,14 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFeed",
 			Handler:    _PostService_GetFeed_Handler,
 		},
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _PostService_DeleteUserData_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _PostService_ExportUserData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "post.proto",
@@ -8,7 +8,6 @@ import (
 	authpb "socialnet/services/auth/gen"
 	pb "socialnet/services/post/gen"
 	"socialnet/services/post/internal/service"
-	userpb "socialnet/services/user/gen"
 )
 
 type PostHandler struct {
@@ -40,18 +39,17 @@ func (h *PostHandler) GetPost(ctx context.Context, req *pb.GetPostRequest) (*pb.
 
 // DeletePost — удалить пост
 func (h *PostHandler) DeletePost(ctx context.Context, req *pb.DeletePostRequest) (*authpb.Confirmation, error) {
-	err := h.service.DeletePost(ctx, req)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete post: %v", err)
+	if err := h.service.DeletePost(ctx, req); err != nil {
+		return nil, err
 	}
 	return &authpb.Confirmation{Status: "Post deleted successfully"}, nil
 }
 
-// ListUserPosts — все посты конкретного пользователя
+// ListUserPosts — посты конкретного пользователя, постранично
 func (h *PostHandler) ListUserPosts(ctx context.Context, req *pb.UserPostsRequest) (*pb.Posts, error) {
 	posts, err := h.service.ListUserPosts(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list user posts: %v", err)
+		return nil, err
 	}
 	return posts, nil
 }
@@ -60,17 +58,29 @@ func (h *PostHandler) ListUserPosts(ctx context.Context, req *pb.UserPostsReques
 func (h *PostHandler) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.Posts, error) {
 	feed, err := h.service.GetFeed(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to load feed: %v", err)
+		return nil, err
 	}
 	fmt.Printf("Feed loaded successfully: %d posts\n", len(feed.Posts))
 	return feed, nil
 }
 
-func (h *PostHandler) ListPosts(ctx context.Context, req *userpb.EmptyRequest) (*pb.Posts, error) {
-	posts, err := h.service.GetAllPosts()
+func (h *PostHandler) ListPosts(ctx context.Context, req *pb.ListPostsRequest) (*pb.Posts, error) {
+	posts, err := h.service.ListPosts(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to load feed: %v", err)
+		return nil, err
 	}
 	fmt.Printf("Feed loaded successfully: %d posts\n", len(posts.Posts))
 	return posts, nil
 }
+
+func (h *PostHandler) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	deleted, err := h.service.DeleteUserData(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteUserDataResponse{Deleted: deleted}, nil
+}
+
+func (h *PostHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	return h.service.ExportUserData(ctx, req.UserId)
+}
@@ -3,12 +3,12 @@ package model
 import "time"
 
 type Post struct {
-	ID            uint   `gorm:"primaryKey"`
+	ID            uint   `gorm:"primaryKey;index:idx_posts_page,priority:2"`
 	UserId        string `gorm:"index;not null"`
 	Content       string `gorm:"type:text"`
 	ImageUrl      string
 	LikesCount    int32     `gorm:"default:0"`
 	CommentsCount int32     `gorm:"default:0"`
-	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	CreatedAt     time.Time `gorm:"autoCreateTime;index:idx_posts_page,priority:1"`
 	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
 }
@@ -2,6 +2,7 @@ package repos
 
 import (
 	"gorm.io/gorm"
+	"socialnet/pkg/utils"
 	"socialnet/services/post/internal/model"
 )
 
@@ -25,9 +26,19 @@ func (r *PostRepo) GetPostByID(id string) (*model.Post, error) {
 	return post, nil
 }
 
-func (r *PostRepo) GetAllPosts() ([]*model.Post, error) {
+// page — новые посты первыми, keyset по (created_at, id) от курсора.
+// limit+1 строка: лишняя означает, что есть следующая страница.
+func page(q *gorm.DB, after *utils.PageCursor, limit int) *gorm.DB {
+	q = q.Order("created_at DESC, id DESC").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at < ? OR (created_at = ? AND id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	return q
+}
+
+func (r *PostRepo) ListPosts(after *utils.PageCursor, limit int) ([]*model.Post, error) {
 	var posts []*model.Post
-	if err := r.db.Find(&posts).Error; err != nil {
+	if err := page(r.db, after, limit).Find(&posts).Error; err != nil {
 		return nil, err
 	}
 	return posts, nil
@@ -45,12 +56,17 @@ func (r *PostRepo) GetUserPosts(userID string) ([]*model.Post, error) {
 	return posts, nil
 }
 
-func (r *PostRepo) GetPostsByUsers(userIDs []string) ([]*model.Post, error) {
+func (r *PostRepo) ListUserPosts(userID string, after *utils.PageCursor, limit int) ([]*model.Post, error) {
 	var posts []*model.Post
-	if err := r.db.
-		Where("user_id IN ?", userIDs).
-		Order("created_at DESC").
-		Find(&posts).Error; err != nil {
+	if err := page(r.db.Where("user_id = ?", userID), after, limit).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (r *PostRepo) GetPostsByUsers(userIDs []string, after *utils.PageCursor, limit int) ([]*model.Post, error) {
+	var posts []*model.Post
+	if err := page(r.db.Where("user_id IN ?", userIDs), after, limit).Find(&posts).Error; err != nil {
 		return nil, err
 	}
 	return posts, nil
@@ -65,3 +81,8 @@ func (r *PostRepo) SearchPosts(query string, limit, offset int) ([]model.Post, e
 	).Limit(limit).Offset(offset).Find(&posts).Error
 	return posts, err
 }
+
+func (r *PostRepo) DeleteUserPosts(userID string) (int64, error) {
+	res := r.db.Where("user_id = ?", userID).Delete(&model.Post{})
+	return res.RowsAffected, res.Error
+}
@@ -11,7 +11,11 @@ import (
 	"os"
 	"socialnet/pkg/config"
 	"socialnet/pkg/contextx"
+	"socialnet/pkg/interceptor"
+	"socialnet/pkg/relations"
 	"socialnet/pkg/storage"
+	"socialnet/pkg/users"
+	"socialnet/pkg/utils"
 	commentpb "socialnet/services/comment/gen"
 	likepb "socialnet/services/like/gen"
 	notificationpb "socialnet/services/notification/gen"
@@ -22,12 +26,13 @@ import (
 )
 
 type PostService struct {
-	repo    *repos.PostRepo
-	clients *config.GRPCClients
+	repo      *repos.PostRepo
+	clients   *config.GRPCClients
+	relations *relations.Checker
 }
 
 func NewPostService(repo *repos.PostRepo, clients *config.GRPCClients) *PostService {
-	return &PostService{repo: repo, clients: clients}
+	return &PostService{repo: repo, clients: clients, relations: relations.FromClients(clients)}
 }
 
 func (s *PostService) CreatePost(ctx context.Context, req *pb.CreatePostRequest) (*pb.Post, error) {
@@ -79,6 +84,8 @@ func (s *PostService) CreatePost(ctx context.Context, req *pb.CreatePostRequest)
 				ReferenceId: fmt.Sprint(post.ID),
 				Content:     "Your post has been published",
 			})
+
+		s.notifyMentions(ctxWithUser, notifClient, userID, post)
 	}
 
 	return &pb.Post{
@@ -92,14 +99,45 @@ func (s *PostService) CreatePost(ctx context.Context, req *pb.CreatePostRequest)
 	}, nil
 }
 
+// notifyMentions — уведомления упомянутым через @handle; ctx уже несёт автора
+// (notification сервис по нему отсекает заблокировавших и заглушивших)
+func (s *PostService) notifyMentions(ctx context.Context, notifClient notificationpb.NotificationServiceClient, userID string, post *model.Post) {
+	userClient, err := s.clients.GetUserClient(users.Addr())
+	if err != nil {
+		return
+	}
+	mentioned, err := users.Mentioned(ctx, userClient, post.Content)
+	if err != nil {
+		log.Printf("⚠️ failed to resolve mentions in post %d: %v", post.ID, err)
+		return
+	}
+	for _, u := range mentioned {
+		if u.Id == userID {
+			continue
+		}
+		_, _ = notifClient.CreateNotification(ctx, &notificationpb.CreateNotificationRequest{
+			UserId:      u.Id,
+			Type:        "mention",
+			ReferenceId: fmt.Sprint(post.ID),
+			Content:     fmt.Sprintf("User %s mentioned you in a post", userID),
+		})
+	}
+}
+
+// GetPost — пост закрытого аккаунта для не-подписчика неотличим от несуществующего
 func (s *PostService) GetPost(ctx context.Context, req *pb.GetPostRequest) (*pb.Post, error) {
 	post, err := s.repo.GetPostByID(req.Id)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "post not found: %v", err)
 	}
-	commClient, err := s.clients.GetCommentClient(os.Getenv("COMMENT_SERVICE_ADDR"))
-	if err != nil {
-		log.Fatalf("error with comment Client %v", err)
+	if viewer := contextx.GetUserID(ctx); viewer != post.UserId {
+		hidden, err := s.hiddenAuthors(ctx, viewer, []string{post.UserId})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to check account privacy: %v", err)
+		}
+		if hidden[post.UserId] {
+			return nil, status.Error(codes.NotFound, "post not found")
+		}
 	}
 	likeClient, err := s.clients.GetLikeClient(os.Getenv("LIKE_SERVICE_ADDR"))
 	if err != nil {
@@ -115,12 +153,7 @@ func (s *PostService) GetPost(ctx context.Context, req *pb.GetPostRequest) (*pb.
 	}
 
 	// 🔹 Получаем количество комментариев
-	commentsResp, err := commClient.ListComments(ctx, &commentpb.ListCommentsRequest{
-		PostId: req.Id,
-	})
-	if err == nil {
-		post.CommentsCount = int32(len(commentsResp.Comments))
-	}
+	post.CommentsCount = s.commentCounts(ctx, []string{req.Id})[req.Id]
 
 	return &pb.Post{
 		Id:            fmt.Sprint(post.ID),
@@ -144,40 +177,62 @@ func (s *PostService) DeletePost(ctx context.Context, req *pb.DeletePostRequest)
 		return status.Error(codes.NotFound, "post not found")
 	}
 
-	// только владелец может удалить
+	// удалить может владелец, чужой пост — модератор или администратор
+	moderated := false
 	if post.UserId != userID {
-		return status.Error(codes.PermissionDenied, "not your post")
+		if ok, _ := utils.AuthorizeUser(contextx.GetRole(ctx), utils.RoleModerator, utils.RoleAdmin); !ok {
+			return status.Error(codes.PermissionDenied, "not your post")
+		}
+		moderated = true
 	}
 
 	if err := s.repo.DeletePostByID(req.Id); err != nil {
 		return status.Errorf(codes.Internal, "failed to delete post: %v", err)
 	}
 
+	if moderated {
+		log.Printf("🛡️ post %s of user %s removed by %s %s", req.Id, post.UserId, contextx.GetRole(ctx), userID)
+	}
+
 	return nil
 }
 
 func (s *PostService) ListUserPosts(ctx context.Context, req *pb.UserPostsRequest) (*pb.Posts, error) {
-	posts, err := s.repo.GetUserPosts(req.Id)
+	after, err := utils.DecodePageToken(req.PageToken)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to load user posts: %v", err)
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
 	}
-	commClient, err := s.clients.GetCommentClient(os.Getenv("COMMENT_SERVICE_ADDR"))
+	if viewer := contextx.GetUserID(ctx); viewer != req.Id {
+		hidden, err := s.hiddenAuthors(ctx, viewer, []string{req.Id})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to check account privacy: %v", err)
+		}
+		if hidden[req.Id] {
+			return nil, status.Error(codes.PermissionDenied, "account is private")
+		}
+	}
+	size := utils.PageSize(req.PageSize)
+	posts, err := s.repo.ListUserPosts(req.Id, after, size)
 	if err != nil {
-		log.Fatalf("error with comment Client %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to load user posts: %v", err)
 	}
+	posts, next := nextPage(posts, size)
 	likeClient, err := s.clients.GetLikeClient(os.Getenv("LIKE_SERVICE_ADDR"))
 	if err != nil {
 		log.Fatalf("error with like Client %v", err)
 	}
 
+	postIDs := make([]string, 0, len(posts))
+	for _, p := range posts {
+		postIDs = append(postIDs, fmt.Sprint(p.ID))
+	}
+	comments := s.commentCounts(ctx, postIDs)
+
 	var pbPosts []*pb.Post
 	for _, p := range posts {
 		likesResp, _ := likeClient.LikePost(ctx, &likepb.LikePostRequest{
 			Id: fmt.Sprint(p.ID),
 		})
-		commentsResp, _ := commClient.ListComments(ctx, &commentpb.ListCommentsRequest{
-			PostId: fmt.Sprint(p.ID),
-		})
 
 		pbPosts = append(pbPosts, &pb.Post{
 			Id:            fmt.Sprint(p.ID),
@@ -185,19 +240,31 @@ func (s *PostService) ListUserPosts(ctx context.Context, req *pb.UserPostsReques
 			Content:       p.Content,
 			ImageUrl:      p.ImageUrl,
 			LikesCount:    likesResp.GetLikesCount(),
-			CommentsCount: int32(len(commentsResp.GetComments())),
+			CommentsCount: comments[fmt.Sprint(p.ID)],
 			CreatedAt:     p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		})
 	}
 
-	return &pb.Posts{Posts: pbPosts}, nil
+	return &pb.Posts{Posts: pbPosts, NextPageToken: next}, nil
 }
 
-func (s *PostService) GetAllPosts() (*pb.Posts, error) {
-	posts, err := s.repo.GetAllPosts()
+func (s *PostService) ListPosts(ctx context.Context, req *pb.ListPostsRequest) (*pb.Posts, error) {
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+	posts, err := s.repo.ListPosts(after, size)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to load posts: %v", err)
 	}
+	posts, next := nextPage(posts, size)
+
+	// токен уже построен по последней строке страницы, фильтр его не сдвигает
+	posts, err = s.withoutHiddenAuthors(ctx, posts)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to check account privacy: %v", err)
+	}
 
 	var pbPosts []*pb.Post
 	for _, p := range posts {
@@ -211,7 +278,7 @@ func (s *PostService) GetAllPosts() (*pb.Posts, error) {
 			CreatedAt:     p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		})
 	}
-	return &pb.Posts{Posts: pbPosts}, nil
+	return &pb.Posts{Posts: pbPosts, NextPageToken: next}, nil
 }
 
 func (s *PostService) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.Posts, error) {
@@ -219,30 +286,52 @@ func (s *PostService) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.
 	if userID == "" {
 		return nil, status.Error(codes.Unauthenticated, "missing user id")
 	}
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
 
 	userClient, err := s.clients.GetUserClient(os.Getenv("USER_SERVICE_ADDR"))
 	if err != nil {
 		log.Fatalf("error with user Client %v", err)
 	}
 
-	followingResp, err := userClient.GetFollowing(ctx, &userpb.GetFollowingRequest{
-		Id: userID,
-	})
+	//  Собираем все ID: мой + друзей. В подписках только одобренные,
+	//  так что закрытые аккаунты сюда попадают лишь для подписчиков
+	following, err := followingIDs(ctx, userClient, userID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to load following list: %v", err)
 	}
 
-	//  Собираем все ID: мой + друзей
+	//  Заглушённых и заблокированных убираем до запроса, чтобы страницы были полными
+	rel, err := s.relations.Check(ctx, userID, following)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to check relations: %v", err)
+	}
 	userIDs := []string{userID}
-	for _, u := range followingResp.Users {
-		userIDs = append(userIDs, u.Id)
+	for _, id := range following {
+		if !rel.Hidden(id) {
+			userIDs = append(userIDs, id)
+		}
 	}
 
 	//  Достаём посты этих пользователей
-	posts, err := s.repo.GetPostsByUsers(userIDs)
+	posts, err := s.repo.GetPostsByUsers(userIDs, after, size)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to load posts: %v", err)
 	}
+	posts, next := nextPage(posts, size)
+
+	//  Авторы одним запросом; без них лента всё равно отдаётся
+	authorIDs := make([]string, 0, len(posts))
+	for _, p := range posts {
+		authorIDs = append(authorIDs, p.UserId)
+	}
+	authors, err := users.Summaries(ctx, userClient, authorIDs)
+	if err != nil {
+		log.Printf("⚠️ failed to load feed authors: %v", err)
+	}
 
 	//  Формируем ответ
 	var pbPosts []*pb.Post
@@ -255,8 +344,165 @@ func (s *PostService) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.
 			LikesCount:    p.LikesCount,
 			CommentsCount: p.CommentsCount,
 			CreatedAt:     p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Author:        authors[p.UserId],
 		})
 	}
 
-	return &pb.Posts{Posts: pbPosts}, nil
+	return &pb.Posts{Posts: pbPosts, NextPageToken: next}, nil
+}
+
+// followingIDs — все подписки пользователя; список постраничный, запрашиваем от его имени,
+// иначе у закрытого аккаунта он недоступен
+func followingIDs(ctx context.Context, client userpb.UserServiceClient, userID string) ([]string, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "user-id", userID)
+	var ids []string
+	req := &userpb.GetFollowingRequest{Id: userID, PageSize: utils.MaxPageSize}
+	for {
+		resp, err := client.GetFollowing(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range resp.Users {
+			ids = append(ids, u.Id)
+		}
+		if resp.NextPageToken == "" {
+			return ids, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// nextPage — репозиторий отдаёт size+1 постов; лишний означает, что есть следующая страница,
+// токен строится по последнему отданному
+func nextPage(posts []*model.Post, size int) ([]*model.Post, string) {
+	if len(posts) <= size {
+		return posts, ""
+	}
+	posts = posts[:size]
+	last := posts[size-1]
+	return posts, utils.EncodePageToken(last.CreatedAt, last.ID)
+}
+
+// hiddenAuthors — закрытые аккаунты среди authorIDs, на которые viewer не подписан
+func (s *PostService) hiddenAuthors(ctx context.Context, viewerID string, authorIDs []string) (map[string]bool, error) {
+	userClient, err := s.clients.GetUserClient(os.Getenv("USER_SERVICE_ADDR"))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := userClient.GetHiddenAuthors(interceptor.WithInternalToken(ctx), &userpb.GetHiddenAuthorsRequest{
+		ViewerId:  viewerID,
+		AuthorIds: authorIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hidden := make(map[string]bool, len(resp.AuthorIds))
+	for _, id := range resp.AuthorIds {
+		hidden[id] = true
+	}
+	return hidden, nil
+}
+
+// withoutHiddenAuthors — убирает посты закрытых аккаунтов, которые текущий пользователь видеть не должен
+func (s *PostService) withoutHiddenAuthors(ctx context.Context, posts []*model.Post) ([]*model.Post, error) {
+	viewer := contextx.GetUserID(ctx)
+	seen := map[string]bool{}
+	var authors []string
+	for _, p := range posts {
+		if p.UserId != viewer && !seen[p.UserId] {
+			seen[p.UserId] = true
+			authors = append(authors, p.UserId)
+		}
+	}
+	if len(authors) == 0 {
+		return posts, nil
+	}
+
+	hidden, err := s.hiddenAuthors(ctx, viewer, authors)
+	if err != nil {
+		return nil, err
+	}
+	visible := posts[:0]
+	for _, p := range posts {
+		if !hidden[p.UserId] {
+			visible = append(visible, p)
+		}
+	}
+	return visible, nil
+}
+
+// commentCounts — число комментариев для страницы постов одним вызовом;
+// при ошибке счётчики остаются нулевыми, посты всё равно отдаются
+func (s *PostService) commentCounts(ctx context.Context, postIDs []string) map[string]int32 {
+	commClient, err := s.clients.GetCommentClient(os.Getenv("COMMENT_SERVICE_ADDR"))
+	if err != nil {
+		log.Fatalf("error with comment Client %v", err)
+	}
+	resp, err := commClient.CountComments(interceptor.WithInternalToken(ctx), &commentpb.CountCommentsRequest{PostIds: postIDs})
+	if err != nil {
+		log.Printf("⚠️ failed to count comments: %v", err)
+		return nil
+	}
+	return resp.Counts
+}
+
+// DeleteUserData — шаг саги удаления аккаунта: сначала изображения в S3, потом строки.
+// При сбое S3 посты остаются, и повторный вызов найдёт те же ссылки.
+func (s *PostService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	if userID == "" {
+		return 0, status.Error(codes.InvalidArgument, "user id required")
+	}
+	posts, err := s.repo.GetUserPosts(userID)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to load posts: %v", err)
+	}
+
+	var s3 *storage.S3Client
+	for _, post := range posts {
+		key, ok := storage.KeyFromURL(post.ImageUrl)
+		if !ok {
+			continue
+		}
+		if s3 == nil {
+			if s3, err = storage.NewS3Client(); err != nil {
+				return 0, status.Error(codes.Internal, "cannot to create client")
+			}
+		}
+		if err := s3.DeleteFile(key); err != nil {
+			return 0, status.Errorf(codes.Unavailable, "failed to delete image of post %d: %v", post.ID, err)
+		}
+	}
+
+	deleted, err := s.repo.DeleteUserPosts(userID)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to delete posts: %v", err)
+	}
+	return deleted, nil
+}
+
+// ExportUserData — посты пользователя для архива персональных данных.
+// Счётчики берём из БД: обход like/comment сервисов здесь не нужен.
+func (s *PostService) ExportUserData(ctx context.Context, userID string) (*pb.ExportUserDataResponse, error) {
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id required")
+	}
+	posts, err := s.repo.GetUserPosts(userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load user posts: %v", err)
+	}
+
+	resp := &pb.ExportUserDataResponse{Posts: make([]*pb.Post, 0, len(posts))}
+	for _, p := range posts {
+		resp.Posts = append(resp.Posts, &pb.Post{
+			Id:            fmt.Sprint(p.ID),
+			UserId:        p.UserId,
+			Content:       p.Content,
+			ImageUrl:      p.ImageUrl,
+			LikesCount:    p.LikesCount,
+			CommentsCount: p.CommentsCount,
+			CreatedAt:     p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:     p.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return resp, nil
 }
@@ -5,8 +5,12 @@ import (
 	"strings"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"socialnet/pkg/contextx"
+	"socialnet/pkg/relations"
+	"socialnet/pkg/utils"
 	postpb "socialnet/services/post/gen"
 	pb "socialnet/services/search/gen"
 	userpb "socialnet/services/user/gen"
@@ -15,40 +19,70 @@ import (
 type SearchService struct {
 	userClient userpb.UserServiceClient
 	postClient postpb.PostServiceClient
+	relations  *relations.Checker
 }
 
 func NewSearchService(userClient userpb.UserServiceClient, postClient postpb.PostServiceClient) *SearchService {
 	return &SearchService{
 		userClient: userClient,
 		postClient: postClient,
+		relations: relations.NewChecker(func() (userpb.UserServiceClient, error) {
+			return userClient, nil
+		}),
 	}
 }
 
+// blockedAmong — с кем из ids у ищущего есть блокировка; анонимный поиск не фильтруется
+func (s *SearchService) blockedAmong(ctx context.Context, ids []string) (map[string]bool, error) {
+	rel, err := s.relations.Check(ctx, contextx.GetUserID(ctx), ids)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to check blocks: %v", err)
+	}
+	return rel.Blocked, nil
+}
+
 // SearchUsers 🔍 Поиск пользователей
 func (s *SearchService) SearchUsers(ctx context.Context, req *pb.SearchRequest) (*pb.SearchUsersResponse, error) {
-	if strings.TrimSpace(req.Query) == "" {
+	// @bob ищет по handle так же, как bob
+	query := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(req.Query)), "@")
+	if query == "" {
 		return nil, status.Error(codes.InvalidArgument, "query cannot be empty")
 	}
-
-	usersResp, err := s.userClient.ListUsers(ctx, &userpb.EmptyRequest{})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch users: %v", err)
-	}
-
-	query := strings.ToLower(req.Query)
 	var results []*userpb.User
 
-	for _, u := range usersResp.Users {
-		if strings.Contains(strings.ToLower(u.FirstName), query) ||
-			strings.Contains(strings.ToLower(u.LastName), query) {
-			results = append(results, u)
+	// список пользователей постраничный — идём по страницам, пока не наберём limit
+	listReq := &userpb.ListUsersRequest{PageSize: utils.MaxPageSize}
+	for {
+		usersResp, err := s.userClient.ListUsers(ctx, listReq)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch users: %v", err)
+		}
+		ids := make([]string, 0, len(usersResp.Users))
+		for _, u := range usersResp.Users {
+			ids = append(ids, u.Id)
+		}
+		blocked, err := s.blockedAmong(ctx, ids)
+		if err != nil {
+			return nil, err
 		}
-		if req.Limit > 0 && int32(len(results)) >= req.Limit {
-			break
+		for _, u := range usersResp.Users {
+			if blocked[u.Id] {
+				continue
+			}
+			if strings.Contains(strings.ToLower(u.FirstName), query) ||
+				strings.Contains(strings.ToLower(u.LastName), query) ||
+				strings.Contains(strings.ToLower(u.Handle), query) {
+				results = append(results, u)
+			}
+			if req.Limit > 0 && int32(len(results)) >= req.Limit {
+				return &pb.SearchUsersResponse{Users: results}, nil
+			}
 		}
+		if usersResp.NextPageToken == "" {
+			return &pb.SearchUsersResponse{Users: results}, nil
+		}
+		listReq.PageToken = usersResp.NextPageToken
 	}
-
-	return &pb.SearchUsersResponse{Users: results}, nil
 }
 
 // SearchPosts 🔍 Поиск постов
@@ -57,24 +91,46 @@ func (s *SearchService) SearchPosts(ctx context.Context, req *pb.SearchRequest)
 		return nil, status.Error(codes.InvalidArgument, "query cannot be empty")
 	}
 
-	postsResp, err := s.postClient.ListPosts(ctx, &userpb.EmptyRequest{})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch posts: %v", err)
-	}
-
 	query := strings.ToLower(req.Query)
 	var results []*postpb.Post
 
-	for _, p := range postsResp.Posts {
-		if strings.Contains(strings.ToLower(p.Content), query) {
-			results = append(results, p)
+	// post сервис скрывает посты закрытых аккаунтов от всех, кроме подписчиков,
+	// поэтому передаём, кто ищет
+	postCtx := ctx
+	if userID := contextx.GetUserID(ctx); userID != "" {
+		postCtx = metadata.AppendToOutgoingContext(ctx, "user-id", userID)
+	}
+
+	listReq := &postpb.ListPostsRequest{PageSize: utils.MaxPageSize}
+	for {
+		postsResp, err := s.postClient.ListPosts(postCtx, listReq)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch posts: %v", err)
+		}
+		authors := make([]string, 0, len(postsResp.Posts))
+		for _, p := range postsResp.Posts {
+			authors = append(authors, p.UserId)
+		}
+		blocked, err := s.blockedAmong(ctx, authors)
+		if err != nil {
+			return nil, err
 		}
-		if req.Limit > 0 && int32(len(results)) >= req.Limit {
-			break
+		for _, p := range postsResp.Posts {
+			if blocked[p.UserId] {
+				continue
+			}
+			if strings.Contains(strings.ToLower(p.Content), query) {
+				results = append(results, p)
+			}
+			if req.Limit > 0 && int32(len(results)) >= req.Limit {
+				return &pb.SearchPostsResponse{Posts: results}, nil
+			}
 		}
+		if postsResp.NextPageToken == "" {
+			return &pb.SearchPostsResponse{Posts: results}, nil
+		}
+		listReq.PageToken = postsResp.NextPageToken
 	}
-
-	return &pb.SearchPostsResponse{Posts: results}, nil
 }
 
 func (s *SearchService) SearchAll(ctx context.Context, req *pb.SearchRequest) (*pb.SearchAllResponse, error) {
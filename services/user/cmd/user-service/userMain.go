@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -15,6 +17,7 @@ import (
 	"socialnet/services/user/internal/model"
 	"socialnet/services/user/internal/repos"
 	"socialnet/services/user/internal/service"
+	"time"
 )
 
 func main() {
@@ -33,7 +36,15 @@ func main() {
 	}
 
 	// 🔹 Автомиграции
-	if err := db.AutoMigrate(&model.Follow{}, &model.User{}); err != nil {
+	if n, err := repos.NewUserRepo(db).DedupeFollows(); err != nil {
+		log.Fatalf(" failed to dedupe follows: %v", err)
+	} else if n > 0 {
+		log.Printf("👥 %d duplicate follows removed", n)
+	}
+	if err := db.AutoMigrate(&model.Follow{}, &model.User{}, &model.FollowRequest{},
+		&model.Block{}, &model.Mute{}, &model.FollowStats{},
+		&model.Suggestion{}, &model.DismissedSuggestion{},
+		&model.ProfileLink{}, &model.ProfileEntry{}, &model.FieldVisibility{}); err != nil {
 		log.Fatalf(" failed to migrate database: %v", err)
 	}
 
@@ -42,9 +53,33 @@ func main() {
 
 	// 🔹 Репозиторий, сервис, хендлер
 	repo := repos.NewUserRepo(db)
+	if err := repo.BackfillFollowStats(); err != nil {
+		log.Fatalf(" failed to backfill follow counts: %v", err)
+	}
+	if err := repo.BackfillBirthdays(); err != nil {
+		log.Fatalf(" failed to backfill birthdays: %v", err)
+	}
 	userService := service.NewUserService(repo, clients)
+
+	// 🔹 Кэш кратких профилей для BatchGetUsers: без Redis читаем из БД
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		rdb := redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: os.Getenv("REDIS_PASS"),
+			DB:       0,
+		})
+		if err := rdb.Ping(context.Background()).Err(); err != nil {
+			log.Fatalf("❌ Redis connection failed: %v", err)
+		}
+		userService.Summaries = repos.NewSummaryCache(rdb)
+	} else {
+		log.Println("⚠️ REDIS_ADDR is not set, user summaries are not cached")
+	}
 	userHandler := handlers.NewUserHandler(userService)
 
+	// 🔹 Пересчёт рекомендаций подписок
+	go userService.StartSuggestionWorker(context.Background(), time.Hour)
+
 	// 🔹 gRPC сервер
 	lis, err := net.Listen("tcp", ":50052")
 	if err != nil {
@@ -54,6 +89,14 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.ExtractUserInterceptor(),
+			interceptor.InternalOnly(
+				userpb.UserService_DeleteUser_FullMethodName,
+				userpb.UserService_ExportUserData_FullMethodName,
+				userpb.UserService_GetHiddenAuthors_FullMethodName,
+				userpb.UserService_CheckRelations_FullMethodName,
+				userpb.UserService_BatchGetUsers_FullMethodName,
+				userpb.UserService_SetHandle_FullMethodName,
+			),
 			interceptor.LoggingInterceptor(),
 		),
 	)
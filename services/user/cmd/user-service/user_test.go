@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"os"
 	"socialnet/pkg/config"
 	authpb "socialnet/services/auth/gen"
 	notificationpb "socialnet/services/notification/gen"
 	"socialnet/services/user/internal/service"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
@@ -24,6 +28,7 @@ import (
 var testDB *gorm.DB
 var testRepo *repos.UserRepo
 var testSvc *service.UserService
+var testNotif *mockNotif
 var ctx context.Context
 
 // ========== FULL MOCK for NotificationServiceClient ==========
@@ -55,7 +60,16 @@ func (m *mockNotif) StreamNotifications(ctx context.Context, in *notificationpb.
 	return nil, nil // стрим нам не нужен
 }
 
+func (m *mockNotif) DeleteUserData(ctx context.Context, in *notificationpb.DeleteUserDataRequest, opts ...grpc.CallOption) (*notificationpb.DeleteUserDataResponse, error) {
+	return &notificationpb.DeleteUserDataResponse{}, nil
+}
+
+func (m *mockNotif) ExportUserData(ctx context.Context, in *notificationpb.ExportUserDataRequest, opts ...grpc.CallOption) (*notificationpb.ExportUserDataResponse, error) {
+	return &notificationpb.ExportUserDataResponse{}, nil
+}
+
 func (m *mockNotif) CreateNotification(ctx context.Context, req *notificationpb.CreateNotificationRequest, opts ...grpc.CallOption) (*authpb.Confirmation, error) {
+	m.Created = append(m.Created, req)
 	return &authpb.Confirmation{Status: "ok"}, nil
 }
 
@@ -79,9 +93,20 @@ func TestMain(m *testing.M) {
 	// Чистим таблицы
 	_ = testDB.Exec(`DROP TABLE IF EXISTS follows CASCADE`)
 	_ = testDB.Exec(`DROP TABLE IF EXISTS users CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS follow_requests CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS blocks CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS mutes CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS follow_stats CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS suggestions CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS dismissed_suggestions CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS profile_links CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS profile_entries CASCADE`)
+	_ = testDB.Exec(`DROP TABLE IF EXISTS profile_field_visibility CASCADE`)
 
 	// Миграции
-	if err := testDB.AutoMigrate(&model.User{}, &model.Follow{}); err != nil {
+	if err := testDB.AutoMigrate(&model.User{}, &model.Follow{}, &model.FollowRequest{}, &model.Block{}, &model.Mute{}, &model.FollowStats{},
+		&model.Suggestion{}, &model.DismissedSuggestion{},
+		&model.ProfileLink{}, &model.ProfileEntry{}, &model.FieldVisibility{}); err != nil {
 		panic(err)
 	}
 
@@ -90,6 +115,7 @@ func TestMain(m *testing.M) {
 
 	// 🔥 СОЗДАЁМ МОК ДО СОЗДАНИЯ СЕРВИСА!
 	mockNotifClient := &mockNotif{}
+	testNotif = mockNotifClient
 
 	// 🔥 СОЗДАЁМ GRPCClients ВРУЧНУЮ
 	mockClients := &config.GRPCClients{
@@ -132,17 +158,17 @@ func TestGetUser_Success(t *testing.T) {
 	u, err := createUser("Test", "User")
 	assert.NoError(t, err)
 
-	resp, err := testSvc.GetUser(&pb.GetUserRequest{Id: fmt.Sprint(u.Id)})
+	resp, err := testSvc.GetUser("", &pb.GetUserRequest{Id: fmt.Sprint(u.Id)})
 
 	assert.NoError(t, err)
-	assert.Equal(t, "Test", resp.Firstname)
+	assert.Equal(t, "Test", resp.FirstName)
 }
 
 // ---------------------------------------------------------
 // GET USER NOT FOUND
 // ---------------------------------------------------------
 func TestGetUser_NotFound(t *testing.T) {
-	_, err := testSvc.GetUser(&pb.GetUserRequest{Id: "999999"})
+	_, err := testSvc.GetUser("", &pb.GetUserRequest{Id: "999999"})
 	assert.Error(t, err)
 }
 
@@ -152,18 +178,92 @@ func TestGetUser_NotFound(t *testing.T) {
 func TestUpdateUser_Success(t *testing.T) {
 	u, _ := createUser("Old", "Name")
 
-	err := testSvc.UpdateUser(&pb.UpdateUserRequest{
-		Id:        fmt.Sprint(u.Id),
-		FirstName: "New",
-		LastName:  "Name",
-		Bio:       "Updated bio",
-		BirthDate: "2020-01-01",
+	err := testSvc.UpdateUser(fmt.Sprint(u.Id), &pb.UpdateUserRequest{
+		Id:         fmt.Sprint(u.Id),
+		FirstName:  "New",
+		LastName:   "Name",
+		Bio:        "Updated bio",
+		BirthDate:  "2020-01-01",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name", "last_name", "bio", "birth_date"}},
 	})
 	assert.NoError(t, err)
 
 	updated, _ := testRepo.GetUser(u.Id)
 	assert.Equal(t, "New", updated.Firstname)
 	assert.Equal(t, "Updated bio", updated.Bio)
+
+	// без маски ничего не меняется: поля, которых нет в запросе, не затираются
+	err = testSvc.UpdateUser(fmt.Sprint(u.Id), &pb.UpdateUserRequest{Id: fmt.Sprint(u.Id), FirstName: "Other"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	updated, _ = testRepo.GetUser(u.Id)
+	assert.Equal(t, "Updated bio", updated.Bio)
+}
+
+// ---------------------------------------------------------
+// UpdateUser по field mask + видимость полей
+// ---------------------------------------------------------
+func TestUpdateUser_FieldMaskAndVisibility(t *testing.T) {
+	owner, _ := createUser("Ann", "Owner")
+	fan, _ := createUser("Fan", "F")
+	stranger, _ := createUser("Str", "S")
+	ownerID, fanID, strangerID := fmt.Sprint(owner.Id), fmt.Sprint(fan.Id), fmt.Sprint(stranger.Id)
+	_, err := testSvc.FollowUser(ctx, fanID, ownerID)
+	assert.NoError(t, err)
+
+	err = testSvc.UpdateUser(ownerID, &pb.UpdateUserRequest{
+		Id:        ownerID,
+		FirstName: "ignored, not in mask",
+		BirthDate: "1990-05-17",
+		Location:  "Berlin",
+		Links:     []*pb.ProfileLink{{Url: "https://ann.example.com", Label: "site"}},
+		Work:      []*pb.ProfileEntry{{Organization: "Acme", Title: "Engineer", StartYear: 2015}},
+		FieldVisibility: map[string]pb.Visibility{
+			"birth_date": pb.Visibility_VISIBILITY_ONLY_ME,
+			"location":   pb.Visibility_VISIBILITY_FOLLOWERS,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"birthDate", "location", "links", "work", "field_visibility"}},
+	})
+	assert.NoError(t, err)
+
+	self, err := testSvc.GetUser(ownerID, &pb.GetUserRequest{Id: ownerID})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ann", self.FirstName)
+	assert.Equal(t, "1990-05-17", self.BirthDate)
+	assert.Equal(t, "Berlin", self.Location)
+	assert.Len(t, self.Links, 1)
+	assert.Equal(t, "Acme", self.Work[0].Organization)
+	assert.Equal(t, pb.Visibility_VISIBILITY_ONLY_ME, self.FieldVisibility["birth_date"])
+	assert.Equal(t, pb.Visibility_VISIBILITY_PUBLIC, self.FieldVisibility["work"])
+
+	asFan, _ := testSvc.GetUser(fanID, &pb.GetUserRequest{Id: ownerID})
+	assert.Empty(t, asFan.BirthDate)
+	assert.Equal(t, "Berlin", asFan.Location)
+	assert.Empty(t, asFan.FieldVisibility)
+
+	asStranger, _ := testSvc.GetUser(strangerID, &pb.GetUserRequest{Id: ownerID})
+	assert.Empty(t, asStranger.Location)
+	assert.Equal(t, "Acme", asStranger.Work[0].Organization)
+
+	// UNSPECIFIED сбрасывает настройку к public
+	assert.NoError(t, testSvc.UpdateUser(ownerID, &pb.UpdateUserRequest{
+		Id:              ownerID,
+		FieldVisibility: map[string]pb.Visibility{"location": pb.Visibility_VISIBILITY_UNSPECIFIED},
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"field_visibility"}},
+	}))
+	asStranger, _ = testSvc.GetUser(strangerID, &pb.GetUserRequest{Id: ownerID})
+	assert.Equal(t, "Berlin", asStranger.Location)
+	assert.Empty(t, asStranger.BirthDate)
+
+	err = testSvc.UpdateUser(strangerID, &pb.UpdateUserRequest{Id: ownerID, Bio: "hacked"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = testSvc.UpdateUser(ownerID, &pb.UpdateUserRequest{Id: ownerID, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"is_private"}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = testSvc.UpdateUser(ownerID, &pb.UpdateUserRequest{Id: ownerID, BirthDate: "17.05.1990",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"birth_date"}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = testSvc.UpdateUser(ownerID, &pb.UpdateUserRequest{Id: ownerID, Links: []*pb.ProfileLink{{Url: "javascript:alert(1)"}},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"links"}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 // ---------------------------------------------------------
@@ -173,7 +273,7 @@ func TestFollowUser_Success(t *testing.T) {
 	u1, _ := createUser("A", "One")
 	u2, _ := createUser("B", "Two")
 
-	err := testSvc.FollowUser(ctx, fmt.Sprint(u1.Id), fmt.Sprint(u2.Id))
+	_, err := testSvc.FollowUser(ctx, fmt.Sprint(u1.Id), fmt.Sprint(u2.Id))
 	assert.NoError(t, err)
 
 	var count int64
@@ -187,19 +287,45 @@ func TestFollowUser_Success(t *testing.T) {
 func TestFollowUser_CannotFollowYourself(t *testing.T) {
 	u, _ := createUser("Self", "Test")
 
-	err := testSvc.FollowUser(nil, fmt.Sprint(u.Id), fmt.Sprint(u.Id))
+	_, err := testSvc.FollowUser(nil, fmt.Sprint(u.Id), fmt.Sprint(u.Id))
 	assert.Error(t, err)
 }
 
 // ---------------------------------------------------------
 // UNFOLLOW SUCCESS
 // ---------------------------------------------------------
+// дубли подписок из старых версий убираются до построения уникального индекса
+func TestDedupeFollows_KeepsEarliestRow(t *testing.T) {
+	a, _ := createUser("Dup", "A")
+	b, _ := createUser("Dup", "B")
+	assert.NoError(t, testDB.Migrator().DropIndex(&model.Follow{}, "idx_follows_unique"))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, testDB.Create(&model.Follow{FollowerID: a.Id, FollowingID: b.Id}).Error)
+	}
+	assert.NoError(t, testDB.Create(&model.Follow{FollowerID: b.Id, FollowingID: a.Id}).Error)
+	var first model.Follow
+	assert.NoError(t, testDB.Where("follower_id = ? AND following_id = ?", a.Id, b.Id).Order("id").First(&first).Error)
+
+	n, err := testRepo.DedupeFollows()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, testDB.AutoMigrate(&model.Follow{}))
+	n, err = testRepo.DedupeFollows()
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+
+	var rows []model.Follow
+	assert.NoError(t, testDB.Where("follower_id IN ?", []uint{a.Id, b.Id}).Find(&rows).Error)
+	assert.Len(t, rows, 2)
+	assert.Contains(t, rows, first)
+}
+
 func TestUnfollowUser_Success(t *testing.T) {
 	u1, _ := createUser("A", "One")
 	u2, _ := createUser("B", "Two")
 
 	// follow
-	_ = testSvc.FollowUser(ctx, fmt.Sprint(u1.Id), fmt.Sprint(u2.Id))
+	_, _ = testSvc.FollowUser(ctx, fmt.Sprint(u1.Id), fmt.Sprint(u2.Id))
 
 	// unfollow
 	err := testSvc.UnfollowUser(fmt.Sprint(u1.Id), fmt.Sprint(u2.Id))
@@ -217,22 +343,364 @@ func TestGetFollowing_Success(t *testing.T) {
 	follower, _ := createUser("Follower", "X")
 	following, _ := createUser("Following", "Y")
 
-	_ = testSvc.FollowUser(ctx, fmt.Sprint(follower.Id), fmt.Sprint(following.Id))
+	_, _ = testSvc.FollowUser(ctx, fmt.Sprint(follower.Id), fmt.Sprint(following.Id))
+
+	users, err := testSvc.GetFollowing("", &pb.GetFollowingRequest{Id: fmt.Sprint(follower.Id)})
+	assert.NoError(t, err)
+	assert.Len(t, users.Users, 1)
+	assert.Equal(t, fmt.Sprint(following.Id), users.Users[0].Id)
+	assert.Equal(t, "Following", users.Users[0].FirstName)
+}
+
+// ---------------------------------------------------------
+// Счётчики подписок, подписчики и отношения между пользователями
+// ---------------------------------------------------------
+func TestFollowers_CountsAndRelationship(t *testing.T) {
+	star, _ := createUser("Star", "User")
+	fan1, _ := createUser("Fan", "A")
+	fan2, _ := createUser("Fan", "B")
+	starID, fan1ID, fan2ID := fmt.Sprint(star.Id), fmt.Sprint(fan1.Id), fmt.Sprint(fan2.Id)
+
+	_, err := testSvc.FollowUser(ctx, fan1ID, starID)
+	assert.NoError(t, err)
+	_, err = testSvc.FollowUser(ctx, fan1ID, starID)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, _ = testSvc.FollowUser(ctx, fan2ID, starID)
+	_, _ = testSvc.FollowUser(ctx, starID, fan1ID)
+
+	profile, err := testSvc.GetUser(fan1ID, &pb.GetUserRequest{Id: starID})
+	assert.NoError(t, err)
+	assert.Equal(t, starID, profile.Id)
+	assert.Equal(t, int64(2), profile.FollowerCount)
+	assert.Equal(t, int64(1), profile.FollowingCount)
+	assert.True(t, profile.IsFollowedByMe)
+
+	followers, err := testSvc.GetFollowers(fan1ID, &pb.GetFollowersRequest{Id: starID, PageSize: 1})
+	assert.NoError(t, err)
+	assert.Len(t, followers.Users, 1)
+	assert.NotEmpty(t, followers.NextPageToken)
+	rest, err := testSvc.GetFollowers(fan1ID, &pb.GetFollowersRequest{Id: starID, PageToken: followers.NextPageToken})
+	assert.NoError(t, err)
+	assert.Len(t, rest.Users, 1)
+	assert.ElementsMatch(t, []string{fan1ID, fan2ID}, []string{followers.Users[0].Id, rest.Users[0].Id})
+
+	rel, err := testSvc.GetFollowRelationship(fan1ID, &pb.GetFollowRelationshipRequest{UserId: fan1ID, OtherId: starID})
+	assert.NoError(t, err)
+	assert.True(t, rel.Following)
+	assert.True(t, rel.FollowedBy)
+
+	// отписка и блокировка уменьшают счётчики у обеих сторон
+	assert.NoError(t, testSvc.UnfollowUser(fan2ID, starID))
+	assert.NoError(t, testSvc.BlockUser(starID, fan1ID))
+	profile, _ = testSvc.GetUser("", &pb.GetUserRequest{Id: starID})
+	assert.Equal(t, int64(0), profile.FollowerCount)
+	assert.Equal(t, int64(0), profile.FollowingCount)
+
+	// списки закрытого аккаунта видны только подписчикам
+	assert.NoError(t, testSvc.SetAccountPrivacy(ctx, starID, true))
+	_, err = testSvc.GetFollowing(fan2ID, &pb.GetFollowingRequest{Id: starID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = testSvc.GetFollowing(starID, &pb.GetFollowingRequest{Id: starID})
+	assert.NoError(t, err)
+	_, _ = testSvc.FollowUser(ctx, fan2ID, starID)
+	rel, _ = testSvc.GetFollowRelationship(starID, &pb.GetFollowRelationshipRequest{UserId: starID, OtherId: fan2ID})
+	assert.True(t, rel.RequestReceived)
+	rel, _ = testSvc.GetFollowRelationship(fan1ID, &pb.GetFollowRelationshipRequest{UserId: starID, OtherId: fan2ID})
+	assert.False(t, rel.RequestReceived)
+}
+
+// ---------------------------------------------------------
+// BatchGetUsers — порядок запроса, без повторов и без неизвестных id
+// ---------------------------------------------------------
+func TestBatchGetUsers(t *testing.T) {
+	a, _ := createUser("Alice", "A")
+	b, _ := createUser("Bob", "B")
+	aID, bID := fmt.Sprint(a.Id), fmt.Sprint(b.Id)
+
+	resp, err := testSvc.BatchGetUsers(ctx, &pb.BatchGetUsersRequest{Ids: []string{bID, "999999", aID, bID}})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Users, 2)
+	assert.Equal(t, bID, resp.Users[0].Id)
+	assert.Equal(t, "Bob", resp.Users[0].FirstName)
+	assert.Equal(t, aID, resp.Users[1].Id)
+
+	_, err = testSvc.BatchGetUsers(ctx, &pb.BatchGetUsersRequest{Ids: []string{"abc"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// ---------------------------------------------------------
+// Handle — без учёта регистра, переходит к новому владельцу
+// ---------------------------------------------------------
+func TestHandles_CaseInsensitiveLookup(t *testing.T) {
+	a, _ := createUser("Alice", "A")
+	b, _ := createUser("Bob", "B")
+	aID, bID := fmt.Sprint(a.Id), fmt.Sprint(b.Id)
+
+	assert.NoError(t, testSvc.SetHandle(&pb.SetHandleRequest{UserId: aID, Handle: "Alice_1"}))
+	// повтор от auth — безопасен
+	assert.NoError(t, testSvc.SetHandle(&pb.SetHandleRequest{UserId: aID, Handle: "Alice_1"}))
+
+	u, err := testSvc.GetUserByHandle("", &pb.GetUserByHandleRequest{Handle: "@alice_1"})
+	assert.NoError(t, err)
+	assert.Equal(t, aID, u.Id)
+	assert.Equal(t, "Alice_1", u.Handle)
+
+	resp, err := testSvc.BatchGetUsers(ctx, &pb.BatchGetUsersRequest{Handles: []string{"ALICE_1", "nobody_here"}})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Users, 1)
+	assert.Equal(t, aID, resp.Users[0].Id)
 
-	users, err := testSvc.GetFollowing(fmt.Sprint(follower.Id))
+	// alice сменила username, bob занял старый — handle уходит к bob
+	assert.NoError(t, testSvc.SetHandle(&pb.SetHandleRequest{UserId: aID, Handle: "alice_2"}))
+	assert.NoError(t, testSvc.SetHandle(&pb.SetHandleRequest{UserId: bID, Handle: "alice_1"}))
+	u, err = testSvc.GetUserByHandle("", &pb.GetUserByHandleRequest{Handle: "Alice_1"})
 	assert.NoError(t, err)
-	assert.Len(t, users, 1)
-	assert.Equal(t, fmt.Sprint(following.Id), users[0].Id)
+	assert.Equal(t, bID, u.Id)
+
+	_, err = testSvc.GetUserByHandle("", &pb.GetUserByHandleRequest{Handle: "a b"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = testSvc.GetUserByHandle("", &pb.GetUserByHandleRequest{Handle: "ghost_user"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
 }
 
 // ---------------------------------------------------------
 // GET ALL USERS
 // ---------------------------------------------------------
-func TestGetAllUsers_Success(t *testing.T) {
+func TestListUsers_Success(t *testing.T) {
 	_, _ = createUser("U1", "L1")
 	_, _ = createUser("U2", "L2")
 
-	res, err := testSvc.GetAllUsers()
+	res, err := testSvc.ListUsers("", &pb.ListUsersRequest{})
 	assert.NoError(t, err)
 	assert.True(t, len(res.Users) >= 2)
 }
+
+func TestListUsers_PagesWithoutGapsOrDuplicates(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		_, _ = createUser(fmt.Sprint("Page", i), "User")
+	}
+	var total int64
+	testDB.Model(&model.User{}).Count(&total)
+
+	seen := map[string]bool{}
+	req := &pb.ListUsersRequest{PageSize: 2}
+	for {
+		res, err := testSvc.ListUsers("", req)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(res.Users), 2)
+		for _, u := range res.Users {
+			assert.False(t, seen[u.Id], "duplicate user %s", u.Id)
+			seen[u.Id] = true
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		req.PageToken = res.NextPageToken
+	}
+	assert.Equal(t, int(total), len(seen))
+
+	_, err := testSvc.ListUsers("", &pb.ListUsersRequest{PageToken: "not-a-token"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// ---------------------------------------------------------
+// Закрытый аккаунт: подписка через заявку
+// ---------------------------------------------------------
+func TestPrivateAccount_FollowRequests(t *testing.T) {
+	owner, _ := createUser("Private", "Owner")
+	fan, _ := createUser("Fan", "One")
+	other, _ := createUser("Fan", "Two")
+	ownerID, fanID, otherID := fmt.Sprint(owner.Id), fmt.Sprint(fan.Id), fmt.Sprint(other.Id)
+
+	assert.NoError(t, testSvc.SetAccountPrivacy(ctx, ownerID, true))
+	// профиль сохраняется без потери настройки приватности
+	assert.NoError(t, testSvc.UpdateUser(ownerID, &pb.UpdateUserRequest{Id: ownerID, FirstName: "Private", LastName: "Renamed", BirthDate: "2000-01-01", Bio: "private",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name", "last_name", "birth_date", "bio"}}}))
+	u, err := testRepo.GetUser(owner.Id)
+	assert.NoError(t, err)
+	assert.True(t, u.IsPrivate)
+
+	requested, err := testSvc.FollowUser(ctx, fanID, ownerID)
+	assert.NoError(t, err)
+	assert.True(t, requested)
+	requested, err = testSvc.FollowUser(ctx, otherID, ownerID)
+	assert.NoError(t, err)
+	assert.True(t, requested)
+	// повторная заявка остаётся ожидающей, но уведомление не дублируется
+	requested, err = testSvc.FollowUser(ctx, fanID, ownerID)
+	assert.NoError(t, err)
+	assert.True(t, requested)
+	fanRequests := 0
+	for _, n := range testNotif.Created {
+		if n.Type == "follow_request" && n.UserId == ownerID && n.ReferenceId == fanID {
+			fanRequests++
+		}
+	}
+	assert.Equal(t, 1, fanRequests)
+	following, _ := testRepo.IsFollowing(fan.Id, owner.Id)
+	assert.False(t, following)
+
+	// посты закрытого аккаунта скрыты, пока заявка не одобрена
+	hidden, err := testSvc.GetHiddenAuthors(&pb.GetHiddenAuthorsRequest{ViewerId: fanID, AuthorIds: []string{ownerID, otherID}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ownerID}, hidden.AuthorIds)
+	hidden, _ = testSvc.GetHiddenAuthors(&pb.GetHiddenAuthorsRequest{AuthorIds: []string{ownerID}})
+	assert.Equal(t, []string{ownerID}, hidden.AuthorIds)
+	hidden, _ = testSvc.GetHiddenAuthors(&pb.GetHiddenAuthorsRequest{ViewerId: ownerID, AuthorIds: []string{ownerID}})
+	assert.Empty(t, hidden.AuthorIds)
+
+	list, err := testSvc.ListFollowRequests(ownerID, &pb.ListFollowRequestsRequest{PageSize: 1})
+	assert.NoError(t, err)
+	assert.Len(t, list.Requests, 1)
+	assert.Equal(t, fanID, list.Requests[0].UserId)
+	assert.NotEmpty(t, list.NextPageToken)
+
+	assert.NoError(t, testSvc.ApproveFollowRequest(ctx, ownerID, fanID))
+	assert.Equal(t, codes.NotFound, status.Code(testSvc.ApproveFollowRequest(ctx, ownerID, fanID)))
+	following, _ = testRepo.IsFollowing(fan.Id, owner.Id)
+	assert.True(t, following)
+	hidden, _ = testSvc.GetHiddenAuthors(&pb.GetHiddenAuthorsRequest{ViewerId: fanID, AuthorIds: []string{ownerID}})
+	assert.Empty(t, hidden.AuthorIds)
+
+	_, err = testSvc.FollowUser(ctx, fanID, ownerID)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	assert.NoError(t, testSvc.RejectFollowRequest(ownerID, otherID))
+	assert.Equal(t, codes.NotFound, status.Code(testSvc.RejectFollowRequest(ownerID, otherID)))
+	following, _ = testRepo.IsFollowing(other.Id, owner.Id)
+	assert.False(t, following)
+
+	// открытие аккаунта одобряет оставшиеся заявки
+	_, _ = testSvc.FollowUser(ctx, otherID, ownerID)
+	assert.NoError(t, testSvc.SetAccountPrivacy(ctx, ownerID, false))
+	following, _ = testRepo.IsFollowing(other.Id, owner.Id)
+	assert.True(t, following)
+	list, _ = testSvc.ListFollowRequests(ownerID, &pb.ListFollowRequestsRequest{})
+	assert.Empty(t, list.Requests)
+}
+
+// ---------------------------------------------------------
+// Block — разрывает подписки в обе стороны и запрещает новые
+// ---------------------------------------------------------
+func TestBlockUser_RemovesFollowsAndDeniesFollow(t *testing.T) {
+	u1, _ := createUser("Blocker", "User")
+	u2, _ := createUser("Blocked", "User")
+	u3, _ := createUser("Muted", "User")
+	id1, id2, id3 := fmt.Sprint(u1.Id), fmt.Sprint(u2.Id), fmt.Sprint(u3.Id)
+	testDB.Create(&model.Follow{FollowerID: u1.Id, FollowingID: u2.Id})
+	testDB.Create(&model.Follow{FollowerID: u2.Id, FollowingID: u1.Id})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(testSvc.BlockUser(id1, id1)))
+	assert.NoError(t, testSvc.BlockUser(id1, id2))
+	assert.NoError(t, testSvc.BlockUser(id1, id2))
+	assert.NoError(t, testSvc.MuteUser(id1, id3))
+
+	var follows int64
+	testDB.Model(&model.Follow{}).
+		Where("follower_id IN ? AND following_id IN ?", []uint{u1.Id, u2.Id}, []uint{u1.Id, u2.Id}).
+		Count(&follows)
+	assert.Equal(t, int64(0), follows)
+
+	// блокировка действует в обе стороны
+	_, err := testSvc.FollowUser(ctx, id2, id1)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	rel, err := testSvc.CheckRelations(&pb.CheckRelationsRequest{UserId: id2, OtherIds: []string{id1, id3}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{id1}, rel.BlockedIds)
+	assert.Empty(t, rel.MutedIds)
+	rel, _ = testSvc.CheckRelations(&pb.CheckRelationsRequest{UserId: id1, OtherIds: []string{id2, id3}})
+	assert.Equal(t, []string{id2}, rel.BlockedIds)
+	assert.Equal(t, []string{id3}, rel.MutedIds)
+
+	list, err := testSvc.ListBlocked(id1, &pb.ListBlockedRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, list.Users, 1)
+	assert.Equal(t, id2, list.Users[0].UserId)
+
+	assert.NoError(t, testSvc.UnblockUser(id1, id2))
+	_, err = testSvc.FollowUser(ctx, id2, id1)
+	assert.NoError(t, err)
+}
+
+// ---------------------------------------------------------
+// Рекомендации: друзья друзей выше, без подписок, блокировок и скрытых
+// ---------------------------------------------------------
+func TestSuggestFollows_RankedAndFiltered(t *testing.T) {
+	me, _ := createUser("Me", "S")
+	friend1, _ := createUser("Friend", "One")
+	friend2, _ := createUser("Friend", "Two")
+	popular, _ := createUser("Popular", "P")
+	niche, _ := createUser("Niche", "N")
+	blocked, _ := createUser("Blocked", "B")
+	fan, _ := createUser("Fan", "F")
+	newbie, _ := createUser("New", "Comer")
+	id := func(u *model.User) string { return fmt.Sprint(u.Id) }
+
+	for _, f := range []*model.User{friend1, friend2} {
+		_, _ = testSvc.FollowUser(ctx, id(me), id(f))
+		_, _ = testSvc.FollowUser(ctx, id(f), id(popular))
+		_, _ = testSvc.FollowUser(ctx, id(f), id(blocked))
+	}
+	_, _ = testSvc.FollowUser(ctx, id(friend1), id(niche))
+	_, _ = testSvc.FollowUser(ctx, id(fan), id(me))
+	assert.NoError(t, testSvc.BlockUser(id(me), id(blocked)))
+
+	_, err := testSvc.RunSuggestions(time.Now())
+	assert.NoError(t, err)
+
+	resp, err := testSvc.SuggestFollows(ctx, id(me), &pb.SuggestFollowsRequest{})
+	assert.NoError(t, err)
+	var ids []string
+	for _, s := range resp.Suggestions {
+		ids = append(ids, s.User.Id)
+	}
+	assert.Equal(t, id(popular), ids[0])
+	assert.Equal(t, int32(2), resp.Suggestions[0].MutualCount)
+	assert.Contains(t, ids, id(niche))
+	assert.Contains(t, ids, id(fan))
+	assert.NotContains(t, ids, id(me))
+	assert.NotContains(t, ids, id(friend1))
+	assert.NotContains(t, ids, id(blocked))
+
+	// скрытая рекомендация больше не показывается
+	assert.NoError(t, testSvc.DismissSuggestion(id(me), id(popular)))
+	resp, _ = testSvc.SuggestFollows(ctx, id(me), &pb.SuggestFollowsRequest{})
+	for _, s := range resp.Suggestions {
+		assert.NotEqual(t, id(popular), s.User.Id)
+	}
+
+	// у нового пользователя без своего списка — общий список популярных
+	_ = testRepo.ReplaceSuggestions(newbie.Id, nil)
+	resp, err = testSvc.SuggestFollows(ctx, id(newbie), &pb.SuggestFollowsRequest{PageSize: 5})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Suggestions)
+	for _, s := range resp.Suggestions {
+		assert.NotEqual(t, id(newbie), s.User.Id)
+	}
+}
+
+// ---------------------------------------------------------
+// DeleteUser — профиль и подписки в обе стороны
+// ---------------------------------------------------------
+func TestDeleteUser_RemovesProfileAndFollows(t *testing.T) {
+	u1, _ := createUser("Gone", "User")
+	u2, _ := createUser("Stays", "User")
+	testDB.Create(&model.Follow{FollowerID: u1.Id, FollowingID: u2.Id})
+	testDB.Create(&model.Follow{FollowerID: u2.Id, FollowingID: u1.Id})
+
+	err := testSvc.DeleteUser(&pb.DeleteUserRequest{Id: fmt.Sprint(u1.Id)})
+	assert.NoError(t, err)
+
+	_, err = testRepo.GetUser(u1.Id)
+	assert.Error(t, err)
+
+	var follows int64
+	testDB.Model(&model.Follow{}).
+		Where("follower_id = ? OR following_id = ?", u1.Id, u1.Id).
+		Count(&follows)
+	assert.Equal(t, int64(0), follows)
+
+	// повторный шаг саги не падает
+	assert.NoError(t, testSvc.DeleteUser(&pb.DeleteUserRequest{Id: fmt.Sprint(u1.Id)}))
+}
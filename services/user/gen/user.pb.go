@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	gen "socialnet/services/auth/gen"
 	sync "sync"
@@ -23,18 +24,84 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Visibility — кто видит поле профиля. Имя, handle и аватар видны всем.
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_UNSPECIFIED Visibility = 0
+	Visibility_VISIBILITY_PUBLIC      Visibility = 1
+	Visibility_VISIBILITY_FOLLOWERS   Visibility = 2
+	Visibility_VISIBILITY_ONLY_ME     Visibility = 3
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "VISIBILITY_PUBLIC",
+		2: "VISIBILITY_FOLLOWERS",
+		3: "VISIBILITY_ONLY_ME",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED": 0,
+		"VISIBILITY_PUBLIC":      1,
+		"VISIBILITY_FOLLOWERS":   2,
+		"VISIBILITY_ONLY_ME":     3,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_user_proto_enumTypes[0].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_user_proto_enumTypes[0]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{0}
+}
+
 // ----- Models -----
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FirstName     string                 `protobuf:"bytes,2,opt,name=firstName,proto3" json:"firstName,omitempty"`
-	LastName      string                 `protobuf:"bytes,3,opt,name=lastName,proto3" json:"lastName,omitempty"`
-	Bio           string                 `protobuf:"bytes,4,opt,name=bio,proto3" json:"bio,omitempty"`
-	AvatarUrl     string                 `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	BirthDate     string                 `protobuf:"bytes,7,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName      string                 `protobuf:"bytes,2,opt,name=firstName,proto3" json:"firstName,omitempty"`
+	LastName       string                 `protobuf:"bytes,3,opt,name=lastName,proto3" json:"lastName,omitempty"`
+	Bio            string                 `protobuf:"bytes,4,opt,name=bio,proto3" json:"bio,omitempty"`
+	AvatarUrl      string                 `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	BirthDate      string                 `protobuf:"bytes,7,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
+	IsPrivate      bool                   `protobuf:"varint,8,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	FollowerCount  int64                  `protobuf:"varint,9,opt,name=follower_count,json=followerCount,proto3" json:"follower_count,omitempty"`
+	FollowingCount int64                  `protobuf:"varint,10,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
+	IsFollowedByMe bool                   `protobuf:"varint,11,opt,name=is_followed_by_me,json=isFollowedByMe,proto3" json:"is_followed_by_me,omitempty"` // для текущего пользователя из user-id
+	Handle         string                 `protobuf:"bytes,12,opt,name=handle,proto3" json:"handle,omitempty"`
+	Location       string                 `protobuf:"bytes,13,opt,name=location,proto3" json:"location,omitempty"`
+	Links          []*ProfileLink         `protobuf:"bytes,14,rep,name=links,proto3" json:"links,omitempty"`
+	Pronouns       string                 `protobuf:"bytes,15,opt,name=pronouns,proto3" json:"pronouns,omitempty"`
+	CoverUrl       string                 `protobuf:"bytes,16,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	Work           []*ProfileEntry        `protobuf:"bytes,17,rep,name=work,proto3" json:"work,omitempty"`
+	Education      []*ProfileEntry        `protobuf:"bytes,18,rep,name=education,proto3" json:"education,omitempty"`
+	// Видимость полей; заполняется только для владельца профиля
+	FieldVisibility map[string]Visibility `protobuf:"bytes,19,rep,name=field_visibility,json=fieldVisibility,proto3" json:"field_visibility,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=user.Visibility"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -106,38 +173,2002 @@ func (x *User) GetCreatedAt() string {
 	if x != nil {
 		return x.CreatedAt
 	}
-	return ""
+	return ""
+}
+
+func (x *User) GetBirthDate() string {
+	if x != nil {
+		return x.BirthDate
+	}
+	return ""
+}
+
+func (x *User) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *User) GetFollowerCount() int64 {
+	if x != nil {
+		return x.FollowerCount
+	}
+	return 0
+}
+
+func (x *User) GetFollowingCount() int64 {
+	if x != nil {
+		return x.FollowingCount
+	}
+	return 0
+}
+
+func (x *User) GetIsFollowedByMe() bool {
+	if x != nil {
+		return x.IsFollowedByMe
+	}
+	return false
+}
+
+func (x *User) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *User) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *User) GetLinks() []*ProfileLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *User) GetPronouns() string {
+	if x != nil {
+		return x.Pronouns
+	}
+	return ""
+}
+
+func (x *User) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *User) GetWork() []*ProfileEntry {
+	if x != nil {
+		return x.Work
+	}
+	return nil
+}
+
+func (x *User) GetEducation() []*ProfileEntry {
+	if x != nil {
+		return x.Education
+	}
+	return nil
+}
+
+func (x *User) GetFieldVisibility() map[string]Visibility {
+	if x != nil {
+		return x.FieldVisibility
+	}
+	return nil
+}
+
+type ProfileLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileLink) Reset() {
+	*x = ProfileLink{}
+	mi := &file_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileLink) ProtoMessage() {}
+
+func (x *ProfileLink) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileLink.ProtoReflect.Descriptor instead.
+func (*ProfileLink) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProfileLink) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProfileLink) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// ProfileEntry — место работы или учёбы; end_year 0 — по настоящее время
+type ProfileEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  string                 `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	StartYear     int32                  `protobuf:"varint,3,opt,name=start_year,json=startYear,proto3" json:"start_year,omitempty"`
+	EndYear       int32                  `protobuf:"varint,4,opt,name=end_year,json=endYear,proto3" json:"end_year,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileEntry) Reset() {
+	*x = ProfileEntry{}
+	mi := &file_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileEntry) ProtoMessage() {}
+
+func (x *ProfileEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileEntry.ProtoReflect.Descriptor instead.
+func (*ProfileEntry) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProfileEntry) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *ProfileEntry) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ProfileEntry) GetStartYear() int32 {
+	if x != nil {
+		return x.StartYear
+	}
+	return 0
+}
+
+func (x *ProfileEntry) GetEndYear() int32 {
+	if x != nil {
+		return x.EndYear
+	}
+	return 0
+}
+
+// UserSummary — то, что показывается рядом с постом, комментарием или в списке чатов
+type UserSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Handle        string                 `protobuf:"bytes,5,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSummary) Reset() {
+	*x = UserSummary{}
+	mi := &file_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSummary) ProtoMessage() {}
+
+func (x *UserSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSummary.ProtoReflect.Descriptor instead.
+func (*UserSummary) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserSummary) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *UserSummary) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *UserSummary) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UserSummary) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type Users struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — последняя страница
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Users) Reset() {
+	*x = Users{}
+	mi := &file_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Users) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Users) ProtoMessage() {}
+
+func (x *Users) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Users.ProtoReflect.Descriptor instead.
+func (*Users) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Users) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *Users) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetFollowersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowersRequest) Reset() {
+	*x = GetFollowersRequest{}
+	mi := &file_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowersRequest) ProtoMessage() {}
+
+func (x *GetFollowersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowersRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowersRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetFollowersRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetFollowersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetFollowersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetFollowingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowingRequest) Reset() {
+	*x = GetFollowingRequest{}
+	mi := &file_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowingRequest) ProtoMessage() {}
+
+func (x *GetFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowingRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetFollowingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetFollowingRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetFollowingRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetFollowRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OtherId       string                 `protobuf:"bytes,2,opt,name=other_id,json=otherId,proto3" json:"other_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowRelationshipRequest) Reset() {
+	*x = GetFollowRelationshipRequest{}
+	mi := &file_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowRelationshipRequest) ProtoMessage() {}
+
+func (x *GetFollowRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetFollowRelationshipRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFollowRelationshipRequest) GetOtherId() string {
+	if x != nil {
+		return x.OtherId
+	}
+	return ""
+}
+
+type FollowRelationship struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Following       bool                   `protobuf:"varint,1,opt,name=following,proto3" json:"following,omitempty"`                                    // user_id подписан на other_id
+	FollowedBy      bool                   `protobuf:"varint,2,opt,name=followed_by,json=followedBy,proto3" json:"followed_by,omitempty"`                // other_id подписан на user_id
+	Requested       bool                   `protobuf:"varint,3,opt,name=requested,proto3" json:"requested,omitempty"`                                    // заявка user_id → other_id ждёт одобрения
+	RequestReceived bool                   `protobuf:"varint,4,opt,name=request_received,json=requestReceived,proto3" json:"request_received,omitempty"` // заявка other_id → user_id ждёт одобрения
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FollowRelationship) Reset() {
+	*x = FollowRelationship{}
+	mi := &file_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRelationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRelationship) ProtoMessage() {}
+
+func (x *FollowRelationship) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRelationship.ProtoReflect.Descriptor instead.
+func (*FollowRelationship) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FollowRelationship) GetFollowing() bool {
+	if x != nil {
+		return x.Following
+	}
+	return false
+}
+
+func (x *FollowRelationship) GetFollowedBy() bool {
+	if x != nil {
+		return x.FollowedBy
+	}
+	return false
+}
+
+func (x *FollowRelationship) GetRequested() bool {
+	if x != nil {
+		return x.Requested
+	}
+	return false
+}
+
+func (x *FollowRelationship) GetRequestReceived() bool {
+	if x != nil {
+		return x.RequestReceived
+	}
+	return false
+}
+
+type GetUserByHandleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        string                 `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByHandleRequest) Reset() {
+	*x = GetUserByHandleRequest{}
+	mi := &file_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByHandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByHandleRequest) ProtoMessage() {}
+
+func (x *GetUserByHandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByHandleRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByHandleRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetUserByHandleRequest) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type SetHandleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Handle        string                 `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetHandleRequest) Reset() {
+	*x = SetHandleRequest{}
+	mi := &file_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetHandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHandleRequest) ProtoMessage() {}
+
+func (x *SetHandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHandleRequest.ProtoReflect.Descriptor instead.
+func (*SetHandleRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetHandleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetHandleRequest) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Avatar        []byte                 `protobuf:"bytes,2,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAvatarRequest) Reset() {
+	*x = UpdateAvatarRequest{}
+	mi := &file_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAvatarRequest) ProtoMessage() {}
+
+func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateAvatarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAvatarRequest) GetAvatar() []byte {
+	if x != nil {
+		return x.Avatar
+	}
+	return nil
+}
+
+func (x *UpdateAvatarRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type UpdateAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AvatarUrl     string                 `protobuf:"bytes,1,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAvatarResponse) Reset() {
+	*x = UpdateAvatarResponse{}
+	mi := &file_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAvatarResponse) ProtoMessage() {}
+
+func (x *UpdateAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAvatarResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateAvatarResponse) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+type UpdateCoverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cover         []byte                 `protobuf:"bytes,2,opt,name=cover,proto3" json:"cover,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCoverRequest) Reset() {
+	*x = UpdateCoverRequest{}
+	mi := &file_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCoverRequest) ProtoMessage() {}
+
+func (x *UpdateCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCoverRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCoverRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateCoverRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCoverRequest) GetCover() []byte {
+	if x != nil {
+		return x.Cover
+	}
+	return nil
+}
+
+func (x *UpdateCoverRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type UpdateCoverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CoverUrl      string                 `protobuf:"bytes,1,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCoverResponse) Reset() {
+	*x = UpdateCoverResponse{}
+	mi := &file_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCoverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCoverResponse) ProtoMessage() {}
+
+func (x *UpdateCoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCoverResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCoverResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateCoverResponse) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+// UpdateUserRequest — меняются только поля из update_mask
+// (first_name, last_name, birth_date, bio, location, links, pronouns, work,
+// education, field_visibility). Маска обязательна, в JSON — "updateMask": "firstName,bio".
+// birth_date — YYYY-MM-DD, пустая строка очищает.
+type UpdateUserRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName string                 `protobuf:"bytes,2,opt,name=firstName,proto3" json:"firstName,omitempty"`
+	LastName  string                 `protobuf:"bytes,3,opt,name=lastName,proto3" json:"lastName,omitempty"`
+	BirthDate string                 `protobuf:"bytes,4,opt,name=birthDate,proto3" json:"birthDate,omitempty"`
+	Bio       string                 `protobuf:"bytes,5,opt,name=bio,proto3" json:"bio,omitempty"`
+	Location  string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Links     []*ProfileLink         `protobuf:"bytes,7,rep,name=links,proto3" json:"links,omitempty"`
+	Pronouns  string                 `protobuf:"bytes,8,opt,name=pronouns,proto3" json:"pronouns,omitempty"`
+	Work      []*ProfileEntry        `protobuf:"bytes,9,rep,name=work,proto3" json:"work,omitempty"`
+	Education []*ProfileEntry        `protobuf:"bytes,10,rep,name=education,proto3" json:"education,omitempty"`
+	// сливается с текущими настройками: меняются только переданные поля
+	FieldVisibility map[string]Visibility  `protobuf:"bytes,11,rep,name=field_visibility,json=fieldVisibility,proto3" json:"field_visibility,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=user.Visibility"`
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,12,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetBirthDate() string {
+	if x != nil {
+		return x.BirthDate
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetLinks() []*ProfileLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetPronouns() string {
+	if x != nil {
+		return x.Pronouns
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetWork() []*ProfileEntry {
+	if x != nil {
+		return x.Work
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetEducation() []*ProfileEntry {
+	if x != nil {
+		return x.Education
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetFieldVisibility() map[string]Visibility {
+	if x != nil {
+		return x.FieldVisibility
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EmptyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyRequest) Reset() {
+	*x = EmptyRequest{}
+	mi := &file_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyRequest) ProtoMessage() {}
+
+func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
+func (*EmptyRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{18}
+}
+
+// Списки постраничные: page_size по умолчанию 20 (максимум 100),
+// page_token — next_page_token из предыдущего ответа
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type FollowUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowUserRequest) Reset() {
+	*x = FollowUserRequest{}
+	mi := &file_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowUserRequest) ProtoMessage() {}
+
+func (x *FollowUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowUserRequest.ProtoReflect.Descriptor instead.
+func (*FollowUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FollowUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ----- Private accounts -----
+type SetAccountPrivacyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsPrivate     bool                   `protobuf:"varint,1,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAccountPrivacyRequest) Reset() {
+	*x = SetAccountPrivacyRequest{}
+	mi := &file_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAccountPrivacyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountPrivacyRequest) ProtoMessage() {}
+
+func (x *SetAccountPrivacyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountPrivacyRequest.ProtoReflect.Descriptor instead.
+func (*SetAccountPrivacyRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetAccountPrivacyRequest) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+type FollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // кто хочет подписаться
+	CreatedAt     string                 `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequest) Reset() {
+	*x = FollowRequest{}
+	mi := &file_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequest) ProtoMessage() {}
+
+func (x *FollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequest.ProtoReflect.Descriptor instead.
+func (*FollowRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FollowRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FollowRequest) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type FollowRequests struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*FollowRequest       `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequests) Reset() {
+	*x = FollowRequests{}
+	mi := &file_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequests) ProtoMessage() {}
+
+func (x *FollowRequests) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequests.ProtoReflect.Descriptor instead.
+func (*FollowRequests) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FollowRequests) GetRequests() []*FollowRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *FollowRequests) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListFollowRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowRequestsRequest) Reset() {
+	*x = ListFollowRequestsRequest{}
+	mi := &file_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowRequestsRequest) ProtoMessage() {}
+
+func (x *ListFollowRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListFollowRequestsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFollowRequestsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ApproveFollowRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveFollowRequestRequest) Reset() {
+	*x = ApproveFollowRequestRequest{}
+	mi := &file_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveFollowRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveFollowRequestRequest) ProtoMessage() {}
+
+func (x *ApproveFollowRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveFollowRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveFollowRequestRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ApproveFollowRequestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RejectFollowRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectFollowRequestRequest) Reset() {
+	*x = RejectFollowRequestRequest{}
+	mi := &file_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectFollowRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectFollowRequestRequest) ProtoMessage() {}
+
+func (x *RejectFollowRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectFollowRequestRequest.ProtoReflect.Descriptor instead.
+func (*RejectFollowRequestRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RejectFollowRequestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// ----- Blocking and muting -----
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BlockUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnblockUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type MuteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteUserRequest) Reset() {
+	*x = MuteUserRequest{}
+	mi := &file_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteUserRequest) ProtoMessage() {}
+
+func (x *MuteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteUserRequest.ProtoReflect.Descriptor instead.
+func (*MuteUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MuteUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UnmuteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmuteUserRequest) Reset() {
+	*x = UnmuteUserRequest{}
+	mi := &file_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmuteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmuteUserRequest) ProtoMessage() {}
+
+func (x *UnmuteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmuteUserRequest.ProtoReflect.Descriptor instead.
+func (*UnmuteUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UnmuteUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BlockedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedUser) Reset() {
+	*x = BlockedUser{}
+	mi := &file_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedUser) ProtoMessage() {}
+
+func (x *BlockedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedUser.ProtoReflect.Descriptor instead.
+func (*BlockedUser) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BlockedUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BlockedUser) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type BlockedUsers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*BlockedUser         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedUsers) Reset() {
+	*x = BlockedUsers{}
+	mi := &file_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedUsers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedUsers) ProtoMessage() {}
+
+func (x *BlockedUsers) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedUsers.ProtoReflect.Descriptor instead.
+func (*BlockedUsers) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BlockedUsers) GetUsers() []*BlockedUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *BlockedUsers) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListBlockedRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBlockedRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ----- Follow suggestions -----
+type SuggestFollowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestFollowsRequest) Reset() {
+	*x = SuggestFollowsRequest{}
+	mi := &file_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestFollowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestFollowsRequest) ProtoMessage() {}
+
+func (x *SuggestFollowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestFollowsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestFollowsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SuggestFollowsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserSummary           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	MutualCount   int32                  `protobuf:"varint,2,opt,name=mutual_count,json=mutualCount,proto3" json:"mutual_count,omitempty"` // сколько ваших подписок подписаны на него
+	FollowsYou    bool                   `protobuf:"varint,3,opt,name=follows_you,json=followsYou,proto3" json:"follows_you,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Suggestion) GetUser() *UserSummary {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Suggestion) GetMutualCount() int32 {
+	if x != nil {
+		return x.MutualCount
+	}
+	return 0
 }
 
-func (x *User) GetBirthDate() string {
+func (x *Suggestion) GetFollowsYou() bool {
 	if x != nil {
-		return x.BirthDate
+		return x.FollowsYou
 	}
-	return ""
+	return false
 }
 
-type Users struct {
+type Suggestions struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Users) Reset() {
-	*x = Users{}
-	mi := &file_user_proto_msgTypes[1]
+func (x *Suggestions) Reset() {
+	*x = Suggestions{}
+	mi := &file_user_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Users) String() string {
+func (x *Suggestions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Users) ProtoMessage() {}
+func (*Suggestions) ProtoMessage() {}
 
-func (x *Users) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[1]
+func (x *Suggestions) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -148,40 +2179,40 @@ func (x *Users) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Users.ProtoReflect.Descriptor instead.
-func (*Users) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Suggestions.ProtoReflect.Descriptor instead.
+func (*Suggestions) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *Users) GetUsers() []*User {
+func (x *Suggestions) GetSuggestions() []*Suggestion {
 	if x != nil {
-		return x.Users
+		return x.Suggestions
 	}
 	return nil
 }
 
-type GetFollowersRequest struct {
+type DismissSuggestionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFollowersRequest) Reset() {
-	*x = GetFollowersRequest{}
-	mi := &file_user_proto_msgTypes[2]
+func (x *DismissSuggestionRequest) Reset() {
+	*x = DismissSuggestionRequest{}
+	mi := &file_user_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFollowersRequest) String() string {
+func (x *DismissSuggestionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFollowersRequest) ProtoMessage() {}
+func (*DismissSuggestionRequest) ProtoMessage() {}
 
-func (x *GetFollowersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[2]
+func (x *DismissSuggestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -192,40 +2223,41 @@ func (x *GetFollowersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFollowersRequest.ProtoReflect.Descriptor instead.
-func (*GetFollowersRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DismissSuggestionRequest.ProtoReflect.Descriptor instead.
+func (*DismissSuggestionRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetFollowersRequest) GetId() string {
+func (x *DismissSuggestionRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-type GetFollowingRequest struct {
+type BatchGetUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Handles       []string               `protobuf:"bytes,2,rep,name=handles,proto3" json:"handles,omitempty"` // для @упоминаний, без учёта регистра
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFollowingRequest) Reset() {
-	*x = GetFollowingRequest{}
-	mi := &file_user_proto_msgTypes[3]
+func (x *BatchGetUsersRequest) Reset() {
+	*x = BatchGetUsersRequest{}
+	mi := &file_user_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFollowingRequest) String() string {
+func (x *BatchGetUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFollowingRequest) ProtoMessage() {}
+func (*BatchGetUsersRequest) ProtoMessage() {}
 
-func (x *GetFollowingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[3]
+func (x *BatchGetUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -236,40 +2268,47 @@ func (x *GetFollowingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFollowingRequest.ProtoReflect.Descriptor instead.
-func (*GetFollowingRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use BatchGetUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetFollowingRequest) GetId() string {
+func (x *BatchGetUsersRequest) GetIds() []string {
 	if x != nil {
-		return x.Id
+		return x.Ids
 	}
-	return ""
+	return nil
 }
 
-type GetUserRequest struct {
+func (x *BatchGetUsersRequest) GetHandles() []string {
+	if x != nil {
+		return x.Handles
+	}
+	return nil
+}
+
+type BatchGetUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Users         []*UserSummary         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // без профиля id в ответ не попадает
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserRequest) Reset() {
-	*x = GetUserRequest{}
-	mi := &file_user_proto_msgTypes[4]
+func (x *BatchGetUsersResponse) Reset() {
+	*x = BatchGetUsersResponse{}
+	mi := &file_user_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserRequest) String() string {
+func (x *BatchGetUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserRequest) ProtoMessage() {}
+func (*BatchGetUsersResponse) ProtoMessage() {}
 
-func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[4]
+func (x *BatchGetUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,42 +2319,41 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
-func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use BatchGetUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetUserRequest) GetId() string {
+func (x *BatchGetUsersResponse) GetUsers() []*UserSummary {
 	if x != nil {
-		return x.Id
+		return x.Users
 	}
-	return ""
+	return nil
 }
 
-type UpdateAvatarRequest struct {
+type CheckRelationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Avatar        []byte                 `protobuf:"bytes,2,opt,name=avatar,proto3" json:"avatar,omitempty"`
-	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OtherIds      []string               `protobuf:"bytes,2,rep,name=other_ids,json=otherIds,proto3" json:"other_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAvatarRequest) Reset() {
-	*x = UpdateAvatarRequest{}
-	mi := &file_user_proto_msgTypes[5]
+func (x *CheckRelationsRequest) Reset() {
+	*x = CheckRelationsRequest{}
+	mi := &file_user_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAvatarRequest) String() string {
+func (x *CheckRelationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAvatarRequest) ProtoMessage() {}
+func (*CheckRelationsRequest) ProtoMessage() {}
 
-func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[5]
+func (x *CheckRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,54 +2364,48 @@ func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAvatarRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAvatarRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CheckRelationsRequest.ProtoReflect.Descriptor instead.
+func (*CheckRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *UpdateAvatarRequest) GetId() string {
+func (x *CheckRelationsRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *UpdateAvatarRequest) GetAvatar() []byte {
+func (x *CheckRelationsRequest) GetOtherIds() []string {
 	if x != nil {
-		return x.Avatar
+		return x.OtherIds
 	}
 	return nil
 }
 
-func (x *UpdateAvatarRequest) GetFilename() string {
-	if x != nil {
-		return x.Filename
-	}
-	return ""
-}
-
-type UpdateAvatarResponse struct {
+type CheckRelationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AvatarUrl     string                 `protobuf:"bytes,1,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	BlockedIds    []string               `protobuf:"bytes,1,rep,name=blocked_ids,json=blockedIds,proto3" json:"blocked_ids,omitempty"` // блокировка в любую сторону
+	MutedIds      []string               `protobuf:"bytes,2,rep,name=muted_ids,json=mutedIds,proto3" json:"muted_ids,omitempty"`       // user_id заглушил
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAvatarResponse) Reset() {
-	*x = UpdateAvatarResponse{}
-	mi := &file_user_proto_msgTypes[6]
+func (x *CheckRelationsResponse) Reset() {
+	*x = CheckRelationsResponse{}
+	mi := &file_user_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAvatarResponse) String() string {
+func (x *CheckRelationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAvatarResponse) ProtoMessage() {}
+func (*CheckRelationsResponse) ProtoMessage() {}
 
-func (x *UpdateAvatarResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[6]
+func (x *CheckRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,44 +2416,48 @@ func (x *UpdateAvatarResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAvatarResponse.ProtoReflect.Descriptor instead.
-func (*UpdateAvatarResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use CheckRelationsResponse.ProtoReflect.Descriptor instead.
+func (*CheckRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *UpdateAvatarResponse) GetAvatarUrl() string {
+func (x *CheckRelationsResponse) GetBlockedIds() []string {
 	if x != nil {
-		return x.AvatarUrl
+		return x.BlockedIds
 	}
-	return ""
+	return nil
 }
 
-type UpdateUserRequest struct {
+func (x *CheckRelationsResponse) GetMutedIds() []string {
+	if x != nil {
+		return x.MutedIds
+	}
+	return nil
+}
+
+type GetHiddenAuthorsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FirstName     string                 `protobuf:"bytes,2,opt,name=firstName,proto3" json:"firstName,omitempty"`
-	LastName      string                 `protobuf:"bytes,3,opt,name=lastName,proto3" json:"lastName,omitempty"`
-	BirthDate     string                 `protobuf:"bytes,4,opt,name=birthDate,proto3" json:"birthDate,omitempty"`
-	Bio           string                 `protobuf:"bytes,5,opt,name=bio,proto3" json:"bio,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // пусто — анонимный просмотр
+	AuthorIds     []string               `protobuf:"bytes,2,rep,name=author_ids,json=authorIds,proto3" json:"author_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserRequest) Reset() {
-	*x = UpdateUserRequest{}
-	mi := &file_user_proto_msgTypes[7]
+func (x *GetHiddenAuthorsRequest) Reset() {
+	*x = GetHiddenAuthorsRequest{}
+	mi := &file_user_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserRequest) String() string {
+func (x *GetHiddenAuthorsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserRequest) ProtoMessage() {}
+func (*GetHiddenAuthorsRequest) ProtoMessage() {}
 
-func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[7]
+func (x *GetHiddenAuthorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,68 +2468,47 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *UpdateUserRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetFirstName() string {
-	if x != nil {
-		return x.FirstName
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetLastName() string {
-	if x != nil {
-		return x.LastName
-	}
-	return ""
+// Deprecated: Use GetHiddenAuthorsRequest.ProtoReflect.Descriptor instead.
+func (*GetHiddenAuthorsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *UpdateUserRequest) GetBirthDate() string {
+func (x *GetHiddenAuthorsRequest) GetViewerId() string {
 	if x != nil {
-		return x.BirthDate
+		return x.ViewerId
 	}
 	return ""
 }
 
-func (x *UpdateUserRequest) GetBio() string {
+func (x *GetHiddenAuthorsRequest) GetAuthorIds() []string {
 	if x != nil {
-		return x.Bio
+		return x.AuthorIds
 	}
-	return ""
+	return nil
 }
 
-type DeleteUserRequest struct {
+type GetHiddenAuthorsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorIds     []string               `protobuf:"bytes,1,rep,name=author_ids,json=authorIds,proto3" json:"author_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserRequest) Reset() {
-	*x = DeleteUserRequest{}
-	mi := &file_user_proto_msgTypes[8]
+func (x *GetHiddenAuthorsResponse) Reset() {
+	*x = GetHiddenAuthorsResponse{}
+	mi := &file_user_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserRequest) String() string {
+func (x *GetHiddenAuthorsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserRequest) ProtoMessage() {}
+func (*GetHiddenAuthorsResponse) ProtoMessage() {}
 
-func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[8]
+func (x *GetHiddenAuthorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -504,39 +2519,40 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
-func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetHiddenAuthorsResponse.ProtoReflect.Descriptor instead.
+func (*GetHiddenAuthorsResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *DeleteUserRequest) GetId() string {
+func (x *GetHiddenAuthorsResponse) GetAuthorIds() []string {
 	if x != nil {
-		return x.Id
+		return x.AuthorIds
 	}
-	return ""
+	return nil
 }
 
-type EmptyRequest struct {
+type UnfollowUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EmptyRequest) Reset() {
-	*x = EmptyRequest{}
-	mi := &file_user_proto_msgTypes[9]
+func (x *UnfollowUserRequest) Reset() {
+	*x = UnfollowUserRequest{}
+	mi := &file_user_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmptyRequest) String() string {
+func (x *UnfollowUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyRequest) ProtoMessage() {}
+func (*UnfollowUserRequest) ProtoMessage() {}
 
-func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[9]
+func (x *UnfollowUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -547,33 +2563,41 @@ func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
-func (*EmptyRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use UnfollowUserRequest.ProtoReflect.Descriptor instead.
+func (*UnfollowUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{44}
 }
 
-type FollowUserRequest struct {
+func (x *UnfollowUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ----- Data export -----
+type ExportUserDataRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FollowUserRequest) Reset() {
-	*x = FollowUserRequest{}
-	mi := &file_user_proto_msgTypes[10]
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_user_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FollowUserRequest) String() string {
+func (x *ExportUserDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FollowUserRequest) ProtoMessage() {}
+func (*ExportUserDataRequest) ProtoMessage() {}
 
-func (x *FollowUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[10]
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,40 +2608,42 @@ func (x *FollowUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FollowUserRequest.ProtoReflect.Descriptor instead.
-func (*FollowUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *FollowUserRequest) GetId() string {
+func (x *ExportUserDataRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-type UnfollowUserRequest struct {
+type ExportUserDataResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Profile       *User                  `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Following     []string               `protobuf:"bytes,2,rep,name=following,proto3" json:"following,omitempty"` // id пользователей
+	Followers     []string               `protobuf:"bytes,3,rep,name=followers,proto3" json:"followers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnfollowUserRequest) Reset() {
-	*x = UnfollowUserRequest{}
-	mi := &file_user_proto_msgTypes[11]
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_user_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnfollowUserRequest) String() string {
+func (x *ExportUserDataResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnfollowUserRequest) ProtoMessage() {}
+func (*ExportUserDataResponse) ProtoMessage() {}
 
-func (x *UnfollowUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[11]
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,16 +2654,30 @@ func (x *UnfollowUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnfollowUserRequest.ProtoReflect.Descriptor instead.
-func (*UnfollowUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *UnfollowUserRequest) GetId() string {
+func (x *ExportUserDataResponse) GetProfile() *User {
 	if x != nil {
-		return x.Id
+		return x.Profile
 	}
-	return ""
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetFollowing() []string {
+	if x != nil {
+		return x.Following
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetFollowers() []string {
+	if x != nil {
+		return x.Followers
+	}
+	return nil
 }
 
 var File_user_proto protoreflect.FileDescriptor
@@ -646,7 +2686,7 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
 	"user.proto\x12\x04user\x1a\x1cgoogle/api/annotations.proto\x1a\n" +
-	"auth.proto\"\xbf\x01\n" +
+	"auth.proto\x1a google/protobuf/field_mask.proto\"\xeb\x05\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\tfirstName\x18\x02 \x01(\tR\tfirstName\x12\x1a\n" +
@@ -657,14 +2697,69 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"birth_date\x18\a \x01(\tR\tbirthDate\")\n" +
+	"birth_date\x18\a \x01(\tR\tbirthDate\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\b \x01(\bR\tisPrivate\x12%\n" +
+	"\x0efollower_count\x18\t \x01(\x03R\rfollowerCount\x12'\n" +
+	"\x0ffollowing_count\x18\n" +
+	" \x01(\x03R\x0efollowingCount\x12)\n" +
+	"\x11is_followed_by_me\x18\v \x01(\bR\x0eisFollowedByMe\x12\x16\n" +
+	"\x06handle\x18\f \x01(\tR\x06handle\x12\x1a\n" +
+	"\blocation\x18\r \x01(\tR\blocation\x12'\n" +
+	"\x05links\x18\x0e \x03(\v2\x11.user.ProfileLinkR\x05links\x12\x1a\n" +
+	"\bpronouns\x18\x0f \x01(\tR\bpronouns\x12\x1b\n" +
+	"\tcover_url\x18\x10 \x01(\tR\bcoverUrl\x12&\n" +
+	"\x04work\x18\x11 \x03(\v2\x12.user.ProfileEntryR\x04work\x120\n" +
+	"\teducation\x18\x12 \x03(\v2\x12.user.ProfileEntryR\teducation\x12J\n" +
+	"\x10field_visibility\x18\x13 \x03(\v2\x1f.user.User.FieldVisibilityEntryR\x0ffieldVisibility\x1aT\n" +
+	"\x14FieldVisibilityEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x10.user.VisibilityR\x05value:\x028\x01\"5\n" +
+	"\vProfileLink\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"\x82\x01\n" +
+	"\fProfileEntry\x12\"\n" +
+	"\forganization\x18\x01 \x01(\tR\forganization\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"start_year\x18\x03 \x01(\x05R\tstartYear\x12\x19\n" +
+	"\bend_year\x18\x04 \x01(\x05R\aendYear\"\x90\x01\n" +
+	"\vUserSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12\x16\n" +
+	"\x06handle\x18\x05 \x01(\tR\x06handle\"Q\n" +
 	"\x05Users\x12 \n" +
 	"\x05users\x18\x01 \x03(\v2\n" +
-	".user.UserR\x05users\"%\n" +
+	".user.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"a\n" +
 	"\x13GetFollowersRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"a\n" +
 	"\x13GetFollowingRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\" \n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"R\n" +
+	"\x1cGetFollowRelationshipRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bother_id\x18\x02 \x01(\tR\aotherId\"\x9c\x01\n" +
+	"\x12FollowRelationship\x12\x1c\n" +
+	"\tfollowing\x18\x01 \x01(\bR\tfollowing\x12\x1f\n" +
+	"\vfollowed_by\x18\x02 \x01(\bR\n" +
+	"followedBy\x12\x1c\n" +
+	"\trequested\x18\x03 \x01(\bR\trequested\x12)\n" +
+	"\x10request_received\x18\x04 \x01(\bR\x0frequestReceived\"0\n" +
+	"\x16GetUserByHandleRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\tR\x06handle\"C\n" +
+	"\x10SetHandleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06handle\x18\x02 \x01(\tR\x06handle\" \n" +
 	"\x0eGetUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"Y\n" +
 	"\x13UpdateAvatarRequest\x12\x0e\n" +
@@ -673,32 +2768,158 @@ const file_user_proto_rawDesc = "" +
 	"\bfilename\x18\x03 \x01(\tR\bfilename\"5\n" +
 	"\x14UpdateAvatarResponse\x12\x1d\n" +
 	"\n" +
-	"avatar_url\x18\x01 \x01(\tR\tavatarUrl\"\x8d\x01\n" +
+	"avatar_url\x18\x01 \x01(\tR\tavatarUrl\"V\n" +
+	"\x12UpdateCoverRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05cover\x18\x02 \x01(\fR\x05cover\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\"2\n" +
+	"\x13UpdateCoverResponse\x12\x1b\n" +
+	"\tcover_url\x18\x01 \x01(\tR\bcoverUrl\"\xb4\x04\n" +
 	"\x11UpdateUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\tfirstName\x18\x02 \x01(\tR\tfirstName\x12\x1a\n" +
 	"\blastName\x18\x03 \x01(\tR\blastName\x12\x1c\n" +
 	"\tbirthDate\x18\x04 \x01(\tR\tbirthDate\x12\x10\n" +
-	"\x03bio\x18\x05 \x01(\tR\x03bio\"#\n" +
+	"\x03bio\x18\x05 \x01(\tR\x03bio\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12'\n" +
+	"\x05links\x18\a \x03(\v2\x11.user.ProfileLinkR\x05links\x12\x1a\n" +
+	"\bpronouns\x18\b \x01(\tR\bpronouns\x12&\n" +
+	"\x04work\x18\t \x03(\v2\x12.user.ProfileEntryR\x04work\x120\n" +
+	"\teducation\x18\n" +
+	" \x03(\v2\x12.user.ProfileEntryR\teducation\x12W\n" +
+	"\x10field_visibility\x18\v \x03(\v2,.user.UpdateUserRequest.FieldVisibilityEntryR\x0ffieldVisibility\x12;\n" +
+	"\vupdate_mask\x18\f \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x1aT\n" +
+	"\x14FieldVisibilityEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x10.user.VisibilityR\x05value:\x028\x01\"#\n" +
 	"\x11DeleteUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x0e\n" +
-	"\fEmptyRequest\"#\n" +
+	"\fEmptyRequest\"N\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"#\n" +
 	"\x11FollowUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"9\n" +
+	"\x18SetAccountPrivacyRequest\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x01 \x01(\bR\tisPrivate\"G\n" +
+	"\rFollowRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\tR\tcreatedAt\"i\n" +
+	"\x0eFollowRequests\x12/\n" +
+	"\brequests\x18\x01 \x03(\v2\x13.user.FollowRequestR\brequests\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"W\n" +
+	"\x19ListFollowRequestsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"6\n" +
+	"\x1bApproveFollowRequestRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"5\n" +
+	"\x1aRejectFollowRequestRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\"\n" +
+	"\x10BlockUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"$\n" +
+	"\x12UnblockUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\x0fMuteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
+	"\x11UnmuteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
+	"\vBlockedUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\tR\tcreatedAt\"_\n" +
+	"\fBlockedUsers\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.user.BlockedUserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"P\n" +
+	"\x12ListBlockedRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"4\n" +
+	"\x15SuggestFollowsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\"w\n" +
+	"\n" +
+	"Suggestion\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.user.UserSummaryR\x04user\x12!\n" +
+	"\fmutual_count\x18\x02 \x01(\x05R\vmutualCount\x12\x1f\n" +
+	"\vfollows_you\x18\x03 \x01(\bR\n" +
+	"followsYou\"A\n" +
+	"\vSuggestions\x122\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x10.user.SuggestionR\vsuggestions\"3\n" +
+	"\x18DismissSuggestionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
+	"\x14BatchGetUsersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x18\n" +
+	"\ahandles\x18\x02 \x03(\tR\ahandles\"@\n" +
+	"\x15BatchGetUsersResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.user.UserSummaryR\x05users\"M\n" +
+	"\x15CheckRelationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tother_ids\x18\x02 \x03(\tR\botherIds\"V\n" +
+	"\x16CheckRelationsResponse\x12\x1f\n" +
+	"\vblocked_ids\x18\x01 \x03(\tR\n" +
+	"blockedIds\x12\x1b\n" +
+	"\tmuted_ids\x18\x02 \x03(\tR\bmutedIds\"U\n" +
+	"\x17GetHiddenAuthorsRequest\x12\x1b\n" +
+	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x1d\n" +
+	"\n" +
+	"author_ids\x18\x02 \x03(\tR\tauthorIds\"9\n" +
+	"\x18GetHiddenAuthorsResponse\x12\x1d\n" +
+	"\n" +
+	"author_ids\x18\x01 \x03(\tR\tauthorIds\"%\n" +
 	"\x13UnfollowUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xe1\x05\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"z\n" +
+	"\x16ExportUserDataResponse\x12$\n" +
+	"\aprofile\x18\x01 \x01(\v2\n" +
+	".user.UserR\aprofile\x12\x1c\n" +
+	"\tfollowing\x18\x02 \x03(\tR\tfollowing\x12\x1c\n" +
+	"\tfollowers\x18\x03 \x03(\tR\tfollowers*q\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11VISIBILITY_PUBLIC\x10\x01\x12\x18\n" +
+	"\x14VISIBILITY_FOLLOWERS\x10\x02\x12\x16\n" +
+	"\x12VISIBILITY_ONLY_ME\x10\x032\x8c\x15\n" +
 	"\vUserService\x12G\n" +
 	"\aGetUser\x12\x14.user.GetUserRequest\x1a\n" +
-	".user.User\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/users/{id}\x12X\n" +
+	".user.User\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/users/{id}\x12W\n" +
+	"\x0fGetUserByHandle\x12\x1c.user.GetUserByHandleRequest\x1a\n" +
+	".user.User\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/u/{handle}\x12X\n" +
 	"\n" +
-	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x12.auth.Confirmation\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*2\x12/api/v1/users/{id}\x12C\n" +
-	"\tListUsers\x12\x12.user.EmptyRequest\x1a\v.user.Users\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/users\x12_\n" +
+	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x12.auth.Confirmation\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*2\x12/api/v1/users/{id}\x12G\n" +
+	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\v.user.Users\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/users\x12_\n" +
 	"\n" +
 	"FollowUser\x12\x17.user.FollowUserRequest\x1a\x12.auth.Confirmation\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/{id}/follow\x12`\n" +
 	"\fUnfollowUser\x12\x19.user.UnfollowUserRequest\x1a\x12.auth.Confirmation\"!\x82\xd3\xe4\x93\x02\x1b*\x19/api/v1/users/{id}/follow\x12k\n" +
-	"\fUpdateAvatar\x12\x19.user.UpdateAvatarRequest\x1a\x1a.user.UpdateAvatarResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/{id}/avatar\x12\\\n" +
+	"\fUpdateAvatar\x12\x19.user.UpdateAvatarRequest\x1a\x1a.user.UpdateAvatarResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/{id}/avatar\x12g\n" +
+	"\vUpdateCover\x12\x18.user.UpdateCoverRequest\x1a\x19.user.UpdateCoverResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/users/{id}/cover\x12\\\n" +
 	"\fGetFollowers\x12\x19.user.GetFollowersRequest\x1a\v.user.Users\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/users/{id}/followers\x12\\\n" +
-	"\fGetFollowing\x12\x19.user.GetFollowingRequest\x1a\v.user.Users\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/users/{id}/followingB$Z\"socialnet/services/user/gen;userpbb\x06proto3"
+	"\fGetFollowing\x12\x19.user.GetFollowingRequest\x1a\v.user.Users\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/users/{id}/following\x12\x8e\x01\n" +
+	"\x15GetFollowRelationship\x12\".user.GetFollowRelationshipRequest\x1a\x18.user.FollowRelationship\"7\x82\xd3\xe4\x93\x021\x12//api/v1/users/{user_id}/relationship/{other_id}\x12l\n" +
+	"\x11SetAccountPrivacy\x12\x1e.user.SetAccountPrivacyRequest\x1a\x12.auth.Confirmation\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\x1a\x18/api/v1/users/me/privacy\x12u\n" +
+	"\x12ListFollowRequests\x12\x1f.user.ListFollowRequestsRequest\x1a\x14.user.FollowRequests\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/users/me/follow-requests\x12\x8c\x01\n" +
+	"\x14ApproveFollowRequest\x12!.user.ApproveFollowRequestRequest\x1a\x12.auth.Confirmation\"=\x82\xd3\xe4\x93\x027:\x01*\"2/api/v1/users/me/follow-requests/{user_id}/approve\x12\x7f\n" +
+	"\x13RejectFollowRequest\x12 .user.RejectFollowRequestRequest\x1a\x12.auth.Confirmation\"2\x82\xd3\xe4\x93\x02,**/api/v1/users/me/follow-requests/{user_id}\x12\\\n" +
+	"\tBlockUser\x12\x16.user.BlockUserRequest\x1a\x12.auth.Confirmation\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/users/{id}/block\x12]\n" +
+	"\vUnblockUser\x12\x18.user.UnblockUserRequest\x1a\x12.auth.Confirmation\" \x82\xd3\xe4\x93\x02\x1a*\x18/api/v1/users/{id}/block\x12Y\n" +
+	"\bMuteUser\x12\x15.user.MuteUserRequest\x1a\x12.auth.Confirmation\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/users/{id}/mute\x12Z\n" +
+	"\n" +
+	"UnmuteUser\x12\x17.user.UnmuteUserRequest\x1a\x12.auth.Confirmation\"\x1f\x82\xd3\xe4\x93\x02\x19*\x17/api/v1/users/{id}/mute\x12]\n" +
+	"\vListBlocked\x12\x18.user.ListBlockedRequest\x1a\x12.user.BlockedUsers\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/users/me/blocked\x12f\n" +
+	"\x0eSuggestFollows\x12\x1b.user.SuggestFollowsRequest\x1a\x11.user.Suggestions\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/users/me/suggestions\x12w\n" +
+	"\x11DismissSuggestion\x12\x1e.user.DismissSuggestionRequest\x1a\x12.auth.Confirmation\".\x82\xd3\xe4\x93\x02(*&/api/v1/users/me/suggestions/{user_id}\x127\n" +
+	"\tSetHandle\x12\x16.user.SetHandleRequest\x1a\x12.auth.Confirmation\x12H\n" +
+	"\rBatchGetUsers\x12\x1a.user.BatchGetUsersRequest\x1a\x1b.user.BatchGetUsersResponse\x12K\n" +
+	"\x0eCheckRelations\x12\x1b.user.CheckRelationsRequest\x1a\x1c.user.CheckRelationsResponse\x12Q\n" +
+	"\x10GetHiddenAuthors\x12\x1d.user.GetHiddenAuthorsRequest\x1a\x1e.user.GetHiddenAuthorsResponse\x129\n" +
+	"\n" +
+	"DeleteUser\x12\x17.user.DeleteUserRequest\x1a\x12.auth.Confirmation\x12K\n" +
+	"\x0eExportUserData\x12\x1b.user.ExportUserDataRequest\x1a\x1c.user.ExportUserDataResponseB$Z\"socialnet/services/user/gen;userpbb\x06proto3"
 
 var (
 	file_user_proto_rawDescOnce sync.Once
@@ -712,45 +2933,142 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_user_proto_goTypes = []any{
-	(*User)(nil),                 // 0: user.User
-	(*Users)(nil),                // 1: user.Users
-	(*GetFollowersRequest)(nil),  // 2: user.GetFollowersRequest
-	(*GetFollowingRequest)(nil),  // 3: user.GetFollowingRequest
-	(*GetUserRequest)(nil),       // 4: user.GetUserRequest
-	(*UpdateAvatarRequest)(nil),  // 5: user.UpdateAvatarRequest
-	(*UpdateAvatarResponse)(nil), // 6: user.UpdateAvatarResponse
-	(*UpdateUserRequest)(nil),    // 7: user.UpdateUserRequest
-	(*DeleteUserRequest)(nil),    // 8: user.DeleteUserRequest
-	(*EmptyRequest)(nil),         // 9: user.EmptyRequest
-	(*FollowUserRequest)(nil),    // 10: user.FollowUserRequest
-	(*UnfollowUserRequest)(nil),  // 11: user.UnfollowUserRequest
-	(*gen.Confirmation)(nil),     // 12: auth.Confirmation
+	(Visibility)(0),                      // 0: user.Visibility
+	(*User)(nil),                         // 1: user.User
+	(*ProfileLink)(nil),                  // 2: user.ProfileLink
+	(*ProfileEntry)(nil),                 // 3: user.ProfileEntry
+	(*UserSummary)(nil),                  // 4: user.UserSummary
+	(*Users)(nil),                        // 5: user.Users
+	(*GetFollowersRequest)(nil),          // 6: user.GetFollowersRequest
+	(*GetFollowingRequest)(nil),          // 7: user.GetFollowingRequest
+	(*GetFollowRelationshipRequest)(nil), // 8: user.GetFollowRelationshipRequest
+	(*FollowRelationship)(nil),           // 9: user.FollowRelationship
+	(*GetUserByHandleRequest)(nil),       // 10: user.GetUserByHandleRequest
+	(*SetHandleRequest)(nil),             // 11: user.SetHandleRequest
+	(*GetUserRequest)(nil),               // 12: user.GetUserRequest
+	(*UpdateAvatarRequest)(nil),          // 13: user.UpdateAvatarRequest
+	(*UpdateAvatarResponse)(nil),         // 14: user.UpdateAvatarResponse
+	(*UpdateCoverRequest)(nil),           // 15: user.UpdateCoverRequest
+	(*UpdateCoverResponse)(nil),          // 16: user.UpdateCoverResponse
+	(*UpdateUserRequest)(nil),            // 17: user.UpdateUserRequest
+	(*DeleteUserRequest)(nil),            // 18: user.DeleteUserRequest
+	(*EmptyRequest)(nil),                 // 19: user.EmptyRequest
+	(*ListUsersRequest)(nil),             // 20: user.ListUsersRequest
+	(*FollowUserRequest)(nil),            // 21: user.FollowUserRequest
+	(*SetAccountPrivacyRequest)(nil),     // 22: user.SetAccountPrivacyRequest
+	(*FollowRequest)(nil),                // 23: user.FollowRequest
+	(*FollowRequests)(nil),               // 24: user.FollowRequests
+	(*ListFollowRequestsRequest)(nil),    // 25: user.ListFollowRequestsRequest
+	(*ApproveFollowRequestRequest)(nil),  // 26: user.ApproveFollowRequestRequest
+	(*RejectFollowRequestRequest)(nil),   // 27: user.RejectFollowRequestRequest
+	(*BlockUserRequest)(nil),             // 28: user.BlockUserRequest
+	(*UnblockUserRequest)(nil),           // 29: user.UnblockUserRequest
+	(*MuteUserRequest)(nil),              // 30: user.MuteUserRequest
+	(*UnmuteUserRequest)(nil),            // 31: user.UnmuteUserRequest
+	(*BlockedUser)(nil),                  // 32: user.BlockedUser
+	(*BlockedUsers)(nil),                 // 33: user.BlockedUsers
+	(*ListBlockedRequest)(nil),           // 34: user.ListBlockedRequest
+	(*SuggestFollowsRequest)(nil),        // 35: user.SuggestFollowsRequest
+	(*Suggestion)(nil),                   // 36: user.Suggestion
+	(*Suggestions)(nil),                  // 37: user.Suggestions
+	(*DismissSuggestionRequest)(nil),     // 38: user.DismissSuggestionRequest
+	(*BatchGetUsersRequest)(nil),         // 39: user.BatchGetUsersRequest
+	(*BatchGetUsersResponse)(nil),        // 40: user.BatchGetUsersResponse
+	(*CheckRelationsRequest)(nil),        // 41: user.CheckRelationsRequest
+	(*CheckRelationsResponse)(nil),       // 42: user.CheckRelationsResponse
+	(*GetHiddenAuthorsRequest)(nil),      // 43: user.GetHiddenAuthorsRequest
+	(*GetHiddenAuthorsResponse)(nil),     // 44: user.GetHiddenAuthorsResponse
+	(*UnfollowUserRequest)(nil),          // 45: user.UnfollowUserRequest
+	(*ExportUserDataRequest)(nil),        // 46: user.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),       // 47: user.ExportUserDataResponse
+	nil,                                  // 48: user.User.FieldVisibilityEntry
+	nil,                                  // 49: user.UpdateUserRequest.FieldVisibilityEntry
+	(*fieldmaskpb.FieldMask)(nil),        // 50: google.protobuf.FieldMask
+	(*gen.Confirmation)(nil),             // 51: auth.Confirmation
 }
 var file_user_proto_depIdxs = []int32{
-	0,  // 0: user.Users.users:type_name -> user.User
-	4,  // 1: user.UserService.GetUser:input_type -> user.GetUserRequest
-	7,  // 2: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	9,  // 3: user.UserService.ListUsers:input_type -> user.EmptyRequest
-	10, // 4: user.UserService.FollowUser:input_type -> user.FollowUserRequest
-	11, // 5: user.UserService.UnfollowUser:input_type -> user.UnfollowUserRequest
-	5,  // 6: user.UserService.UpdateAvatar:input_type -> user.UpdateAvatarRequest
-	2,  // 7: user.UserService.GetFollowers:input_type -> user.GetFollowersRequest
-	3,  // 8: user.UserService.GetFollowing:input_type -> user.GetFollowingRequest
-	0,  // 9: user.UserService.GetUser:output_type -> user.User
-	12, // 10: user.UserService.UpdateUser:output_type -> auth.Confirmation
-	1,  // 11: user.UserService.ListUsers:output_type -> user.Users
-	12, // 12: user.UserService.FollowUser:output_type -> auth.Confirmation
-	12, // 13: user.UserService.UnfollowUser:output_type -> auth.Confirmation
-	6,  // 14: user.UserService.UpdateAvatar:output_type -> user.UpdateAvatarResponse
-	1,  // 15: user.UserService.GetFollowers:output_type -> user.Users
-	1,  // 16: user.UserService.GetFollowing:output_type -> user.Users
-	9,  // [9:17] is the sub-list for method output_type
-	1,  // [1:9] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	2,  // 0: user.User.links:type_name -> user.ProfileLink
+	3,  // 1: user.User.work:type_name -> user.ProfileEntry
+	3,  // 2: user.User.education:type_name -> user.ProfileEntry
+	48, // 3: user.User.field_visibility:type_name -> user.User.FieldVisibilityEntry
+	1,  // 4: user.Users.users:type_name -> user.User
+	2,  // 5: user.UpdateUserRequest.links:type_name -> user.ProfileLink
+	3,  // 6: user.UpdateUserRequest.work:type_name -> user.ProfileEntry
+	3,  // 7: user.UpdateUserRequest.education:type_name -> user.ProfileEntry
+	49, // 8: user.UpdateUserRequest.field_visibility:type_name -> user.UpdateUserRequest.FieldVisibilityEntry
+	50, // 9: user.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	23, // 10: user.FollowRequests.requests:type_name -> user.FollowRequest
+	32, // 11: user.BlockedUsers.users:type_name -> user.BlockedUser
+	4,  // 12: user.Suggestion.user:type_name -> user.UserSummary
+	36, // 13: user.Suggestions.suggestions:type_name -> user.Suggestion
+	4,  // 14: user.BatchGetUsersResponse.users:type_name -> user.UserSummary
+	1,  // 15: user.ExportUserDataResponse.profile:type_name -> user.User
+	0,  // 16: user.User.FieldVisibilityEntry.value:type_name -> user.Visibility
+	0,  // 17: user.UpdateUserRequest.FieldVisibilityEntry.value:type_name -> user.Visibility
+	12, // 18: user.UserService.GetUser:input_type -> user.GetUserRequest
+	10, // 19: user.UserService.GetUserByHandle:input_type -> user.GetUserByHandleRequest
+	17, // 20: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	20, // 21: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	21, // 22: user.UserService.FollowUser:input_type -> user.FollowUserRequest
+	45, // 23: user.UserService.UnfollowUser:input_type -> user.UnfollowUserRequest
+	13, // 24: user.UserService.UpdateAvatar:input_type -> user.UpdateAvatarRequest
+	15, // 25: user.UserService.UpdateCover:input_type -> user.UpdateCoverRequest
+	6,  // 26: user.UserService.GetFollowers:input_type -> user.GetFollowersRequest
+	7,  // 27: user.UserService.GetFollowing:input_type -> user.GetFollowingRequest
+	8,  // 28: user.UserService.GetFollowRelationship:input_type -> user.GetFollowRelationshipRequest
+	22, // 29: user.UserService.SetAccountPrivacy:input_type -> user.SetAccountPrivacyRequest
+	25, // 30: user.UserService.ListFollowRequests:input_type -> user.ListFollowRequestsRequest
+	26, // 31: user.UserService.ApproveFollowRequest:input_type -> user.ApproveFollowRequestRequest
+	27, // 32: user.UserService.RejectFollowRequest:input_type -> user.RejectFollowRequestRequest
+	28, // 33: user.UserService.BlockUser:input_type -> user.BlockUserRequest
+	29, // 34: user.UserService.UnblockUser:input_type -> user.UnblockUserRequest
+	30, // 35: user.UserService.MuteUser:input_type -> user.MuteUserRequest
+	31, // 36: user.UserService.UnmuteUser:input_type -> user.UnmuteUserRequest
+	34, // 37: user.UserService.ListBlocked:input_type -> user.ListBlockedRequest
+	35, // 38: user.UserService.SuggestFollows:input_type -> user.SuggestFollowsRequest
+	38, // 39: user.UserService.DismissSuggestion:input_type -> user.DismissSuggestionRequest
+	11, // 40: user.UserService.SetHandle:input_type -> user.SetHandleRequest
+	39, // 41: user.UserService.BatchGetUsers:input_type -> user.BatchGetUsersRequest
+	41, // 42: user.UserService.CheckRelations:input_type -> user.CheckRelationsRequest
+	43, // 43: user.UserService.GetHiddenAuthors:input_type -> user.GetHiddenAuthorsRequest
+	18, // 44: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	46, // 45: user.UserService.ExportUserData:input_type -> user.ExportUserDataRequest
+	1,  // 46: user.UserService.GetUser:output_type -> user.User
+	1,  // 47: user.UserService.GetUserByHandle:output_type -> user.User
+	51, // 48: user.UserService.UpdateUser:output_type -> auth.Confirmation
+	5,  // 49: user.UserService.ListUsers:output_type -> user.Users
+	51, // 50: user.UserService.FollowUser:output_type -> auth.Confirmation
+	51, // 51: user.UserService.UnfollowUser:output_type -> auth.Confirmation
+	14, // 52: user.UserService.UpdateAvatar:output_type -> user.UpdateAvatarResponse
+	16, // 53: user.UserService.UpdateCover:output_type -> user.UpdateCoverResponse
+	5,  // 54: user.UserService.GetFollowers:output_type -> user.Users
+	5,  // 55: user.UserService.GetFollowing:output_type -> user.Users
+	9,  // 56: user.UserService.GetFollowRelationship:output_type -> user.FollowRelationship
+	51, // 57: user.UserService.SetAccountPrivacy:output_type -> auth.Confirmation
+	24, // 58: user.UserService.ListFollowRequests:output_type -> user.FollowRequests
+	51, // 59: user.UserService.ApproveFollowRequest:output_type -> auth.Confirmation
+	51, // 60: user.UserService.RejectFollowRequest:output_type -> auth.Confirmation
+	51, // 61: user.UserService.BlockUser:output_type -> auth.Confirmation
+	51, // 62: user.UserService.UnblockUser:output_type -> auth.Confirmation
+	51, // 63: user.UserService.MuteUser:output_type -> auth.Confirmation
+	51, // 64: user.UserService.UnmuteUser:output_type -> auth.Confirmation
+	33, // 65: user.UserService.ListBlocked:output_type -> user.BlockedUsers
+	37, // 66: user.UserService.SuggestFollows:output_type -> user.Suggestions
+	51, // 67: user.UserService.DismissSuggestion:output_type -> auth.Confirmation
+	51, // 68: user.UserService.SetHandle:output_type -> auth.Confirmation
+	40, // 69: user.UserService.BatchGetUsers:output_type -> user.BatchGetUsersResponse
+	42, // 70: user.UserService.CheckRelations:output_type -> user.CheckRelationsResponse
+	44, // 71: user.UserService.GetHiddenAuthors:output_type -> user.GetHiddenAuthorsResponse
+	51, // 72: user.UserService.DeleteUser:output_type -> auth.Confirmation
+	47, // 73: user.UserService.ExportUserData:output_type -> user.ExportUserDataResponse
+	46, // [46:74] is the sub-list for method output_type
+	18, // [18:46] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -763,13 +3081,14 @@ func file_user_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      1,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_user_proto_goTypes,
 		DependencyIndexes: file_user_proto_depIdxs,
+		EnumInfos:         file_user_proto_enumTypes,
 		MessageInfos:      file_user_proto_msgTypes,
 	}.Build()
 	File_user_proto = out.File
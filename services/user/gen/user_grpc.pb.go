@@ -20,14 +20,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetUser_FullMethodName      = "/user.UserService/GetUser"
-	UserService_UpdateUser_FullMethodName   = "/user.UserService/UpdateUser"
-	UserService_ListUsers_FullMethodName    = "/user.UserService/ListUsers"
-	UserService_FollowUser_FullMethodName   = "/user.UserService/FollowUser"
-	UserService_UnfollowUser_FullMethodName = "/user.UserService/UnfollowUser"
-	UserService_UpdateAvatar_FullMethodName = "/user.UserService/UpdateAvatar"
-	UserService_GetFollowers_FullMethodName = "/user.UserService/GetFollowers"
-	UserService_GetFollowing_FullMethodName = "/user.UserService/GetFollowing"
+	UserService_GetUser_FullMethodName               = "/user.UserService/GetUser"
+	UserService_GetUserByHandle_FullMethodName       = "/user.UserService/GetUserByHandle"
+	UserService_UpdateUser_FullMethodName            = "/user.UserService/UpdateUser"
+	UserService_ListUsers_FullMethodName             = "/user.UserService/ListUsers"
+	UserService_FollowUser_FullMethodName            = "/user.UserService/FollowUser"
+	UserService_UnfollowUser_FullMethodName          = "/user.UserService/UnfollowUser"
+	UserService_UpdateAvatar_FullMethodName          = "/user.UserService/UpdateAvatar"
+	UserService_UpdateCover_FullMethodName           = "/user.UserService/UpdateCover"
+	UserService_GetFollowers_FullMethodName          = "/user.UserService/GetFollowers"
+	UserService_GetFollowing_FullMethodName          = "/user.UserService/GetFollowing"
+	UserService_GetFollowRelationship_FullMethodName = "/user.UserService/GetFollowRelationship"
+	UserService_SetAccountPrivacy_FullMethodName     = "/user.UserService/SetAccountPrivacy"
+	UserService_ListFollowRequests_FullMethodName    = "/user.UserService/ListFollowRequests"
+	UserService_ApproveFollowRequest_FullMethodName  = "/user.UserService/ApproveFollowRequest"
+	UserService_RejectFollowRequest_FullMethodName   = "/user.UserService/RejectFollowRequest"
+	UserService_BlockUser_FullMethodName             = "/user.UserService/BlockUser"
+	UserService_UnblockUser_FullMethodName           = "/user.UserService/UnblockUser"
+	UserService_MuteUser_FullMethodName              = "/user.UserService/MuteUser"
+	UserService_UnmuteUser_FullMethodName            = "/user.UserService/UnmuteUser"
+	UserService_ListBlocked_FullMethodName           = "/user.UserService/ListBlocked"
+	UserService_SuggestFollows_FullMethodName        = "/user.UserService/SuggestFollows"
+	UserService_DismissSuggestion_FullMethodName     = "/user.UserService/DismissSuggestion"
+	UserService_SetHandle_FullMethodName             = "/user.UserService/SetHandle"
+	UserService_BatchGetUsers_FullMethodName         = "/user.UserService/BatchGetUsers"
+	UserService_CheckRelations_FullMethodName        = "/user.UserService/CheckRelations"
+	UserService_GetHiddenAuthors_FullMethodName      = "/user.UserService/GetHiddenAuthors"
+	UserService_DeleteUser_FullMethodName            = "/user.UserService/DeleteUser"
+	UserService_ExportUserData_FullMethodName        = "/user.UserService/ExportUserData"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -36,17 +56,71 @@ const (
 type UserServiceClient interface {
 	// GetUser → GET /api/v1/users/{id}
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// GetUserByHandle → GET /api/v1/u/{handle}
+	// Без учёта регистра, ведущий @ допускается
+	GetUserByHandle(ctx context.Context, in *GetUserByHandleRequest, opts ...grpc.CallOption) (*User, error)
 	// UpdateUser → PATCH /api/v1/users/{id}
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
-	// ListUsers → GET /api/v1/users
-	ListUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Users, error)
+	// ListUsers → GET /api/v1/users?page_size=&page_token=
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*Users, error)
 	// FollowUser → POST /api/v1/users/{id}/follow
 	FollowUser(ctx context.Context, in *FollowUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
 	// UnfollowUser → DELETE /api/v1/users/{id}/follow
 	UnfollowUser(ctx context.Context, in *UnfollowUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
 	UpdateAvatar(ctx context.Context, in *UpdateAvatarRequest, opts ...grpc.CallOption) (*UpdateAvatarResponse, error)
+	// UpdateCover → POST /api/v1/users/{id}/cover
+	UpdateCover(ctx context.Context, in *UpdateCoverRequest, opts ...grpc.CallOption) (*UpdateCoverResponse, error)
+	// GetFollowers → GET /api/v1/users/{id}/followers?page_size=&page_token=
+	// Списки подписчиков и подписок закрытого аккаунта видны только ему и его подписчикам
 	GetFollowers(ctx context.Context, in *GetFollowersRequest, opts ...grpc.CallOption) (*Users, error)
+	// GetFollowing → GET /api/v1/users/{id}/following?page_size=&page_token=
 	GetFollowing(ctx context.Context, in *GetFollowingRequest, opts ...grpc.CallOption) (*Users, error)
+	// GetFollowRelationship → GET /api/v1/users/{user_id}/relationship/{other_id}
+	// Заявки видны только самим участникам
+	GetFollowRelationship(ctx context.Context, in *GetFollowRelationshipRequest, opts ...grpc.CallOption) (*FollowRelationship, error)
+	// SetAccountPrivacy → PUT /api/v1/users/me/privacy
+	// Закрытый аккаунт: подписка только через одобренную заявку.
+	// При открытии аккаунта ожидающие заявки одобряются автоматически.
+	SetAccountPrivacy(ctx context.Context, in *SetAccountPrivacyRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// ListFollowRequests → GET /api/v1/users/me/follow-requests?page_size=&page_token=
+	ListFollowRequests(ctx context.Context, in *ListFollowRequestsRequest, opts ...grpc.CallOption) (*FollowRequests, error)
+	// ApproveFollowRequest → POST /api/v1/users/me/follow-requests/{user_id}/approve
+	ApproveFollowRequest(ctx context.Context, in *ApproveFollowRequestRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// RejectFollowRequest → DELETE /api/v1/users/me/follow-requests/{user_id}
+	RejectFollowRequest(ctx context.Context, in *RejectFollowRequestRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// BlockUser → POST /api/v1/users/{id}/block
+	// Снимает подписки и заявки в обе стороны. Заблокированный не может подписаться,
+	// комментировать и лайкать посты, писать в личные сообщения, не попадает
+	// в поиск и ленту, уведомления от него не приходят.
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// UnblockUser → DELETE /api/v1/users/{id}/block
+	UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// MuteUser → POST /api/v1/users/{id}/mute
+	// Только скрывает посты из ленты и уведомления, подписка остаётся
+	MuteUser(ctx context.Context, in *MuteUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// UnmuteUser → DELETE /api/v1/users/{id}/mute
+	UnmuteUser(ctx context.Context, in *UnmuteUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// ListBlocked → GET /api/v1/users/me/blocked?page_size=&page_token=
+	ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*BlockedUsers, error)
+	// SuggestFollows → GET /api/v1/users/me/suggestions?page_size=
+	// Рекомендации пересчитываются периодически по графу подписок
+	SuggestFollows(ctx context.Context, in *SuggestFollowsRequest, opts ...grpc.CallOption) (*Suggestions, error)
+	// DismissSuggestion → DELETE /api/v1/users/me/suggestions/{user_id}
+	DismissSuggestion(ctx context.Context, in *DismissSuggestionRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// Внутренний вызов auth: handle = username, при регистрации и смене имени
+	SetHandle(ctx context.Context, in *SetHandleRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// Внутренний вызов: краткие профили для ленты, комментариев и чатов одним запросом.
+	// Читается через кэш в Redis, UpdateUser и UpdateAvatar его сбрасывают.
+	BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error)
+	// Внутренний вызов: блокировки и заглушки между user_id и other_ids одним запросом
+	CheckRelations(ctx context.Context, in *CheckRelationsRequest, opts ...grpc.CallOption) (*CheckRelationsResponse, error)
+	// Внутренний вызов: какие из авторов закрыты для viewer (закрытый аккаунт без одобренной подписки)
+	GetHiddenAuthors(ctx context.Context, in *GetHiddenAuthorsRequest, opts ...grpc.CallOption) (*GetHiddenAuthorsResponse, error)
+	// Внутренний вызов саги удаления аккаунта: профиль, подписки и аватар.
+	// HTTP маршрута нет, повторный вызов безопасен.
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error)
+	// Внутренний вызов выгрузки персональных данных: профиль и подписки
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
 }
 
 type userServiceClient struct {
@@ -67,6 +141,16 @@ func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opt
 	return out, nil
 }
 
+func (c *userServiceClient) GetUserByHandle(ctx context.Context, in *GetUserByHandleRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUserByHandle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(gen.Confirmation)
@@ -77,7 +161,7 @@ func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserReques
 	return out, nil
 }
 
-func (c *userServiceClient) ListUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Users, error) {
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*Users, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Users)
 	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, cOpts...)
@@ -117,6 +201,16 @@ func (c *userServiceClient) UpdateAvatar(ctx context.Context, in *UpdateAvatarRe
 	return out, nil
 }
 
+func (c *userServiceClient) UpdateCover(ctx context.Context, in *UpdateCoverRequest, opts ...grpc.CallOption) (*UpdateCoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCoverResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateCover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetFollowers(ctx context.Context, in *GetFollowersRequest, opts ...grpc.CallOption) (*Users, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Users)
@@ -137,23 +231,257 @@ func (c *userServiceClient) GetFollowing(ctx context.Context, in *GetFollowingRe
 	return out, nil
 }
 
+func (c *userServiceClient) GetFollowRelationship(ctx context.Context, in *GetFollowRelationshipRequest, opts ...grpc.CallOption) (*FollowRelationship, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowRelationship)
+	err := c.cc.Invoke(ctx, UserService_GetFollowRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetAccountPrivacy(ctx context.Context, in *SetAccountPrivacyRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_SetAccountPrivacy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListFollowRequests(ctx context.Context, in *ListFollowRequestsRequest, opts ...grpc.CallOption) (*FollowRequests, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowRequests)
+	err := c.cc.Invoke(ctx, UserService_ListFollowRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ApproveFollowRequest(ctx context.Context, in *ApproveFollowRequestRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_ApproveFollowRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RejectFollowRequest(ctx context.Context, in *RejectFollowRequestRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_RejectFollowRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) MuteUser(ctx context.Context, in *MuteUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_MuteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnmuteUser(ctx context.Context, in *UnmuteUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_UnmuteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*BlockedUsers, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockedUsers)
+	err := c.cc.Invoke(ctx, UserService_ListBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SuggestFollows(ctx context.Context, in *SuggestFollowsRequest, opts ...grpc.CallOption) (*Suggestions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Suggestions)
+	err := c.cc.Invoke(ctx, UserService_SuggestFollows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DismissSuggestion(ctx context.Context, in *DismissSuggestionRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_DismissSuggestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetHandle(ctx context.Context, in *SetHandleRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_SetHandle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchGetUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CheckRelations(ctx context.Context, in *CheckRelationsRequest, opts ...grpc.CallOption) (*CheckRelationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckRelationsResponse)
+	err := c.cc.Invoke(ctx, UserService_CheckRelations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetHiddenAuthors(ctx context.Context, in *GetHiddenAuthorsRequest, opts ...grpc.CallOption) (*GetHiddenAuthorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHiddenAuthorsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetHiddenAuthors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*gen.Confirmation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gen.Confirmation)
+	err := c.cc.Invoke(ctx, UserService_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, UserService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
 type UserServiceServer interface {
 	// GetUser → GET /api/v1/users/{id}
 	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// GetUserByHandle → GET /api/v1/u/{handle}
+	// Без учёта регистра, ведущий @ допускается
+	GetUserByHandle(context.Context, *GetUserByHandleRequest) (*User, error)
 	// UpdateUser → PATCH /api/v1/users/{id}
 	UpdateUser(context.Context, *UpdateUserRequest) (*gen.Confirmation, error)
-	// ListUsers → GET /api/v1/users
-	ListUsers(context.Context, *EmptyRequest) (*Users, error)
+	// ListUsers → GET /api/v1/users?page_size=&page_token=
+	ListUsers(context.Context, *ListUsersRequest) (*Users, error)
 	// FollowUser → POST /api/v1/users/{id}/follow
 	FollowUser(context.Context, *FollowUserRequest) (*gen.Confirmation, error)
 	// UnfollowUser → DELETE /api/v1/users/{id}/follow
 	UnfollowUser(context.Context, *UnfollowUserRequest) (*gen.Confirmation, error)
 	UpdateAvatar(context.Context, *UpdateAvatarRequest) (*UpdateAvatarResponse, error)
+	// UpdateCover → POST /api/v1/users/{id}/cover
+	UpdateCover(context.Context, *UpdateCoverRequest) (*UpdateCoverResponse, error)
+	// GetFollowers → GET /api/v1/users/{id}/followers?page_size=&page_token=
+	// Списки подписчиков и подписок закрытого аккаунта видны только ему и его подписчикам
 	GetFollowers(context.Context, *GetFollowersRequest) (*Users, error)
+	// GetFollowing → GET /api/v1/users/{id}/following?page_size=&page_token=
 	GetFollowing(context.Context, *GetFollowingRequest) (*Users, error)
+	// GetFollowRelationship → GET /api/v1/users/{user_id}/relationship/{other_id}
+	// Заявки видны только самим участникам
+	GetFollowRelationship(context.Context, *GetFollowRelationshipRequest) (*FollowRelationship, error)
+	// SetAccountPrivacy → PUT /api/v1/users/me/privacy
+	// Закрытый аккаунт: подписка только через одобренную заявку.
+	// При открытии аккаунта ожидающие заявки одобряются автоматически.
+	SetAccountPrivacy(context.Context, *SetAccountPrivacyRequest) (*gen.Confirmation, error)
+	// ListFollowRequests → GET /api/v1/users/me/follow-requests?page_size=&page_token=
+	ListFollowRequests(context.Context, *ListFollowRequestsRequest) (*FollowRequests, error)
+	// ApproveFollowRequest → POST /api/v1/users/me/follow-requests/{user_id}/approve
+	ApproveFollowRequest(context.Context, *ApproveFollowRequestRequest) (*gen.Confirmation, error)
+	// RejectFollowRequest → DELETE /api/v1/users/me/follow-requests/{user_id}
+	RejectFollowRequest(context.Context, *RejectFollowRequestRequest) (*gen.Confirmation, error)
+	// BlockUser → POST /api/v1/users/{id}/block
+	// Снимает подписки и заявки в обе стороны. Заблокированный не может подписаться,
+	// комментировать и лайкать посты, писать в личные сообщения, не попадает
+	// в поиск и ленту, уведомления от него не приходят.
+	BlockUser(context.Context, *BlockUserRequest) (*gen.Confirmation, error)
+	// UnblockUser → DELETE /api/v1/users/{id}/block
+	UnblockUser(context.Context, *UnblockUserRequest) (*gen.Confirmation, error)
+	// MuteUser → POST /api/v1/users/{id}/mute
+	// Только скрывает посты из ленты и уведомления, подписка остаётся
+	MuteUser(context.Context, *MuteUserRequest) (*gen.Confirmation, error)
+	// UnmuteUser → DELETE /api/v1/users/{id}/mute
+	UnmuteUser(context.Context, *UnmuteUserRequest) (*gen.Confirmation, error)
+	// ListBlocked → GET /api/v1/users/me/blocked?page_size=&page_token=
+	ListBlocked(context.Context, *ListBlockedRequest) (*BlockedUsers, error)
+	// SuggestFollows → GET /api/v1/users/me/suggestions?page_size=
+	// Рекомендации пересчитываются периодически по графу подписок
+	SuggestFollows(context.Context, *SuggestFollowsRequest) (*Suggestions, error)
+	// DismissSuggestion → DELETE /api/v1/users/me/suggestions/{user_id}
+	DismissSuggestion(context.Context, *DismissSuggestionRequest) (*gen.Confirmation, error)
+	// Внутренний вызов auth: handle = username, при регистрации и смене имени
+	SetHandle(context.Context, *SetHandleRequest) (*gen.Confirmation, error)
+	// Внутренний вызов: краткие профили для ленты, комментариев и чатов одним запросом.
+	// Читается через кэш в Redis, UpdateUser и UpdateAvatar его сбрасывают.
+	BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error)
+	// Внутренний вызов: блокировки и заглушки между user_id и other_ids одним запросом
+	CheckRelations(context.Context, *CheckRelationsRequest) (*CheckRelationsResponse, error)
+	// Внутренний вызов: какие из авторов закрыты для viewer (закрытый аккаунт без одобренной подписки)
+	GetHiddenAuthors(context.Context, *GetHiddenAuthorsRequest) (*GetHiddenAuthorsResponse, error)
+	// Внутренний вызов саги удаления аккаунта: профиль, подписки и аватар.
+	// HTTP маршрута нет, повторный вызов безопасен.
+	DeleteUser(context.Context, *DeleteUserRequest) (*gen.Confirmation, error)
+	// Внутренний вызов выгрузки персональных данных: профиль и подписки
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -167,10 +495,13 @@ type UnimplementedUserServiceServer struct{}
 func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
 }
+func (UnimplementedUserServiceServer) GetUserByHandle(context.Context, *GetUserByHandleRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByHandle not implemented")
+}
 func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*gen.Confirmation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
 }
-func (UnimplementedUserServiceServer) ListUsers(context.Context, *EmptyRequest) (*Users, error) {
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*Users, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
 }
 func (UnimplementedUserServiceServer) FollowUser(context.Context, *FollowUserRequest) (*gen.Confirmation, error) {
@@ -182,12 +513,69 @@ func (UnimplementedUserServiceServer) UnfollowUser(context.Context, *UnfollowUse
 func (UnimplementedUserServiceServer) UpdateAvatar(context.Context, *UpdateAvatarRequest) (*UpdateAvatarResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAvatar not implemented")
 }
+func (UnimplementedUserServiceServer) UpdateCover(context.Context, *UpdateCoverRequest) (*UpdateCoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCover not implemented")
+}
 func (UnimplementedUserServiceServer) GetFollowers(context.Context, *GetFollowersRequest) (*Users, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFollowers not implemented")
 }
 func (UnimplementedUserServiceServer) GetFollowing(context.Context, *GetFollowingRequest) (*Users, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFollowing not implemented")
 }
+func (UnimplementedUserServiceServer) GetFollowRelationship(context.Context, *GetFollowRelationshipRequest) (*FollowRelationship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowRelationship not implemented")
+}
+func (UnimplementedUserServiceServer) SetAccountPrivacy(context.Context, *SetAccountPrivacyRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountPrivacy not implemented")
+}
+func (UnimplementedUserServiceServer) ListFollowRequests(context.Context, *ListFollowRequestsRequest) (*FollowRequests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowRequests not implemented")
+}
+func (UnimplementedUserServiceServer) ApproveFollowRequest(context.Context, *ApproveFollowRequestRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveFollowRequest not implemented")
+}
+func (UnimplementedUserServiceServer) RejectFollowRequest(context.Context, *RejectFollowRequestRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectFollowRequest not implemented")
+}
+func (UnimplementedUserServiceServer) BlockUser(context.Context, *BlockUserRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedUserServiceServer) UnblockUser(context.Context, *UnblockUserRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedUserServiceServer) MuteUser(context.Context, *MuteUserRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteUser not implemented")
+}
+func (UnimplementedUserServiceServer) UnmuteUser(context.Context, *UnmuteUserRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmuteUser not implemented")
+}
+func (UnimplementedUserServiceServer) ListBlocked(context.Context, *ListBlockedRequest) (*BlockedUsers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocked not implemented")
+}
+func (UnimplementedUserServiceServer) SuggestFollows(context.Context, *SuggestFollowsRequest) (*Suggestions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestFollows not implemented")
+}
+func (UnimplementedUserServiceServer) DismissSuggestion(context.Context, *DismissSuggestionRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DismissSuggestion not implemented")
+}
+func (UnimplementedUserServiceServer) SetHandle(context.Context, *SetHandleRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHandle not implemented")
+}
+func (UnimplementedUserServiceServer) BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetUsers not implemented")
+}
+func (UnimplementedUserServiceServer) CheckRelations(context.Context, *CheckRelationsRequest) (*CheckRelationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckRelations not implemented")
+}
+func (UnimplementedUserServiceServer) GetHiddenAuthors(context.Context, *GetHiddenAuthorsRequest) (*GetHiddenAuthorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHiddenAuthors not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*gen.Confirmation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedUserServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -227,6 +615,24 @@ func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetUserByHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByHandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserByHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserByHandle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserByHandle(ctx, req.(*GetUserByHandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateUserRequest)
 	if err := dec(in); err != nil {
@@ -246,7 +652,7 @@ func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec f
 }
 
 func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+	in := new(ListUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -258,7 +664,7 @@ func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: UserService_ListUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ListUsers(ctx, req.(*EmptyRequest))
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -317,6 +723,24 @@ func _UserService_UpdateAvatar_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_UpdateCover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateCover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateCover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateCover(ctx, req.(*UpdateCoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetFollowersRequest)
 	if err := dec(in); err != nil {
@@ -353,6 +777,330 @@ func _UserService_GetFollowing_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetFollowRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetFollowRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetFollowRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetFollowRelationship(ctx, req.(*GetFollowRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetAccountPrivacy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountPrivacyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetAccountPrivacy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetAccountPrivacy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetAccountPrivacy(ctx, req.(*SetAccountPrivacyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListFollowRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListFollowRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListFollowRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListFollowRequests(ctx, req.(*ListFollowRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ApproveFollowRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveFollowRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ApproveFollowRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ApproveFollowRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ApproveFollowRequest(ctx, req.(*ApproveFollowRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RejectFollowRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectFollowRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RejectFollowRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RejectFollowRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RejectFollowRequest(ctx, req.(*RejectFollowRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnblockUser(ctx, req.(*UnblockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_MuteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).MuteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_MuteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).MuteUser(ctx, req.(*MuteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnmuteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmuteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnmuteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnmuteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnmuteUser(ctx, req.(*UnmuteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListBlocked(ctx, req.(*ListBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SuggestFollows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SuggestFollows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SuggestFollows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SuggestFollows(ctx, req.(*SuggestFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DismissSuggestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DismissSuggestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DismissSuggestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DismissSuggestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DismissSuggestion(ctx, req.(*DismissSuggestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetHandle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetHandle(ctx, req.(*SetHandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchGetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchGetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchGetUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchGetUsers(ctx, req.(*BatchGetUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CheckRelations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckRelations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CheckRelations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckRelations(ctx, req.(*CheckRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetHiddenAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHiddenAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetHiddenAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetHiddenAuthors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetHiddenAuthors(ctx, req.(*GetHiddenAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -364,6 +1112,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUser",
 			Handler:    _UserService_GetUser_Handler,
 		},
+		{
+			MethodName: "GetUserByHandle",
+			Handler:    _UserService_GetUserByHandle_Handler,
+		},
 		{
 			MethodName: "UpdateUser",
 			Handler:    _UserService_UpdateUser_Handler,
@@ -384,6 +1136,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAvatar",
 			Handler:    _UserService_UpdateAvatar_Handler,
 		},
+		{
+			MethodName: "UpdateCover",
+			Handler:    _UserService_UpdateCover_Handler,
+		},
 		{
 			MethodName: "GetFollowers",
 			Handler:    _UserService_GetFollowers_Handler,
@@ -392,6 +1148,78 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFollowing",
 			Handler:    _UserService_GetFollowing_Handler,
 		},
+		{
+			MethodName: "GetFollowRelationship",
+			Handler:    _UserService_GetFollowRelationship_Handler,
+		},
+		{
+			MethodName: "SetAccountPrivacy",
+			Handler:    _UserService_SetAccountPrivacy_Handler,
+		},
+		{
+			MethodName: "ListFollowRequests",
+			Handler:    _UserService_ListFollowRequests_Handler,
+		},
+		{
+			MethodName: "ApproveFollowRequest",
+			Handler:    _UserService_ApproveFollowRequest_Handler,
+		},
+		{
+			MethodName: "RejectFollowRequest",
+			Handler:    _UserService_RejectFollowRequest_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _UserService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _UserService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "MuteUser",
+			Handler:    _UserService_MuteUser_Handler,
+		},
+		{
+			MethodName: "UnmuteUser",
+			Handler:    _UserService_UnmuteUser_Handler,
+		},
+		{
+			MethodName: "ListBlocked",
+			Handler:    _UserService_ListBlocked_Handler,
+		},
+		{
+			MethodName: "SuggestFollows",
+			Handler:    _UserService_SuggestFollows_Handler,
+		},
+		{
+			MethodName: "DismissSuggestion",
+			Handler:    _UserService_DismissSuggestion_Handler,
+		},
+		{
+			MethodName: "SetHandle",
+			Handler:    _UserService_SetHandle_Handler,
+		},
+		{
+			MethodName: "BatchGetUsers",
+			Handler:    _UserService_BatchGetUsers_Handler,
+		},
+		{
+			MethodName: "CheckRelations",
+			Handler:    _UserService_CheckRelations_Handler,
+		},
+		{
+			MethodName: "GetHiddenAuthors",
+			Handler:    _UserService_GetHiddenAuthors_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _UserService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _UserService_ExportUserData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
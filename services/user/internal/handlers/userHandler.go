@@ -22,16 +22,22 @@ func NewUserHandler(s *service.UserService) *UserHandler {
 
 func (h *UserHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
 
-	user, err := h.serv.GetUser(req)
-	if err != nil {
+	return h.serv.GetUser(contextx.GetUserID(ctx), req)
+}
+
+func (h *UserHandler) GetUserByHandle(ctx context.Context, req *pb.GetUserByHandleRequest) (*pb.User, error) {
+	return h.serv.GetUserByHandle(contextx.GetUserID(ctx), req)
+}
+
+func (h *UserHandler) SetHandle(ctx context.Context, req *pb.SetHandleRequest) (*pb1.Confirmation, error) {
+	if err := h.serv.SetHandle(req); err != nil {
 		return nil, err
 	}
-	return &pb.User{FirstName: user.Firstname, LastName: user.Lastname,
-		BirthDate: user.BirthDate, Bio: user.Bio, AvatarUrl: user.AvatarUrl}, nil
+	return &pb1.Confirmation{Status: codes.OK.String()}, nil
 }
 
 func (h *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb1.Confirmation, error) {
-	err := h.serv.UpdateUser(req)
+	err := h.serv.UpdateUser(contextx.GetUserID(ctx), req)
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +52,12 @@ func (h *UserHandler) UpdateAvatar(ctx context.Context, req *pb.UpdateAvatarRequ
 	return avatar, nil
 }
 
-func (h *UserHandler) ListUsers(ctx context.Context, req *pb.EmptyRequest) (*pb.Users, error) {
-	users, err := h.serv.GetAllUsers()
+func (h *UserHandler) UpdateCover(ctx context.Context, req *pb.UpdateCoverRequest) (*pb.UpdateCoverResponse, error) {
+	return h.serv.UpdateCover(contextx.GetUserID(ctx), req)
+}
+
+func (h *UserHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.Users, error) {
+	users, err := h.serv.ListUsers(contextx.GetUserID(ctx), req)
 	if err != nil {
 		return nil, err
 	}
@@ -61,10 +71,13 @@ func (h *UserHandler) FollowUser(ctx context.Context, req *pb.FollowUserRequest)
 		return nil, status.Error(codes.Unauthenticated, "missing user id")
 	}
 	fmt.Println("GetProfile userId:", userId)
-	err := h.serv.FollowUser(ctx, userId, req.Id)
+	requested, err := h.serv.FollowUser(ctx, userId, req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if requested {
+		return &pb1.Confirmation{Status: "Follow request sent"}, nil
+	}
 	return &pb1.Confirmation{Status: "Followed Successfully"}, nil
 }
 
@@ -80,18 +93,155 @@ func (h *UserHandler) UnfollowUser(ctx context.Context, req *pb.UnfollowUserRequ
 	return &pb1.Confirmation{Status: "Successfully"}, nil
 }
 
+func (h *UserHandler) GetFollowers(ctx context.Context, req *pb.GetFollowersRequest) (*pb.Users, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	return h.serv.GetFollowers(contextx.GetUserID(ctx), req)
+}
+
 func (h *UserHandler) GetFollowing(ctx context.Context, req *pb.GetFollowingRequest) (*pb.Users, error) {
-	// Извлекаем userId из параметра запроса
-	userID := req.Id
-	if userID == "" {
+	if req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "user id is required")
 	}
+	return h.serv.GetFollowing(contextx.GetUserID(ctx), req)
+}
 
-	// Получаем список following из сервиса
-	users, err := h.serv.GetFollowing(userID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get following: %v", err)
+func (h *UserHandler) SuggestFollows(ctx context.Context, req *pb.SuggestFollowsRequest) (*pb.Suggestions, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	return h.serv.SuggestFollows(ctx, userId, req)
+}
+
+func (h *UserHandler) DismissSuggestion(ctx context.Context, req *pb.DismissSuggestionRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	if err := h.serv.DismissSuggestion(userId, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "Suggestion dismissed"}, nil
+}
+
+func (h *UserHandler) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	return h.serv.BatchGetUsers(ctx, req)
+}
+
+func (h *UserHandler) GetFollowRelationship(ctx context.Context, req *pb.GetFollowRelationshipRequest) (*pb.FollowRelationship, error) {
+	return h.serv.GetFollowRelationship(contextx.GetUserID(ctx), req)
+}
+
+func (h *UserHandler) SetAccountPrivacy(ctx context.Context, req *pb.SetAccountPrivacyRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	if err := h.serv.SetAccountPrivacy(ctx, userId, req.IsPrivate); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: codes.OK.String()}, nil
+}
+
+func (h *UserHandler) ListFollowRequests(ctx context.Context, req *pb.ListFollowRequestsRequest) (*pb.FollowRequests, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	return h.serv.ListFollowRequests(userId, req)
+}
+
+func (h *UserHandler) ApproveFollowRequest(ctx context.Context, req *pb.ApproveFollowRequestRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	if err := h.serv.ApproveFollowRequest(ctx, userId, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "Follow request approved"}, nil
+}
+
+func (h *UserHandler) RejectFollowRequest(ctx context.Context, req *pb.RejectFollowRequestRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	if err := h.serv.RejectFollowRequest(userId, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "Follow request rejected"}, nil
+}
+
+func (h *UserHandler) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	if err := h.serv.BlockUser(userId, req.Id); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "User blocked"}, nil
+}
+
+func (h *UserHandler) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	if err := h.serv.UnblockUser(userId, req.Id); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "User unblocked"}, nil
+}
+
+func (h *UserHandler) MuteUser(ctx context.Context, req *pb.MuteUserRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	if err := h.serv.MuteUser(userId, req.Id); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "User muted"}, nil
+}
+
+func (h *UserHandler) UnmuteUser(ctx context.Context, req *pb.UnmuteUserRequest) (*pb1.Confirmation, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
 	}
+	if err := h.serv.UnmuteUser(userId, req.Id); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "User unmuted"}, nil
+}
+
+func (h *UserHandler) ListBlocked(ctx context.Context, req *pb.ListBlockedRequest) (*pb.BlockedUsers, error) {
+	userId := contextx.GetUserID(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+	return h.serv.ListBlocked(userId, req)
+}
+
+func (h *UserHandler) CheckRelations(ctx context.Context, req *pb.CheckRelationsRequest) (*pb.CheckRelationsResponse, error) {
+	return h.serv.CheckRelations(req)
+}
+
+func (h *UserHandler) GetHiddenAuthors(ctx context.Context, req *pb.GetHiddenAuthorsRequest) (*pb.GetHiddenAuthorsResponse, error) {
+	return h.serv.GetHiddenAuthors(req)
+}
+
+func (h *UserHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb1.Confirmation, error) {
+	if err := h.serv.DeleteUser(req); err != nil {
+		return nil, err
+	}
+	return &pb1.Confirmation{Status: "deleted"}, nil
+}
 
-	return &pb.Users{Users: users}, nil
+func (h *UserHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	return h.serv.ExportUserData(req)
 }
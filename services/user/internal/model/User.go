@@ -3,11 +3,22 @@ package model
 import "time"
 
 type User struct {
-	Id        uint   `gorm:"primaryKey"`
+	Id        uint   `gorm:"primaryKey;index:idx_users_page,priority:2"`
 	Firstname string `gorm:"size:50;not null"`
 	Lastname  string `gorm:"size:50;not null"`
-	BirthDate string `gorm:"size:50"`
-	Bio       string `gorm:"size:255;"`
-	AvatarUrl string `gorm:"size:255"`
-	CreatedAt time.Time
+	// LegacyBirthDate — прежняя дата рождения свободным текстом; при старте
+	// переносится в Birthday (BackfillBirthdays), нераспознанная остаётся здесь
+	LegacyBirthDate string     `gorm:"column:birth_date;size:50"`
+	Birthday        *time.Time `gorm:"type:date"`
+	Bio             string     `gorm:"size:255;"`
+	AvatarUrl       string     `gorm:"size:255"`
+	CoverUrl        string     `gorm:"size:255"`
+	Location        string     `gorm:"size:100"`
+	Pronouns        string     `gorm:"size:40"`
+	IsPrivate       bool       `gorm:"not null;default:false"`
+	// Handle — username из auth; уникальность без учёта регистра держит HandleLower
+	// (NULL у профилей, куда имя ещё не пришло)
+	Handle      string    `gorm:"size:32"`
+	HandleLower *string   `gorm:"size:32;uniqueIndex"`
+	CreatedAt   time.Time `gorm:"index:idx_users_page,priority:1"`
 }
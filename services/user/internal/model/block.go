@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Block — BlockerID заблокировал BlockedID; действует в обе стороны
+type Block struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	BlockerID uint      `gorm:"not null;uniqueIndex:idx_blocks_pair"`
+	BlockedID uint      `gorm:"not null;uniqueIndex:idx_blocks_pair;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// Mute — MuterID не видит постов и уведомлений MutedID, подписка сохраняется
+type Mute struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	MuterID   uint      `gorm:"not null;uniqueIndex:idx_mutes_pair"`
+	MutedID   uint      `gorm:"not null;uniqueIndex:idx_mutes_pair;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -4,7 +4,27 @@ import "time"
 
 type Follow struct {
 	ID          uint      `gorm:"primaryKey;autoIncrement"`
-	FollowerID  uint      `gorm:"not null,index:idx_follows_unique,unique"`
-	FollowingID uint      `gorm:"not null,index:idx_follows_unique,unique"`
+	FollowerID  uint      `gorm:"not null;uniqueIndex:idx_follows_unique"`
+	FollowingID uint      `gorm:"not null;uniqueIndex:idx_follows_unique;index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+// FollowStats — денормализованные счётчики подписок. Меняются в одной транзакции
+// со строками follows, поэтому профиль не пересчитывает таблицу подписок.
+type FollowStats struct {
+	UserID    uint  `gorm:"primaryKey;autoIncrement:false"`
+	Followers int64 `gorm:"not null;default:0"`
+	Following int64 `gorm:"not null;default:0"`
+}
+
+func (FollowStats) TableName() string {
+	return "follow_stats"
+}
+
+// FollowRequest — заявка на подписку к закрытому аккаунту; после одобрения становится Follow
+type FollowRequest struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	RequesterID uint      `gorm:"not null;uniqueIndex:idx_follow_requests_pair"`
+	TargetID    uint      `gorm:"not null;uniqueIndex:idx_follow_requests_pair;index"`
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
 }
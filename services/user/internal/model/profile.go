@@ -0,0 +1,45 @@
+package model
+
+// ProfileLink — ссылка в профиле (сайт, соцсети); Position задаёт порядок
+type ProfileLink struct {
+	ID       uint   `gorm:"primaryKey;autoIncrement"`
+	UserID   uint   `gorm:"not null;index"`
+	Position int    `gorm:"not null"`
+	URL      string `gorm:"size:255;not null"`
+	Label    string `gorm:"size:50"`
+}
+
+const (
+	EntryWork      = "work"
+	EntryEducation = "education"
+)
+
+// ProfileEntry — место работы или учёбы; EndYear 0 — по настоящее время
+type ProfileEntry struct {
+	ID           uint   `gorm:"primaryKey;autoIncrement"`
+	UserID       uint   `gorm:"not null;index:idx_profile_entries_user,priority:1"`
+	Kind         string `gorm:"size:16;not null;index:idx_profile_entries_user,priority:2"`
+	Position     int    `gorm:"not null"`
+	Organization string `gorm:"size:100;not null"`
+	Title        string `gorm:"size:100"`
+	StartYear    int
+	EndYear      int
+}
+
+// Уровни видимости — те же значения, что у enum Visibility в proto
+const (
+	VisibilityPublic    = 1
+	VisibilityFollowers = 2
+	VisibilityOnlyMe    = 3
+)
+
+// FieldVisibility — видимость поля профиля; нет строки — поле публичное
+type FieldVisibility struct {
+	UserID uint   `gorm:"primaryKey;autoIncrement:false"`
+	Field  string `gorm:"primaryKey;size:32"`
+	Level  int    `gorm:"not null"`
+}
+
+func (FieldVisibility) TableName() string {
+	return "profile_field_visibility"
+}
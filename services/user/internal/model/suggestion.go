@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Suggestion — рекомендация подписки, пересчитывается периодической задачей.
+// UserID == 0 — общий список популярных для тех, у кого своего ещё нет.
+type Suggestion struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_suggestions_pair;index:idx_suggestions_rank,priority:1"`
+	CandidateID uint      `gorm:"not null;uniqueIndex:idx_suggestions_pair"`
+	Score       float64   `gorm:"not null;index:idx_suggestions_rank,priority:2,sort:desc"`
+	MutualCount int       `gorm:"not null;default:0"`
+	FollowsYou  bool      `gorm:"not null;default:false"`
+	ComputedAt  time.Time `gorm:"not null"`
+}
+
+// DismissedSuggestion — пользователь скрыл рекомендацию; больше её не показываем,
+// а частые скрытия понижают кандидата у остальных
+type DismissedSuggestion struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_dismissed_pair"`
+	CandidateID uint      `gorm:"not null;uniqueIndex:idx_dismissed_pair;index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
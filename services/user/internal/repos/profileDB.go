@@ -0,0 +1,131 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"socialnet/services/user/internal/model"
+	"time"
+)
+
+// ProfilePatch — изменения профиля по field mask; не попавшее в маску не трогается
+type ProfilePatch struct {
+	Columns      map[string]interface{}
+	ReplaceLinks bool
+	Links        []model.ProfileLink
+	// Entries — по Kind; если ключ есть, список этого вида заменяется целиком
+	Entries map[string][]model.ProfileEntry
+	// Visibility — уровень по полю; 0 удаляет настройку (поле снова публичное)
+	Visibility map[string]int
+}
+
+// UpdateProfile — всё одной транзакцией. Профиль мог ещё не заполняться,
+// тогда сначала создаём пустую строку, как SetPrivacy.
+func (r *UserRepo) UpdateProfile(userID uint, patch ProfilePatch) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.User{Id: userID}).Error; err != nil {
+			return err
+		}
+		if len(patch.Columns) > 0 {
+			if err := tx.Model(&model.User{}).Where("id = ?", userID).
+				Updates(patch.Columns).Error; err != nil {
+				return err
+			}
+		}
+		if patch.ReplaceLinks {
+			if err := tx.Where("user_id = ?", userID).Delete(&model.ProfileLink{}).Error; err != nil {
+				return err
+			}
+			for i := range patch.Links {
+				link := patch.Links[i]
+				link.UserID, link.Position = userID, i
+				if err := tx.Create(&link).Error; err != nil {
+					return err
+				}
+			}
+		}
+		for kind, entries := range patch.Entries {
+			if err := tx.Where("user_id = ? AND kind = ?", userID, kind).
+				Delete(&model.ProfileEntry{}).Error; err != nil {
+				return err
+			}
+			for i := range entries {
+				entry := entries[i]
+				entry.UserID, entry.Kind, entry.Position = userID, kind, i
+				if err := tx.Create(&entry).Error; err != nil {
+					return err
+				}
+			}
+		}
+		for field, level := range patch.Visibility {
+			if level == 0 {
+				if err := tx.Where("user_id = ? AND field = ?", userID, field).
+					Delete(&model.FieldVisibility{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "field"}},
+				DoUpdates: clause.AssignmentColumns([]string{"level"}),
+			}).Create(&model.FieldVisibility{UserID: userID, Field: field, Level: level}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ProfileDetails — ссылки, работа/учёба и видимость полей для набора профилей
+func (r *UserRepo) ProfileDetails(ids []uint) (map[uint][]model.ProfileLink, map[uint][]model.ProfileEntry, map[uint]map[string]int, error) {
+	var links []model.ProfileLink
+	if err := r.db.Where("user_id IN ?", ids).Order("user_id, position").Find(&links).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	var entries []model.ProfileEntry
+	if err := r.db.Where("user_id IN ?", ids).Order("user_id, kind, position").Find(&entries).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	var settings []model.FieldVisibility
+	if err := r.db.Where("user_id IN ?", ids).Find(&settings).Error; err != nil {
+		return nil, nil, nil, err
+	}
+
+	linksBy := map[uint][]model.ProfileLink{}
+	for _, l := range links {
+		linksBy[l.UserID] = append(linksBy[l.UserID], l)
+	}
+	entriesBy := map[uint][]model.ProfileEntry{}
+	for _, e := range entries {
+		entriesBy[e.UserID] = append(entriesBy[e.UserID], e)
+	}
+	visibility := map[uint]map[string]int{}
+	for _, v := range settings {
+		if visibility[v.UserID] == nil {
+			visibility[v.UserID] = map[string]int{}
+		}
+		visibility[v.UserID][v.Field] = v.Level
+	}
+	return linksBy, entriesBy, visibility, nil
+}
+
+// BackfillBirthdays — переносит текстовые даты рождения формата YYYY-MM-DD
+// в колонку birthday; остальное остаётся в birth_date как было
+func (r *UserRepo) BackfillBirthdays() error {
+	var users []model.User
+	if err := r.db.Select("id", "birth_date").
+		Where("birth_date <> '' AND birthday IS NULL").Find(&users).Error; err != nil {
+		return err
+	}
+	for _, u := range users {
+		day, err := time.Parse("2006-01-02", u.LegacyBirthDate)
+		if err != nil {
+			continue
+		}
+		if err := r.db.Model(&model.User{}).Where("id = ?", u.Id).
+			Updates(map[string]interface{}{"birthday": day, "birth_date": ""}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"socialnet/services/user/internal/model"
+)
+
+// AllFollows — граф подписок целиком для пересчёта рекомендаций
+func (r *UserRepo) AllFollows() ([]model.Follow, error) {
+	var follows []model.Follow
+	err := r.db.Select("follower_id", "following_id", "created_at").Find(&follows).Error
+	return follows, err
+}
+
+func (r *UserRepo) AllBlocks() ([]model.Block, error) {
+	var blocks []model.Block
+	err := r.db.Select("blocker_id", "blocked_id").Find(&blocks).Error
+	return blocks, err
+}
+
+func (r *UserRepo) AllDismissals() ([]model.DismissedSuggestion, error) {
+	var dismissed []model.DismissedSuggestion
+	err := r.db.Select("user_id", "candidate_id").Find(&dismissed).Error
+	return dismissed, err
+}
+
+func (r *UserRepo) AllUserIDs() ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.User{}).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ReplaceSuggestions — новый список пользователя заменяет старый целиком
+func (r *UserRepo) ReplaceSuggestions(userID uint, suggestions []model.Suggestion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.Suggestion{}).Error; err != nil {
+			return err
+		}
+		if len(suggestions) == 0 {
+			return nil
+		}
+		return tx.Create(&suggestions).Error
+	})
+}
+
+// ListSuggestions — готовый список listID для userID (свой или общий с listID == 0).
+// Список может отстать от графа, поэтому подписки, заявки, блокировки и скрытые
+// отфильтровываются при чтении.
+func (r *UserRepo) ListSuggestions(userID, listID uint, limit int) ([]model.Suggestion, error) {
+	var suggestions []model.Suggestion
+	err := r.db.Where("user_id = ? AND candidate_id <> ?", listID, userID).
+		Where("candidate_id NOT IN (?)", r.db.Model(&model.Follow{}).Select("following_id").Where("follower_id = ?", userID)).
+		Where("candidate_id NOT IN (?)", r.db.Model(&model.FollowRequest{}).Select("target_id").Where("requester_id = ?", userID)).
+		Where("candidate_id NOT IN (?)", r.db.Model(&model.Block{}).Select("blocked_id").Where("blocker_id = ?", userID)).
+		Where("candidate_id NOT IN (?)", r.db.Model(&model.Block{}).Select("blocker_id").Where("blocked_id = ?", userID)).
+		Where("candidate_id NOT IN (?)", r.db.Model(&model.DismissedSuggestion{}).Select("candidate_id").Where("user_id = ?", userID)).
+		Order("score DESC, candidate_id").Limit(limit).
+		Find(&suggestions).Error
+	return suggestions, err
+}
+
+// DismissSuggestion — повторное скрытие не ошибка
+func (r *UserRepo) DismissSuggestion(userID, candidateID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.DismissedSuggestion{UserID: userID, CandidateID: candidateID}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND candidate_id = ?", userID, candidateID).Delete(&model.Suggestion{}).Error
+	})
+}
@@ -0,0 +1,80 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const summaryTTL = 10 * time.Minute
+
+// CachedSummary — краткий профиль в кэше BatchGetUsers
+type CachedSummary struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	AvatarURL string `json:"avatar_url"`
+	Handle    string `json:"handle"`
+}
+
+// SummaryCache — read-through кэш кратких профилей. nil-кэш (Redis не настроен) ничего не хранит.
+type SummaryCache struct {
+	rdb *redis.Client
+}
+
+func NewSummaryCache(rdb *redis.Client) *SummaryCache {
+	return &SummaryCache{rdb: rdb}
+}
+
+func summaryKey(id uint) string {
+	return fmt.Sprintf("user:summary:%d", id)
+}
+
+// Get — найденные в кэше профили и id, за которыми надо идти в БД
+func (c *SummaryCache) Get(ctx context.Context, ids []uint) (map[uint]CachedSummary, []uint, error) {
+	found := map[uint]CachedSummary{}
+	if c == nil || len(ids) == 0 {
+		return found, ids, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = summaryKey(id)
+	}
+	values, err := c.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return found, ids, err
+	}
+	var missing []uint
+	for i, v := range values {
+		raw, ok := v.(string)
+		var s CachedSummary
+		if !ok || json.Unmarshal([]byte(raw), &s) != nil {
+			missing = append(missing, ids[i])
+			continue
+		}
+		found[ids[i]] = s
+	}
+	return found, missing, nil
+}
+
+func (c *SummaryCache) Set(ctx context.Context, summaries []CachedSummary) error {
+	if c == nil || len(summaries) == 0 {
+		return nil
+	}
+	pipe := c.rdb.Pipeline()
+	for _, s := range summaries {
+		data, _ := json.Marshal(s)
+		pipe.Set(ctx, summaryKey(s.ID), data, summaryTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *SummaryCache) Invalidate(ctx context.Context, id uint) error {
+	if c == nil {
+		return nil
+	}
+	return c.rdb.Del(ctx, summaryKey(id)).Err()
+}
@@ -4,9 +4,10 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"socialnet/pkg/utils"
-	pb "socialnet/services/user/gen"
 	"socialnet/services/user/internal/model"
+	"strings"
 )
 
 type UserRepo struct {
@@ -25,52 +26,383 @@ func (r *UserRepo) GetUser(id uint) (*model.User, error) {
 	return user, nil
 }
 
-func (r *UserRepo) UpdateUser(req *pb.UpdateUserRequest) error {
-	id, err := utils.StringToUint(req.Id)
-	if err != nil {
+func (r *UserRepo) UpdateAvatar(userID string, avatarURL string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).Update("avatar_url", avatarURL).Error
+}
+
+func (r *UserRepo) UpdateCover(userID string, coverURL string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).Update("cover_url", coverURL).Error
+}
+
+// FollowByUserId — false, если подписка уже была
+func (r *UserRepo) FollowByUserId(followerID, followingID uint) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		created, err = createFollow(tx, followerID, followingID)
+		return err
+	})
+	return created, err
+}
+
+func (r *UserRepo) DeleteFollow(followerID, followingID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteFollows(tx, "follower_id = ? AND following_id = ?", followerID, followingID)
+	})
+}
+
+// createFollow — все подписки создаются через него, чтобы счётчики не расходились с follows
+func createFollow(tx *gorm.DB, followerID, followingID uint) (bool, error) {
+	res := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.Follow{FollowerID: followerID, FollowingID: followingID})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	return true, bumpFollowStats(tx, followerID, followingID, 1)
+}
+
+// deleteFollows — удаляет подписки по условию и уменьшает счётчики у обеих сторон каждой из них
+func deleteFollows(tx *gorm.DB, query string, args ...interface{}) error {
+	var follows []model.Follow
+	if err := tx.Where(query, args...).Find(&follows).Error; err != nil {
 		return err
 	}
-	user := &model.User{Id: id, Firstname: req.FirstName, Lastname: req.LastName,
-		BirthDate: req.BirthDate, Bio: req.Bio}
-	if err := r.db.Where("id = ?", req.Id).Save(&user).Error; err != nil {
-		return status.Error(codes.Internal, "unable to save info")
+	for _, f := range follows {
+		res := tx.Delete(&model.Follow{}, f.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+		if err := bumpFollowStats(tx, f.FollowerID, f.FollowingID, -1); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (r *UserRepo) UpdateAvatar(userID string, avatarURL string) error {
-	return r.db.Model(&model.User{}).Where("id = ?", userID).Update("avatar_url", avatarURL).Error
+func bumpFollowStats(tx *gorm.DB, followerID, followingID uint, delta int64) error {
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"following": gorm.Expr("follow_stats.following + ?", delta)}),
+	}).Create(&model.FollowStats{UserID: followerID, Following: delta}).Error; err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"followers": gorm.Expr("follow_stats.followers + ?", delta)}),
+	}).Create(&model.FollowStats{UserID: followingID, Followers: delta}).Error
 }
 
-func (r *UserRepo) FollowByUserId(followerID, followingID uint) error {
-	follow := &model.Follow{
-		FollowerID:  followerID,
-		FollowingID: followingID,
+// DedupeFollows — вызывается до AutoMigrate, пока нет уникального индекса idx_follows_unique.
+// Раньше подписка не проверялась на повтор, и одна пара могла попасть в follows несколько раз:
+// индекс на таких данных не построится. Остаётся самая ранняя строка пары (MIN(id)).
+func (r *UserRepo) DedupeFollows() (int64, error) {
+	migrator := r.db.Migrator()
+	if !migrator.HasTable(&model.Follow{}) || migrator.HasIndex(&model.Follow{}, "idx_follows_unique") {
+		return 0, nil
 	}
+	res := r.db.Exec(`DELETE FROM follows WHERE id NOT IN
+		(SELECT MIN(id) FROM follows GROUP BY follower_id, following_id)`)
+	return res.RowsAffected, res.Error
+}
 
-	if err := r.db.Create(follow).Error; err != nil {
+// BackfillFollowStats — заполняет счётчики по follows, если таблица ещё пустая
+// (первый запуск после появления счётчиков)
+func (r *UserRepo) BackfillFollowStats() error {
+	var count int64
+	if err := r.db.Model(&model.FollowStats{}).Count(&count).Error; err != nil || count > 0 {
 		return err
 	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var rows []struct {
+			UserID uint
+			Count  int64
+		}
+		if err := tx.Model(&model.Follow{}).Select("following_id AS user_id, COUNT(*) AS count").
+			Group("following_id").Scan(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := tx.Create(&model.FollowStats{UserID: row.UserID, Followers: row.Count}).Error; err != nil {
+				return err
+			}
+		}
+		rows = nil
+		if err := tx.Model(&model.Follow{}).Select("follower_id AS user_id, COUNT(*) AS count").
+			Group("follower_id").Scan(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"following": row.Count}),
+			}).Create(&model.FollowStats{UserID: row.UserID, Following: row.Count}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FollowStats — счётчики по id; у кого подписок не было, в карте нет
+func (r *UserRepo) FollowStats(userIDs []uint) (map[uint]model.FollowStats, error) {
+	var stats []model.FollowStats
+	if err := r.db.Where("user_id IN ?", userIDs).Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]model.FollowStats, len(stats))
+	for _, s := range stats {
+		byID[s.UserID] = s
+	}
+	return byID, nil
+}
+
+// FollowedAmong — на кого из userIDs подписан followerID
+func (r *UserRepo) FollowedAmong(followerID uint, userIDs []uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.Follow{}).Where("follower_id = ? AND following_id IN ?", followerID, userIDs).
+		Pluck("following_id", &ids).Error
+	return ids, err
+}
+
+// HasFollowRequest — есть ли ожидающая заявка requesterID к targetID
+func (r *UserRepo) HasFollowRequest(requesterID, targetID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.FollowRequest{}).
+		Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *UserRepo) IsFollowing(followerID, followingID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Follow{}).
+		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// SetPrivacy — профиль мог ещё не заполняться, тогда создаём пустую строку
+func (r *UserRepo) SetPrivacy(userID uint, private bool) error {
+	res := r.db.Model(&model.User{}).Where("id = ?", userID).Update("is_private", private)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return r.db.Create(&model.User{Id: userID, IsPrivate: private}).Error
+	}
 	return nil
 }
 
-func (r *UserRepo) DeleteFollow(followerID, followingID uint) error {
-	return r.db.Where("follower_id = ? AND following_id = ?", followerID, followingID).
-		Delete(&model.Follow{}).Error
+// SetHandle — источник истины auth: если имя ещё числится за другим профилем
+// (его переименование не дошло), у того оно снимается до следующей синхронизации
+func (r *UserRepo) SetHandle(userID uint, handle string) error {
+	lower := strings.ToLower(handle)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{}).Where("handle_lower = ? AND id <> ?", lower, userID).
+			Updates(map[string]interface{}{"handle": "", "handle_lower": nil}).Error; err != nil {
+			return err
+		}
+		res := tx.Model(&model.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"handle": handle, "handle_lower": lower})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return tx.Create(&model.User{Id: userID, Handle: handle, HandleLower: &lower}).Error
+		}
+		return nil
+	})
 }
 
-func (r *UserRepo) GetFollowing(userID string) ([]*model.User, error) {
+func (r *UserRepo) GetUserByHandle(handle string) (*model.User, error) {
+	user := &model.User{}
+	if err := r.db.Where("handle_lower = ?", strings.ToLower(handle)).First(user).Error; err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return user, nil
+}
+
+func (r *UserRepo) GetUsersByHandles(handles []string) ([]*model.User, error) {
+	lower := make([]string, 0, len(handles))
+	for _, h := range handles {
+		lower = append(lower, strings.ToLower(h))
+	}
 	var users []*model.User
+	if err := r.db.Where("handle_lower IN ?", lower).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
 
-	err := r.db.
-		Joins("JOIN follows ON follows.following_id = users.id").
-		Where("follows.follower_id = ?", userID).
-		Find(&users).Error
+// SaveFollowRequest — повторная заявка не дублируется; false, если заявка уже была
+func (r *UserRepo) SaveFollowRequest(requesterID, targetID uint) (bool, error) {
+	res := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.FollowRequest{RequesterID: requesterID, TargetID: targetID})
+	return res.RowsAffected > 0, res.Error
+}
 
-	if err != nil {
+func (r *UserRepo) DeleteFollowRequest(requesterID, targetID uint) (int64, error) {
+	res := r.db.Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Delete(&model.FollowRequest{})
+	return res.RowsAffected, res.Error
+}
+
+// ApproveFollowRequest — заявка превращается в подписку; false, если заявки не было
+func (r *UserRepo) ApproveFollowRequest(requesterID, targetID uint) (bool, error) {
+	approved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+			Delete(&model.FollowRequest{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		approved = true
+		_, err := createFollow(tx, requesterID, targetID)
+		return err
+	})
+	return approved, err
+}
+
+// ApproveAllFollowRequests — аккаунт стал открытым, ожидающие заявки становятся подписками
+func (r *UserRepo) ApproveAllFollowRequests(targetID uint) ([]uint, error) {
+	var requesters []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.FollowRequest{}).Where("target_id = ?", targetID).
+			Pluck("requester_id", &requesters).Error; err != nil {
+			return err
+		}
+		for _, id := range requesters {
+			if _, err := createFollow(tx, id, targetID); err != nil {
+				return err
+			}
+		}
+		return tx.Where("target_id = ?", targetID).Delete(&model.FollowRequest{}).Error
+	})
+	return requesters, err
+}
+
+// ListFollowRequests — входящие заявки, старые первыми, keyset по (created_at, id)
+func (r *UserRepo) ListFollowRequests(targetID uint, after *utils.PageCursor, limit int) ([]*model.FollowRequest, error) {
+	var requests []*model.FollowRequest
+	q := r.db.Where("target_id = ?", targetID).Order("created_at, id").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at > ? OR (created_at = ? AND id > ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	if err := q.Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Block — блокировка снимает подписки и заявки в обе стороны
+func (r *UserRepo) Block(blockerID, blockedID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.Block{BlockerID: blockerID, BlockedID: blockedID}).Error; err != nil {
+			return err
+		}
+		if err := deleteFollows(tx, "(follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)",
+			blockerID, blockedID, blockedID, blockerID); err != nil {
+			return err
+		}
+		return tx.Where("(requester_id = ? AND target_id = ?) OR (requester_id = ? AND target_id = ?)",
+			blockerID, blockedID, blockedID, blockerID).Delete(&model.FollowRequest{}).Error
+	})
+}
+
+func (r *UserRepo) Unblock(blockerID, blockedID uint) error {
+	return r.db.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&model.Block{}).Error
+}
+
+// IsBlocked — блокировка между пользователями в любую сторону
+func (r *UserRepo) IsBlocked(userID, otherID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherID, otherID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *UserRepo) Mute(muterID, mutedID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.Mute{MuterID: muterID, MutedID: mutedID}).Error
+}
+
+func (r *UserRepo) Unmute(muterID, mutedID uint) error {
+	return r.db.Where("muter_id = ? AND muted_id = ?", muterID, mutedID).Delete(&model.Mute{}).Error
+}
+
+// ListBlocked — кого заблокировал пользователь, новые первыми, keyset по (created_at, id)
+func (r *UserRepo) ListBlocked(blockerID uint, after *utils.PageCursor, limit int) ([]*model.Block, error) {
+	var blocks []*model.Block
+	q := r.db.Where("blocker_id = ?", blockerID).Order("created_at DESC, id DESC").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at < ? OR (created_at = ? AND id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	if err := q.Find(&blocks).Error; err != nil {
 		return nil, err
 	}
+	return blocks, nil
+}
 
+// Relations — кто из otherIDs в блокировке с userID (в любую сторону) и кого userID заглушил
+func (r *UserRepo) Relations(userID uint, otherIDs []uint) (blocked, muted []uint, err error) {
+	var blocks []model.Block
+	if err = r.db.Where("(blocker_id = ? AND blocked_id IN ?) OR (blocked_id = ? AND blocker_id IN ?)",
+		userID, otherIDs, userID, otherIDs).Find(&blocks).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, b := range blocks {
+		if b.BlockerID == userID {
+			blocked = append(blocked, b.BlockedID)
+		} else {
+			blocked = append(blocked, b.BlockerID)
+		}
+	}
+	if err = r.db.Model(&model.Mute{}).Where("muter_id = ? AND muted_id IN ?", userID, otherIDs).
+		Pluck("muted_id", &muted).Error; err != nil {
+		return nil, nil, err
+	}
+	return blocked, muted, nil
+}
+
+// HiddenAuthors — закрытые аккаунты из authorIDs, на которые viewer не подписан.
+// Свои посты видны всегда; viewerID == 0 — анонимный просмотр.
+func (r *UserRepo) HiddenAuthors(viewerID uint, authorIDs []uint) ([]uint, error) {
+	var hidden []uint
+	err := r.db.Model(&model.User{}).
+		Where("id IN ? AND is_private = ? AND id <> ?", authorIDs, true, viewerID).
+		Where("id NOT IN (?)", r.db.Model(&model.Follow{}).Select("following_id").Where("follower_id = ?", viewerID)).
+		Pluck("id", &hidden).Error
+	return hidden, err
+}
+
+// ListFollows — подписки (followers == false) или подписчики пользователя,
+// новые первыми, keyset по (created_at, id) строки follows
+func (r *UserRepo) ListFollows(userID uint, followers bool, after *utils.PageCursor, limit int) ([]*model.Follow, error) {
+	var follows []*model.Follow
+	column := "follower_id"
+	if followers {
+		column = "following_id"
+	}
+	q := r.db.Where(column+" = ?", userID).Order("created_at DESC, id DESC").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at < ? OR (created_at = ? AND id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	if err := q.Find(&follows).Error; err != nil {
+		return nil, err
+	}
+	return follows, nil
+}
+
+func (r *UserRepo) GetUsersByIDs(ids []uint) ([]*model.User, error) {
+	var users []*model.User
+	if err := r.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
 	return users, nil
 }
 func (r *UserRepo) SearchUsers(query string, limit, offset int) ([]model.User, error) {
@@ -87,10 +419,71 @@ func (r *UserRepo) SearchUsers(query string, limit, offset int) ([]model.User, e
 	return users, err
 }
 
-func (r *UserRepo) GetAllUsers() ([]*model.User, error) {
+// ListUsers — keyset страница по (created_at, id). Берём limit+1 строк:
+// лишняя строка означает, что есть следующая страница.
+func (r *UserRepo) ListUsers(after *utils.PageCursor, limit int) ([]*model.User, error) {
 	var users []*model.User
-	if err := r.db.Find(&users).Error; err != nil {
+	q := r.db.Order("created_at, id").Limit(limit + 1)
+	if after != nil {
+		q = q.Where("created_at > ? OR (created_at = ? AND id > ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	if err := q.Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
 }
+
+// DeleteUserData — профиль, подписки, заявки, блокировки, заглушки и рекомендации в обе стороны; отсутствие данных ошибкой не считается
+func (r *UserRepo) DeleteUserData(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteFollows(tx, "follower_id = ? OR following_id = ?", userID, userID); err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.FollowStats{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("requester_id = ? OR target_id = ?", userID, userID).
+			Delete(&model.FollowRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("blocker_id = ? OR blocked_id = ?", userID, userID).
+			Delete(&model.Block{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("muter_id = ? OR muted_id = ?", userID, userID).
+			Delete(&model.Mute{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ? OR candidate_id = ?", userID, userID).
+			Delete(&model.Suggestion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ? OR candidate_id = ?", userID, userID).
+			Delete(&model.DismissedSuggestion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.ProfileLink{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.ProfileEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.FieldVisibility{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", userID).Delete(&model.User{}).Error
+	})
+}
+
+// FollowIDs — id подписок и подписчиков пользователя (для выгрузки данных)
+func (r *UserRepo) FollowIDs(userID uint) (following, followers []uint, err error) {
+	if err = r.db.Model(&model.Follow{}).Where("follower_id = ?", userID).
+		Pluck("following_id", &following).Error; err != nil {
+		return nil, nil, err
+	}
+	if err = r.db.Model(&model.Follow{}).Where("following_id = ?", userID).
+		Pluck("follower_id", &followers).Error; err != nil {
+		return nil, nil, err
+	}
+	return following, followers, nil
+}
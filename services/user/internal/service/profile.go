@@ -0,0 +1,287 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/url"
+	"socialnet/pkg/storage"
+	"socialnet/pkg/utils"
+	pb "socialnet/services/user/gen"
+	"socialnet/services/user/internal/model"
+	"socialnet/services/user/internal/repos"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxProfileLinks   = 5
+	maxProfileEntries = 10
+)
+
+// visibilityFields — поля со своей видимостью; имя, handle и аватар видны всем
+var visibilityFields = map[string]bool{
+	"birth_date": true, "bio": true, "location": true, "links": true,
+	"pronouns": true, "cover_url": true, "work": true, "education": true,
+}
+
+// maskAliases — JSON маска из gateway приходит в snake_case, а у старых полей
+// proto имена в camelCase; принимаем оба варианта
+var maskAliases = map[string]string{
+	"firstName": "first_name", "lastName": "last_name", "birthDate": "birth_date",
+}
+
+// UpdateUser — PATCH профиля: меняются только поля из update_mask
+func (s *UserService) UpdateUser(callerID string, req *pb.UpdateUserRequest) error {
+	id, err := utils.StringToUint(req.Id)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if callerID != req.Id {
+		return status.Error(codes.PermissionDenied, "cannot edit another user's profile")
+	}
+
+	// gateway с body "*" маску сам не заполняет: без неё PATCH затёр бы непереданные поля
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+	patch := repos.ProfilePatch{Columns: map[string]interface{}{}}
+	for _, path := range paths {
+		if alias, ok := maskAliases[path]; ok {
+			path = alias
+		}
+		if err := applyPath(&patch, path, req); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpdateProfile(id, patch); err != nil {
+		return status.Error(codes.Internal, "unable to save info")
+	}
+	s.invalidateSummary(id)
+	return nil
+}
+
+func applyPath(patch *repos.ProfilePatch, path string, req *pb.UpdateUserRequest) error {
+	switch path {
+	case "first_name", "last_name":
+		value := strings.TrimSpace(req.FirstName)
+		if path == "last_name" {
+			value = strings.TrimSpace(req.LastName)
+		}
+		if err := checkLen(path, value, 50); err != nil {
+			return err
+		}
+		// колонки firstname/lastname — по именам полей модели
+		patch.Columns[strings.ReplaceAll(path, "_", "")] = value
+	case "birth_date":
+		birthday, err := parseBirthday(req.BirthDate)
+		if err != nil {
+			return err
+		}
+		// дата в старой текстовой колонке больше не нужна
+		patch.Columns["birthday"] = birthday
+		patch.Columns["birth_date"] = ""
+	case "bio", "location", "pronouns":
+		value := strings.TrimSpace(map[string]string{
+			"bio": req.Bio, "location": req.Location, "pronouns": req.Pronouns,
+		}[path])
+		if err := checkLen(path, value, map[string]int{"bio": 255, "location": 100, "pronouns": 40}[path]); err != nil {
+			return err
+		}
+		patch.Columns[path] = value
+	case "links":
+		links, err := parseLinks(req.Links)
+		if err != nil {
+			return err
+		}
+		patch.ReplaceLinks, patch.Links = true, links
+	case "work", "education":
+		source, kind := req.Work, model.EntryWork
+		if path == "education" {
+			source, kind = req.Education, model.EntryEducation
+		}
+		entries, err := parseEntries(path, source)
+		if err != nil {
+			return err
+		}
+		if patch.Entries == nil {
+			patch.Entries = map[string][]model.ProfileEntry{}
+		}
+		patch.Entries[kind] = entries
+	case "field_visibility":
+		patch.Visibility = map[string]int{}
+		for field, level := range req.FieldVisibility {
+			if !visibilityFields[field] {
+				return status.Errorf(codes.InvalidArgument, "visibility cannot be set for %q", field)
+			}
+			if _, ok := pb.Visibility_name[int32(level)]; !ok {
+				return status.Errorf(codes.InvalidArgument, "invalid visibility for %q", field)
+			}
+			// UNSPECIFIED (0) сбрасывает настройку
+			patch.Visibility[field] = int(level)
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown field in update_mask: %q", path)
+	}
+	return nil
+}
+
+func checkLen(field, value string, max int) error {
+	if utf8.RuneCountInString(value) > max {
+		return status.Errorf(codes.InvalidArgument, "%s is longer than %d characters", field, max)
+	}
+	return nil
+}
+
+// parseBirthday — YYYY-MM-DD в прошлом; пустая строка очищает дату
+func parseBirthday(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil || day.Year() < 1900 || day.After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "birth_date must be a past date in YYYY-MM-DD format")
+	}
+	return &day, nil
+}
+
+func parseLinks(links []*pb.ProfileLink) ([]model.ProfileLink, error) {
+	if len(links) > maxProfileLinks {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d links allowed", maxProfileLinks)
+	}
+	result := make([]model.ProfileLink, 0, len(links))
+	for _, l := range links {
+		raw := strings.TrimSpace(l.Url)
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(raw) > 255 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid link %q", l.Url)
+		}
+		label := strings.TrimSpace(l.Label)
+		if err := checkLen("link label", label, 50); err != nil {
+			return nil, err
+		}
+		result = append(result, model.ProfileLink{URL: raw, Label: label})
+	}
+	return result, nil
+}
+
+func parseEntries(field string, entries []*pb.ProfileEntry) ([]model.ProfileEntry, error) {
+	if len(entries) > maxProfileEntries {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d %s entries allowed", maxProfileEntries, field)
+	}
+	thisYear := int32(time.Now().Year())
+	validYear := func(y int32) bool { return y == 0 || (y >= 1900 && y <= thisYear+10) }
+
+	result := make([]model.ProfileEntry, 0, len(entries))
+	for _, e := range entries {
+		org, title := strings.TrimSpace(e.Organization), strings.TrimSpace(e.Title)
+		if org == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "%s entry requires organization", field)
+		}
+		if err := checkLen("organization", org, 100); err != nil {
+			return nil, err
+		}
+		if err := checkLen("title", title, 100); err != nil {
+			return nil, err
+		}
+		if !validYear(e.StartYear) || !validYear(e.EndYear) ||
+			(e.StartYear != 0 && e.EndYear != 0 && e.EndYear < e.StartYear) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid years in %s entry", field)
+		}
+		result = append(result, model.ProfileEntry{
+			Organization: org, Title: title, StartYear: int(e.StartYear), EndYear: int(e.EndYear),
+		})
+	}
+	return result, nil
+}
+
+func birthDate(u *model.User) string {
+	if u.Birthday != nil {
+		return u.Birthday.Format("2006-01-02")
+	}
+	return u.LegacyBirthDate
+}
+
+func addDetails(p *pb.User, links []model.ProfileLink, entries []model.ProfileEntry) {
+	for _, l := range links {
+		p.Links = append(p.Links, &pb.ProfileLink{Url: l.URL, Label: l.Label})
+	}
+	for _, e := range entries {
+		entry := &pb.ProfileEntry{
+			Organization: e.Organization, Title: e.Title,
+			StartYear: int32(e.StartYear), EndYear: int32(e.EndYear),
+		}
+		if e.Kind == model.EntryEducation {
+			p.Education = append(p.Education, entry)
+		} else {
+			p.Work = append(p.Work, entry)
+		}
+	}
+}
+
+// redact — очищает поля, которые не видны зрителю; follower — зритель подписан на владельца
+func redact(p *pb.User, levels map[string]int, follower bool) {
+	for field, level := range levels {
+		if level == model.VisibilityPublic || (level == model.VisibilityFollowers && follower) {
+			continue
+		}
+		switch field {
+		case "birth_date":
+			p.BirthDate = ""
+		case "bio":
+			p.Bio = ""
+		case "location":
+			p.Location = ""
+		case "links":
+			p.Links = nil
+		case "pronouns":
+			p.Pronouns = ""
+		case "cover_url":
+			p.CoverUrl = ""
+		case "work":
+			p.Work = nil
+		case "education":
+			p.Education = nil
+		}
+	}
+}
+
+// visibilityProto — настройки для владельца, вместе с полями по умолчанию (public)
+func visibilityProto(levels map[string]int) map[string]pb.Visibility {
+	result := make(map[string]pb.Visibility, len(visibilityFields))
+	for field := range visibilityFields {
+		result[field] = pb.Visibility_VISIBILITY_PUBLIC
+		if level, ok := levels[field]; ok {
+			result[field] = pb.Visibility(level)
+		}
+	}
+	return result
+}
+
+// UpdateCover — как UpdateAvatar, но менять обложку может только владелец
+func (s *UserService) UpdateCover(callerID string, req *pb.UpdateCoverRequest) (*pb.UpdateCoverResponse, error) {
+	if callerID != req.Id {
+		return nil, status.Error(codes.PermissionDenied, "cannot edit another user's profile")
+	}
+	if len(req.Cover) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cover is empty")
+	}
+	s3, err := storage.NewS3Client()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "cannot to create client")
+	}
+
+	key := fmt.Sprintf("covers/%s_%s", req.Id, req.Filename)
+	url, err := s3.UploadFile(bytes.NewReader(req.Cover), key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upload cover: %v", err)
+	}
+	if err := s.repo.UpdateCover(req.Id, url); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update cover in DB: %v", err)
+	}
+	return &pb.UpdateCoverResponse{CoverUrl: url}, nil
+}
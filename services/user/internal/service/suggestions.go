@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"math"
+	"socialnet/pkg/utils"
+	pb "socialnet/services/user/gen"
+	"socialnet/services/user/internal/model"
+	"sort"
+	"time"
+)
+
+const (
+	suggestionsPerUser = 50
+	// строки с user_id = 0 — общий список популярных
+	popularListID  = 0
+	activityWindow = 30 * 24 * time.Hour
+)
+
+// socialGraph — follows, блокировки и скрытия в памяти на время одного пересчёта
+type socialGraph struct {
+	following map[uint]map[uint]bool
+	followers map[uint]map[uint]bool
+	blocked   map[uint]map[uint]bool // в обе стороны
+	dismissed map[uint]map[uint]bool
+	// dismissCount — сколько раз кандидата скрыли, понижает его у всех
+	dismissCount map[uint]int
+	// recent — подписки с участием пользователя за activityWindow
+	recent   map[uint]int
+	profiles map[uint]bool
+}
+
+func addEdge(m map[uint]map[uint]bool, from, to uint) {
+	if m[from] == nil {
+		m[from] = map[uint]bool{}
+	}
+	m[from][to] = true
+}
+
+type candidate struct {
+	id         uint
+	fof        int  // сколько ваших подписок подписаны на кандидата
+	mutual     int  // сколько ваших подписчиков подписаны на кандидата
+	followsYou bool // кандидат уже подписан на вас
+	score      float64
+}
+
+func (g *socialGraph) score(c *candidate) float64 {
+	score := 3*float64(c.fof) + 2*float64(c.mutual) +
+		math.Log1p(float64(g.recent[c.id])) + 0.5*math.Log1p(float64(len(g.followers[c.id])))
+	if c.followsYou {
+		score += 4
+	}
+	return score / (1 + 0.5*float64(g.dismissCount[c.id]))
+}
+
+func (s *UserService) loadGraph(now time.Time) (*socialGraph, error) {
+	g := &socialGraph{
+		following:    map[uint]map[uint]bool{},
+		followers:    map[uint]map[uint]bool{},
+		blocked:      map[uint]map[uint]bool{},
+		dismissed:    map[uint]map[uint]bool{},
+		dismissCount: map[uint]int{},
+		recent:       map[uint]int{},
+		profiles:     map[uint]bool{},
+	}
+	follows, err := s.repo.AllFollows()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range follows {
+		addEdge(g.following, f.FollowerID, f.FollowingID)
+		addEdge(g.followers, f.FollowingID, f.FollowerID)
+		if now.Sub(f.CreatedAt) < activityWindow {
+			g.recent[f.FollowerID]++
+			g.recent[f.FollowingID]++
+		}
+	}
+	blocks, err := s.repo.AllBlocks()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range blocks {
+		addEdge(g.blocked, b.BlockerID, b.BlockedID)
+		addEdge(g.blocked, b.BlockedID, b.BlockerID)
+	}
+	dismissals, err := s.repo.AllDismissals()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dismissals {
+		addEdge(g.dismissed, d.UserID, d.CandidateID)
+		g.dismissCount[d.CandidateID]++
+	}
+	ids, err := s.repo.AllUserIDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		g.profiles[id] = true
+	}
+	return g, nil
+}
+
+// rank — лучшие кандидаты по убыванию очков, при равенстве меньший id первым
+func rank(candidates map[uint]*candidate, limit int) []*candidate {
+	list := make([]*candidate, 0, len(candidates))
+	for _, c := range candidates {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		return list[i].id < list[j].id
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+func toSuggestions(userID uint, ranked []*candidate, now time.Time) []model.Suggestion {
+	result := make([]model.Suggestion, 0, len(ranked))
+	for _, c := range ranked {
+		result = append(result, model.Suggestion{
+			UserID:      userID,
+			CandidateID: c.id,
+			Score:       c.score,
+			MutualCount: c.fof,
+			FollowsYou:  c.followsYou,
+			ComputedAt:  now,
+		})
+	}
+	return result
+}
+
+// RunSuggestions — пересчёт рекомендаций для всех пользователей с профилем.
+// Кандидаты: подписки подписок, на кого подписаны ваши подписчики, кто подписан на вас,
+// плюс общий список популярных, чтобы у новичка без подписок лента не была пустой.
+func (s *UserService) RunSuggestions(now time.Time) (int, error) {
+	g, err := s.loadGraph(now)
+	if err != nil {
+		return 0, err
+	}
+
+	popular := map[uint]*candidate{}
+	for id := range g.profiles {
+		c := &candidate{id: id}
+		c.score = g.score(c)
+		popular[id] = c
+	}
+	topPopular := rank(popular, suggestionsPerUser)
+	if err := s.repo.ReplaceSuggestions(popularListID, toSuggestions(popularListID, topPopular, now)); err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for user := range g.profiles {
+		candidates := map[uint]*candidate{}
+		get := func(id uint) *candidate {
+			if candidates[id] == nil {
+				candidates[id] = &candidate{id: id}
+			}
+			return candidates[id]
+		}
+		for f := range g.following[user] {
+			for c := range g.following[f] {
+				get(c).fof++
+			}
+		}
+		for f := range g.followers[user] {
+			get(f).followsYou = true
+			for c := range g.following[f] {
+				get(c).mutual++
+			}
+		}
+		for _, c := range topPopular {
+			get(c.id)
+		}
+
+		for id, c := range candidates {
+			if id == user || !g.profiles[id] || g.following[user][id] || g.blocked[user][id] || g.dismissed[user][id] {
+				delete(candidates, id)
+				continue
+			}
+			c.score = g.score(c)
+		}
+		if err := s.repo.ReplaceSuggestions(user, toSuggestions(user, rank(candidates, suggestionsPerUser), now)); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// StartSuggestionWorker — первый пересчёт сразу при старте, дальше раз в interval до отмены ctx
+func (s *UserService) StartSuggestionWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.RunSuggestions(time.Now()); err != nil {
+			log.Printf("❌ suggestion worker: %v", err)
+		} else {
+			log.Printf("✨ follow suggestions recomputed for %d users", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SuggestFollows — свой готовый список, а пока его нет (новый пользователь) — общий популярный
+func (s *UserService) SuggestFollows(ctx context.Context, userID string, req *pb.SuggestFollowsRequest) (*pb.Suggestions, error) {
+	id, err := utils.StringToUint(userID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	size := utils.PageSize(req.PageSize)
+	suggestions, err := s.repo.ListSuggestions(id, id, size)
+	if err == nil && len(suggestions) == 0 {
+		suggestions, err = s.repo.ListSuggestions(id, popularListID, size)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load suggestions")
+	}
+
+	ids := make([]uint, 0, len(suggestions))
+	for _, sg := range suggestions {
+		ids = append(ids, sg.CandidateID)
+	}
+	summaries, err := s.summaries(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.Suggestions{}
+	for _, sg := range suggestions {
+		summary, ok := summaries[sg.CandidateID]
+		if !ok {
+			continue
+		}
+		resp.Suggestions = append(resp.Suggestions, &pb.Suggestion{
+			User:        summary,
+			MutualCount: int32(sg.MutualCount),
+			FollowsYou:  sg.FollowsYou,
+		})
+	}
+	return resp, nil
+}
+
+func (s *UserService) DismissSuggestion(userID, candidateID string) error {
+	user, candidate, err := parsePair(userID, candidateID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DismissSuggestion(user, candidate); err != nil {
+		return status.Error(codes.Internal, "failed to dismiss suggestion")
+	}
+	return nil
+}
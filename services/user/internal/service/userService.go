@@ -7,6 +7,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"log"
+	"regexp"
 	"socialnet/pkg/config"
 	"socialnet/pkg/storage"
 	"socialnet/pkg/utils"
@@ -14,18 +16,24 @@ import (
 	pb "socialnet/services/user/gen"
 	"socialnet/services/user/internal/model"
 	"socialnet/services/user/internal/repos"
+	"strings"
+	"time"
 )
 
 type UserService struct {
 	repo    *repos.UserRepo
 	clients *config.GRPCClients
+
+	// Summaries — кэш BatchGetUsers; nil — всегда читаем из БД
+	Summaries *repos.SummaryCache
 }
 
 func NewUserService(r *repos.UserRepo, clients *config.GRPCClients) *UserService {
 	return &UserService{repo: r, clients: clients}
 }
 
-func (s *UserService) GetUser(req *pb.GetUserRequest) (*model.User, error) {
+// GetUser — профиль со счётчиками подписок; viewerID пустой у анонимного запроса
+func (s *UserService) GetUser(viewerID string, req *pb.GetUserRequest) (*pb.User, error) {
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "required id")
 	}
@@ -37,22 +45,195 @@ func (s *UserService) GetUser(req *pb.GetUserRequest) (*model.User, error) {
 	if err != nil {
 		return nil, err
 	}
-	return user, nil
+	profiles, err := s.profiles(viewerID, []*model.User{user})
+	if err != nil {
+		return nil, err
+	}
+	return profiles[0], nil
 }
 
-func (s *UserService) UpdateUser(req *pb.UpdateUserRequest) error {
-	err := utils.ValidateStruct(req)
+func toProto(u *model.User) *pb.User {
+	return &pb.User{
+		Id:        fmt.Sprint(u.Id),
+		FirstName: u.Firstname,
+		LastName:  u.Lastname,
+		Bio:       u.Bio,
+		AvatarUrl: u.AvatarUrl,
+		CoverUrl:  u.CoverUrl,
+		CreatedAt: u.CreatedAt.Format(time.RFC3339),
+		BirthDate: birthDate(u),
+		IsPrivate: u.IsPrivate,
+		Handle:    u.Handle,
+		Location:  u.Location,
+		Pronouns:  u.Pronouns,
+	}
+}
+
+// handleRe — те же правила, что у username в auth
+var handleRe = regexp.MustCompile(`^[a-zA-Z0-9_]{3,32}$`)
+
+// GetUserByHandle — профиль по @handle, без учёта регистра
+func (s *UserService) GetUserByHandle(viewerID string, req *pb.GetUserByHandleRequest) (*pb.User, error) {
+	handle := strings.TrimPrefix(strings.TrimSpace(req.Handle), "@")
+	if !handleRe.MatchString(handle) {
+		return nil, status.Error(codes.InvalidArgument, "invalid handle")
+	}
+	user, err := s.repo.GetUserByHandle(handle)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	profiles, err := s.profiles(viewerID, []*model.User{user})
+	if err != nil {
+		return nil, err
+	}
+	return profiles[0], nil
+}
 
-	err = s.repo.UpdateUser(req)
+// SetHandle — вызывает auth; повторный вызов с тем же именем безопасен
+func (s *UserService) SetHandle(req *pb.SetHandleRequest) error {
+	id, err := utils.StringToUint(req.UserId)
 	if err != nil {
-		return err
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if !handleRe.MatchString(req.Handle) {
+		return status.Error(codes.InvalidArgument, "invalid handle")
 	}
+	if err := s.repo.SetHandle(id, req.Handle); err != nil {
+		return status.Error(codes.Internal, "failed to save handle")
+	}
+	s.invalidateSummary(id)
 	return nil
 }
 
+// profiles — профили с счётчиками из follow_stats и is_followed_by_me для viewerID.
+// Поля профиля, скрытые от viewerID настройками видимости, приходят пустыми.
+func (s *UserService) profiles(viewerID string, users []*model.User) ([]*pb.User, error) {
+	ids := make([]uint, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.Id)
+	}
+	stats, err := s.repo.FollowStats(ids)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load follow counts")
+	}
+	links, entries, visibility, err := s.repo.ProfileDetails(ids)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load profile details")
+	}
+	followed := map[uint]bool{}
+	if viewerID != "" {
+		if viewer, err := utils.StringToUint(viewerID); err == nil {
+			among, err := s.repo.FollowedAmong(viewer, ids)
+			if err != nil {
+				return nil, status.Error(codes.Internal, "failed to check follows")
+			}
+			for _, id := range among {
+				followed[id] = true
+			}
+		}
+	}
+
+	result := make([]*pb.User, 0, len(users))
+	for _, u := range users {
+		p := toProto(u)
+		p.FollowerCount = stats[u.Id].Followers
+		p.FollowingCount = stats[u.Id].Following
+		p.IsFollowedByMe = followed[u.Id]
+		addDetails(p, links[u.Id], entries[u.Id])
+		if viewerID == p.Id {
+			p.FieldVisibility = visibilityProto(visibility[u.Id])
+		} else {
+			redact(p, visibility[u.Id], followed[u.Id])
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// invalidateSummary — без сброса имя и аватар в ленте обновятся только по истечении TTL кэша
+func (s *UserService) invalidateSummary(id uint) {
+	if err := s.Summaries.Invalidate(context.Background(), id); err != nil {
+		log.Printf("⚠️ failed to invalidate user %d summary: %v", id, err)
+	}
+}
+
+// BatchGetUsers — краткие профили в порядке ids, затем найденные по handles:
+// сначала кэш, промахи одним IN запросом
+func (s *UserService) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	ids := make([]uint, 0, len(req.Ids))
+	seen := map[uint]bool{}
+	for _, raw := range req.Ids {
+		id, err := utils.StringToUint(raw)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(req.Handles) > 0 {
+		byHandle, err := s.repo.GetUsersByHandles(req.Handles)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to load users")
+		}
+		for _, u := range byHandle {
+			if !seen[u.Id] {
+				seen[u.Id] = true
+				ids = append(ids, u.Id)
+			}
+		}
+	}
+
+	found, err := s.summaries(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.BatchGetUsersResponse{}
+	for _, id := range ids {
+		if summary, ok := found[id]; ok {
+			resp.Users = append(resp.Users, summary)
+		}
+	}
+	return resp, nil
+}
+
+// summaries — read-through: сбой Redis не ошибка, тогда всё читается из БД
+func (s *UserService) summaries(ctx context.Context, ids []uint) (map[uint]*pb.UserSummary, error) {
+	cached, missing, err := s.Summaries.Get(ctx, ids)
+	if err != nil {
+		log.Printf("⚠️ user summary cache read failed: %v", err)
+	}
+	if len(missing) > 0 {
+		users, err := s.repo.GetUsersByIDs(missing)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to load users")
+		}
+		fresh := make([]repos.CachedSummary, 0, len(users))
+		for _, u := range users {
+			summary := repos.CachedSummary{ID: u.Id, FirstName: u.Firstname, LastName: u.Lastname,
+				AvatarURL: u.AvatarUrl, Handle: u.Handle}
+			cached[u.Id] = summary
+			fresh = append(fresh, summary)
+		}
+		if err := s.Summaries.Set(ctx, fresh); err != nil {
+			log.Printf("⚠️ user summary cache write failed: %v", err)
+		}
+	}
+
+	found := make(map[uint]*pb.UserSummary, len(cached))
+	for id, summary := range cached {
+		found[id] = &pb.UserSummary{
+			Id:        fmt.Sprint(summary.ID),
+			FirstName: summary.FirstName,
+			LastName:  summary.LastName,
+			AvatarUrl: summary.AvatarURL,
+			Handle:    summary.Handle,
+		}
+	}
+	return found, nil
+}
+
 func (s *UserService) UpdateAvatar(req *pb.UpdateAvatarRequest) (*pb.UpdateAvatarResponse, error) {
 	// avatar → io.Reader
 
@@ -75,86 +256,540 @@ func (s *UserService) UpdateAvatar(req *pb.UpdateAvatarRequest) (*pb.UpdateAvata
 	if err := s.repo.UpdateAvatar(req.Id, url); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update avatar in DB: %v", err)
 	}
+	id, _ := utils.StringToUint(req.Id)
+	s.invalidateSummary(id)
 
 	return &pb.UpdateAvatarResponse{
 		AvatarUrl: url,
 	}, nil
 }
 
-func (s *UserService) FollowUser(ctx context.Context, followerID, followingID string) error {
+// FollowUser — на закрытый аккаунт вместо подписки создаётся заявка (requested == true)
+func (s *UserService) FollowUser(ctx context.Context, followerID, followingID string) (bool, error) {
 
 	if followerID == followingID {
-		return fmt.Errorf("cannot follow yourself")
+		return false, fmt.Errorf("cannot follow yourself")
+	}
+
+	follower, _ := utils.StringToUint(followerID)
+	following, _ := utils.StringToUint(followingID)
+
+	if blocked, err := s.repo.IsBlocked(follower, following); err != nil {
+		return false, status.Error(codes.Internal, "failed to check block")
+	} else if blocked {
+		return false, status.Error(codes.PermissionDenied, "cannot follow this user")
+	}
+
+	if target, err := s.repo.GetUser(following); err == nil && target.IsPrivate {
+		if ok, err := s.repo.IsFollowing(follower, following); err != nil {
+			return false, status.Error(codes.Internal, "failed to check follow")
+		} else if ok {
+			return false, status.Error(codes.AlreadyExists, "already following")
+		}
+		created, err := s.repo.SaveFollowRequest(follower, following)
+		if err != nil {
+			return false, status.Error(codes.Internal, "failed to save follow request")
+		}
+		// повтор запроса клиентом не должен слать владельцу ещё одно уведомление
+		if created {
+			s.notify(ctx, followerID, followingID, "follow_request",
+				fmt.Sprintf("User %s wants to follow you", followerID))
+		}
+		return true, nil
+	}
+
+	created, err := s.repo.FollowByUserId(follower, following)
+	if err != nil {
+		return false, status.Error(codes.Internal, "failed to follow")
+	}
+	if !created {
+		return false, status.Error(codes.AlreadyExists, "already following")
 	}
+	s.notify(ctx, followerID, followingID, "follow", fmt.Sprintf("User %s followed you", followerID))
+	return false, nil
+}
+
+// UnfollowUser — заодно отменяет ещё не одобренную заявку
+func (s *UserService) UnfollowUser(followerID, followingID string) error {
 
 	follower, _ := utils.StringToUint(followerID)
 	following, _ := utils.StringToUint(followingID)
-	if err := s.repo.FollowByUserId(follower, following); err != nil {
+	if err := s.repo.DeleteFollow(follower, following); err != nil {
 		return err
 	}
+	if _, err := s.repo.DeleteFollowRequest(follower, following); err != nil {
+		return err
+	}
+	return nil
+}
 
-	md := metadata.New(map[string]string{"user-id": followerID})
+// notify — уведомление от имени fromID; недоступность сервиса уведомлений не ошибка
+func (s *UserService) notify(ctx context.Context, fromID, toID, kind, content string) {
+	md := metadata.New(map[string]string{"user-id": fromID})
 	ctxWithUser := metadata.NewOutgoingContext(ctx, md)
 
 	notifClient, err := s.clients.GetNotifClient("localhost:50057")
 	if err == nil {
 		_, _ = notifClient.CreateNotification(ctxWithUser,
 			&notificationpb.CreateNotificationRequest{
-				UserId:      followingID,
-				Type:        "follow",
-				ReferenceId: followerID,
-				Content:     fmt.Sprintf("User %s followed you", followerID),
+				UserId:      toID,
+				Type:        kind,
+				ReferenceId: fromID,
+				Content:     content,
 			})
 	}
+}
 
+// SetAccountPrivacy — при открытии аккаунта ожидающие заявки одобряются
+func (s *UserService) SetAccountPrivacy(ctx context.Context, userID string, private bool) error {
+	id, err := utils.StringToUint(userID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := s.repo.SetPrivacy(id, private); err != nil {
+		return status.Error(codes.Internal, "failed to update privacy")
+	}
+	if private {
+		return nil
+	}
+	approved, err := s.repo.ApproveAllFollowRequests(id)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to approve follow requests")
+	}
+	for _, requester := range approved {
+		s.notify(ctx, userID, fmt.Sprint(requester), "follow_request_approved",
+			fmt.Sprintf("User %s approved your follow request", userID))
+	}
 	return nil
 }
 
-func (s *UserService) UnfollowUser(followerID, followingID string) error {
+func (s *UserService) ListFollowRequests(userID string, req *pb.ListFollowRequestsRequest) (*pb.FollowRequests, error) {
+	id, err := utils.StringToUint(userID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+	requests, err := s.repo.ListFollowRequests(id, after, size)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load follow requests")
+	}
 
-	follower, _ := utils.StringToUint(followerID)
-	following, _ := utils.StringToUint(followingID)
-	if err := s.repo.DeleteFollow(follower, following); err != nil {
+	resp := &pb.FollowRequests{}
+	if len(requests) > size {
+		requests = requests[:size]
+		last := requests[size-1]
+		resp.NextPageToken = utils.EncodePageToken(last.CreatedAt, last.ID)
+	}
+	for _, r := range requests {
+		resp.Requests = append(resp.Requests, &pb.FollowRequest{
+			UserId:    fmt.Sprint(r.RequesterID),
+			CreatedAt: r.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func (s *UserService) ApproveFollowRequest(ctx context.Context, userID, requesterID string) error {
+	target, err := utils.StringToUint(userID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	requester, err := utils.StringToUint(requesterID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	approved, err := s.repo.ApproveFollowRequest(requester, target)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to approve follow request")
+	}
+	if !approved {
+		return status.Error(codes.NotFound, "follow request not found")
+	}
+	s.notify(ctx, userID, requesterID, "follow_request_approved",
+		fmt.Sprintf("User %s approved your follow request", userID))
+	return nil
+}
+
+func (s *UserService) RejectFollowRequest(userID, requesterID string) error {
+	target, err := utils.StringToUint(userID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	requester, err := utils.StringToUint(requesterID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	deleted, err := s.repo.DeleteFollowRequest(requester, target)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to reject follow request")
+	}
+	if deleted == 0 {
+		return status.Error(codes.NotFound, "follow request not found")
+	}
+	return nil
+}
+
+// parsePair — id текущего пользователя и того, над кем действие; себя блокировать и глушить нельзя
+func parsePair(userID, otherID string) (uint, uint, error) {
+	user, err := utils.StringToUint(userID)
+	if err != nil {
+		return 0, 0, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	other, err := utils.StringToUint(otherID)
+	if err != nil {
+		return 0, 0, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if user == other {
+		return 0, 0, status.Error(codes.InvalidArgument, "cannot do this to yourself")
+	}
+	return user, other, nil
+}
+
+func (s *UserService) BlockUser(userID, targetID string) error {
+	user, target, err := parsePair(userID, targetID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Block(user, target); err != nil {
+		return status.Error(codes.Internal, "failed to block user")
+	}
+	return nil
+}
+
+func (s *UserService) UnblockUser(userID, targetID string) error {
+	user, target, err := parsePair(userID, targetID)
+	if err != nil {
 		return err
 	}
+	if err := s.repo.Unblock(user, target); err != nil {
+		return status.Error(codes.Internal, "failed to unblock user")
+	}
 	return nil
 }
 
-func (s *UserService) GetFollowing(userID string) ([]*pb.User, error) {
-	users, err := s.repo.GetFollowing(userID)
+func (s *UserService) MuteUser(userID, targetID string) error {
+	user, target, err := parsePair(userID, targetID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if err := s.repo.Mute(user, target); err != nil {
+		return status.Error(codes.Internal, "failed to mute user")
 	}
+	return nil
+}
 
-	// Преобразуем в protobuf
-	var pbUsers []*pb.User
-	for _, u := range users {
-		pbUsers = append(pbUsers, &pb.User{
-			Id: fmt.Sprint(u.Id),
+func (s *UserService) UnmuteUser(userID, targetID string) error {
+	user, target, err := parsePair(userID, targetID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Unmute(user, target); err != nil {
+		return status.Error(codes.Internal, "failed to unmute user")
+	}
+	return nil
+}
+
+func (s *UserService) ListBlocked(userID string, req *pb.ListBlockedRequest) (*pb.BlockedUsers, error) {
+	id, err := utils.StringToUint(userID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+	blocks, err := s.repo.ListBlocked(id, after, size)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load blocked users")
+	}
+
+	resp := &pb.BlockedUsers{}
+	if len(blocks) > size {
+		blocks = blocks[:size]
+		last := blocks[size-1]
+		resp.NextPageToken = utils.EncodePageToken(last.CreatedAt, last.ID)
+	}
+	for _, b := range blocks {
+		resp.Users = append(resp.Users, &pb.BlockedUser{
+			UserId:    fmt.Sprint(b.BlockedID),
+			CreatedAt: b.CreatedAt.Format(time.RFC3339),
 		})
 	}
-	return pbUsers, nil
+	return resp, nil
+}
+
+// CheckRelations — для остальных сервисов, результат они кэшируют (pkg/relations)
+func (s *UserService) CheckRelations(req *pb.CheckRelationsRequest) (*pb.CheckRelationsResponse, error) {
+	user, err := utils.StringToUint(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	others := make([]uint, 0, len(req.OtherIds))
+	for _, o := range req.OtherIds {
+		id, err := utils.StringToUint(o)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		}
+		others = append(others, id)
+	}
+
+	resp := &pb.CheckRelationsResponse{}
+	if len(others) == 0 {
+		return resp, nil
+	}
+	blocked, muted, err := s.repo.Relations(user, others)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check relations")
+	}
+	for _, id := range blocked {
+		resp.BlockedIds = append(resp.BlockedIds, fmt.Sprint(id))
+	}
+	for _, id := range muted {
+		resp.MutedIds = append(resp.MutedIds, fmt.Sprint(id))
+	}
+	return resp, nil
+}
+
+// GetHiddenAuthors — для post сервиса: чьи посты viewer видеть не должен
+func (s *UserService) GetHiddenAuthors(req *pb.GetHiddenAuthorsRequest) (*pb.GetHiddenAuthorsResponse, error) {
+	var viewer uint
+	if req.ViewerId != "" {
+		id, err := utils.StringToUint(req.ViewerId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid viewer id")
+		}
+		viewer = id
+	}
+	authors := make([]uint, 0, len(req.AuthorIds))
+	for _, a := range req.AuthorIds {
+		id, err := utils.StringToUint(a)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid author id")
+		}
+		authors = append(authors, id)
+	}
+
+	resp := &pb.GetHiddenAuthorsResponse{}
+	if len(authors) == 0 {
+		return resp, nil
+	}
+	hidden, err := s.repo.HiddenAuthors(viewer, authors)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check authors")
+	}
+	for _, id := range hidden {
+		resp.AuthorIds = append(resp.AuthorIds, fmt.Sprint(id))
+	}
+	return resp, nil
+}
+
+func (s *UserService) GetFollowers(viewerID string, req *pb.GetFollowersRequest) (*pb.Users, error) {
+	return s.followList(viewerID, req.Id, true, req.PageSize, req.PageToken)
+}
+
+func (s *UserService) GetFollowing(viewerID string, req *pb.GetFollowingRequest) (*pb.Users, error) {
+	return s.followList(viewerID, req.Id, false, req.PageSize, req.PageToken)
+}
+
+// followList — страница подписчиков или подписок в порядке подписки, новые первыми
+func (s *UserService) followList(viewerID, userID string, followers bool, pageSize int32, pageToken string) (*pb.Users, error) {
+	id, err := utils.StringToUint(userID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if err := s.checkFollowsVisible(viewerID, id); err != nil {
+		return nil, err
+	}
+	after, err := utils.DecodePageToken(pageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(pageSize)
+	follows, err := s.repo.ListFollows(id, followers, after, size)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load follows")
+	}
+
+	resp := &pb.Users{}
+	if len(follows) > size {
+		follows = follows[:size]
+		last := follows[size-1]
+		resp.NextPageToken = utils.EncodePageToken(last.CreatedAt, last.ID)
+	}
+	if len(follows) == 0 {
+		return resp, nil
+	}
+
+	ids := make([]uint, 0, len(follows))
+	for _, f := range follows {
+		if followers {
+			ids = append(ids, f.FollowerID)
+		} else {
+			ids = append(ids, f.FollowingID)
+		}
+	}
+	found, err := s.repo.GetUsersByIDs(ids)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load users")
+	}
+	byID := make(map[uint]*model.User, len(found))
+	for _, u := range found {
+		byID[u.Id] = u
+	}
+	// профиль мог ещё не заполняться — отдаём хотя бы id
+	users := make([]*model.User, 0, len(ids))
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			users = append(users, u)
+		} else {
+			users = append(users, &model.User{Id: id})
+		}
+	}
+	resp.Users, err = s.profiles(viewerID, users)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// checkFollowsVisible — списки закрытого аккаунта видят только он сам и его подписчики
+func (s *UserService) checkFollowsVisible(viewerID string, userID uint) error {
+	user, err := s.repo.GetUser(userID)
+	if err != nil || !user.IsPrivate || viewerID == fmt.Sprint(userID) {
+		return nil
+	}
+	viewer, err := utils.StringToUint(viewerID)
+	if err != nil {
+		return status.Error(codes.PermissionDenied, "account is private")
+	}
+	ok, err := s.repo.IsFollowing(viewer, userID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to check follow")
+	}
+	if !ok {
+		return status.Error(codes.PermissionDenied, "account is private")
+	}
+	return nil
+}
+
+// GetFollowRelationship — подписки между двумя пользователями в обе стороны.
+// Заявки заполняются, только если спрашивает один из участников.
+func (s *UserService) GetFollowRelationship(viewerID string, req *pb.GetFollowRelationshipRequest) (*pb.FollowRelationship, error) {
+	a, err := utils.StringToUint(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	b, err := utils.StringToUint(req.OtherId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	resp := &pb.FollowRelationship{}
+	if resp.Following, err = s.repo.IsFollowing(a, b); err != nil {
+		return nil, status.Error(codes.Internal, "failed to check follow")
+	}
+	if resp.FollowedBy, err = s.repo.IsFollowing(b, a); err != nil {
+		return nil, status.Error(codes.Internal, "failed to check follow")
+	}
+	if viewerID != req.UserId && viewerID != req.OtherId {
+		return resp, nil
+	}
+	if resp.Requested, err = s.repo.HasFollowRequest(a, b); err != nil {
+		return nil, status.Error(codes.Internal, "failed to check follow request")
+	}
+	if resp.RequestReceived, err = s.repo.HasFollowRequest(b, a); err != nil {
+		return nil, status.Error(codes.Internal, "failed to check follow request")
+	}
+	return resp, nil
 }
 
-func (s *UserService) GetAllUsers() (*pb.Users, error) {
-	users, err := s.repo.GetAllUsers()
+func (s *UserService) ListUsers(viewerID string, req *pb.ListUsersRequest) (*pb.Users, error) {
+	after, err := utils.DecodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	size := utils.PageSize(req.PageSize)
+	users, err := s.repo.ListUsers(after, size)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load users")
+	}
+
+	next := ""
+	if len(users) > size {
+		users = users[:size]
+		last := users[size-1]
+		next = utils.EncodePageToken(last.CreatedAt, last.Id)
+	}
+
+	pbUsers, err := s.profiles(viewerID, users)
 	if err != nil {
 		return nil, err
 	}
+	return &pb.Users{Users: pbUsers, NextPageToken: next}, nil
+}
+
+// DeleteUser — шаг саги удаления аккаунта. Аватар и обложка удаляются до строки профиля:
+// если S3 недоступен, шаг повторится и ссылки на объекты ещё будут в БД.
+func (s *UserService) DeleteUser(req *pb.DeleteUserRequest) error {
+	id, err := utils.StringToUint(req.Id)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	if user, err := s.repo.GetUser(id); err == nil {
+		for _, url := range []string{user.AvatarUrl, user.CoverUrl} {
+			key, ok := storage.KeyFromURL(url)
+			if !ok {
+				continue
+			}
+			s3, err := storage.NewS3Client()
+			if err != nil {
+				return status.Error(codes.Internal, "cannot to create client")
+			}
+			if err := s3.DeleteFile(key); err != nil {
+				return status.Errorf(codes.Unavailable, "failed to delete %s: %v", key, err)
+			}
+		}
+	}
 
-	var pbUsers []*pb.User
-	for _, v := range users {
-		pbUsers = append(
-			pbUsers,
-			&pb.User{
-				Id:        fmt.Sprint(v.Id),
-				FirstName: v.Firstname,
-				LastName:  v.Lastname,
-				BirthDate: v.BirthDate,
-				Bio:       v.Bio,
-			},
-		)
+	if err := s.repo.DeleteUserData(id); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete user: %v", err)
 	}
+	s.invalidateSummary(id)
+	return nil
+}
 
-	return &pb.Users{Users: pbUsers}, nil
+// ExportUserData — профиль и подписки для архива персональных данных.
+// Профиль может отсутствовать (не заполнялся), тогда выгружаются только подписки.
+func (s *UserService) ExportUserData(req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	id, err := utils.StringToUint(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	resp := &pb.ExportUserDataResponse{}
+	if user, err := s.repo.GetUser(id); err == nil {
+		profiles, err := s.profiles(req.UserId, []*model.User{user})
+		if err != nil {
+			return nil, err
+		}
+		resp.Profile = profiles[0]
+	}
+
+	following, followers, err := s.repo.FollowIDs(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load follows")
+	}
+	for _, f := range following {
+		resp.Following = append(resp.Following, fmt.Sprint(f))
+	}
+	for _, f := range followers {
+		resp.Followers = append(resp.Followers, fmt.Sprint(f))
+	}
+	return resp, nil
 }